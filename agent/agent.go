@@ -0,0 +1,317 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+/*
+Package agent ships errors of the `sourceerror` package to a local
+collector daemon listening on a unix domain socket, so that the
+application doesn't deal with network log shipping itself.
+
+The errors are sent as newline-delimited JSON objects by a background
+goroutine: the application only appends them to a bounded queue and
+never blocks, while the connection is (re-)established with an
+exponential backoff whenever the collector isn't available.
+*/
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `Sink` streams errors to a local collector (see `New()`).
+	Sink struct {
+		path    string
+		mtx     sync.RWMutex  // guards `closed` and sending to `queue`
+		closed  bool          // whether `Close()` was called
+		queue   chan []byte   // the pending JSON lines
+		stop    chan struct{} // closed by `Close()`
+		done    chan struct{} // closed when `run()` returns
+		dropped atomic.Uint64 // number of discarded lines
+	}
+
+	// `tText` is the JSON line of an error rendered by the
+	// `sourceerror` package (see `Sink.Write()`).
+	tText struct {
+		Text string `json:"text"`
+	}
+
+	// `tPlain` is the JSON line of an error not created by the
+	// `sourceerror` package (see `Sink.Send()`).
+	tPlain struct {
+		Message string `json:"message"`
+	}
+)
+
+const (
+	// `DefaultQueueSize` is the number of pending errors used if
+	// `New()` is called with a queue size less than one.
+	DefaultQueueSize = 1024
+
+	// The delays between two connection attempts.
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 30 * time.Second
+
+	// The max. time to wait for the collector to accept a line.
+	writeTimeout = 5 * time.Second
+)
+
+var (
+	// `ErrClosed` is returned when sending to a closed sink.
+	ErrClosed = errors.New("agent: sink closed")
+
+	// `ErrQueueFull` is returned when an error is dropped because the
+	// collector can't keep up (or isn't available).
+	ErrQueueFull = errors.New("agent: queue full")
+)
+
+// `Close()` stops the sink after trying to send the pending errors;
+// errors which can't be sent immediately are dropped.
+//
+// Returns:
+// - `error`: Always `nil`.
+func (s *Sink) Close() error {
+	s.mtx.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.stop)
+		close(s.queue)
+	}
+	s.mtx.Unlock()
+	<-s.done
+
+	return nil
+} // Close()
+
+// `Dropped()` returns the number of errors discarded because the queue
+// was full or the collector wasn't available when the sink was closed.
+//
+// Returns:
+// - `uint64`: The number of dropped errors.
+func (s *Sink) Dropped() uint64 {
+	return s.dropped.Load()
+} // Dropped()
+
+// `enqueue()` appends the given JSON line to the queue without
+// blocking.
+//
+// Parameters:
+// - `aLine`: The newline terminated JSON object to send.
+//
+// Returns:
+// - `error`: `ErrClosed`, `ErrQueueFull`, or `nil` on success.
+func (s *Sink) enqueue(aLine []byte) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+	select {
+	case s.queue <- aLine:
+		return nil
+	default:
+		s.dropped.Add(1)
+		return ErrQueueFull
+	}
+} // enqueue()
+
+// `pause()` waits for the given backoff (unless the sink is closed
+// meanwhile) and doubles it for the next time.
+//
+// Parameters:
+// - `aBackoff`: The current delay between two connection attempts.
+//
+// Returns:
+// - `bool`: `true` if the sink is still running, `false` otherwise.
+func (s *Sink) pause(aBackoff *time.Duration) bool {
+	if s.stopped() {
+		return false
+	}
+	select {
+	case <-time.After(*aBackoff):
+	case <-s.stop:
+	}
+	*aBackoff = min(2*(*aBackoff), maxBackoff)
+
+	return !s.stopped()
+} // pause()
+
+// `run()` sends the queued lines to the collector until the sink is
+// closed.
+//
+// Failed connection attempts as well as failed writes are retried
+// with an exponential backoff while the sink is running; a line the
+// collector received partially isn't sent again since that would
+// corrupt the stream. Once the sink is closed, the remaining lines are
+// sent only as long as the connection works.
+func (s *Sink) run() {
+	defer close(s.done)
+
+	var (
+		conn net.Conn
+		sent int // the lines sent on the current connection
+	)
+	backoff := minBackoff
+	for line := range s.queue {
+		for {
+			if nil == conn {
+				if s.stopped() {
+					s.dropped.Add(1)
+					break
+				}
+				c, err := net.Dial("unix", s.path)
+				if nil != err {
+					if !s.pause(&backoff) {
+						s.dropped.Add(1)
+						break
+					}
+					continue
+				}
+				conn, sent = c, 0
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			n, err := conn.Write(line)
+			if nil == err {
+				if sent++; 1 < sent {
+					// the connection is working, not just accepted
+					backoff = minBackoff
+				}
+				break
+			}
+			_ = conn.Close()
+			conn = nil
+			if (0 < n) || !s.pause(&backoff) {
+				// a partially sent line can't be repeated, and a closed
+				// sink doesn't retry
+				s.dropped.Add(1)
+				break
+			}
+		}
+	}
+	if nil != conn {
+		_ = conn.Close()
+	}
+} // run()
+
+// `Send()` queues the given error to be sent to the collector.
+//
+// Errors of the `sourceerror` package are sent as marshalled by
+// `ErrSource.MarshalJSON()`, all others as an object holding the
+// error's "message".
+//
+// Parameters:
+// - `aErr`: The error to send.
+//
+// Returns:
+// - `error`: `ErrClosed`, `ErrQueueFull`, a marshalling error, or
+// `nil` on success.
+func (s *Sink) Send(aErr error) error {
+	if nil == aErr {
+		return nil
+	}
+
+	var (
+		line []byte
+		err  error
+	)
+	if se, ok := sourceerror.AsSource(aErr); ok {
+		line, err = json.Marshal(se)
+	} else {
+		line, err = json.Marshal(tPlain{Message: aErr.Error()})
+	}
+	if nil != err {
+		return err
+	}
+
+	return s.enqueue(append(line, '\n'))
+} // Send()
+
+// `stopped()` tells whether `Close()` was called.
+//
+// Returns:
+// - `bool`: `true` if the sink is closing, `false` otherwise.
+func (s *Sink) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+} // stopped()
+
+// `Write()` implements the `io.Writer` interface, so that the sink can
+// be registered by `sourceerror.RegisterSink()`:
+//
+//	sink := agent.New("/run/collector.sock", 0)
+//	defer sink.Close()
+//	defer sourceerror.RegisterSink(sink, sourceerror.VerbosityFull)()
+//
+// Each call is expected to pass a single rendered error which is sent
+// as an object holding the error's "text".
+//
+// Parameters:
+// - `aText`: The rendered error.
+//
+// Returns:
+// - `int`: The number of bytes accepted.
+// - `error`: `ErrClosed`, `ErrQueueFull`, or `nil` on success.
+func (s *Sink) Write(aText []byte) (int, error) {
+	line, err := json.Marshal(tText{
+		Text: strings.TrimSuffix(string(aText), "\n"),
+	})
+	if nil != err {
+		return 0, err
+	}
+	if err = s.enqueue(append(line, '\n')); nil != err {
+		return 0, err
+	}
+
+	return len(aText), nil
+} // Write()
+
+// --------------------------------------------------------------------------
+
+// `New()` returns a sink sending errors to the collector listening on
+// the given unix domain socket.
+//
+// The sink connects lazily and reconnects with an exponential backoff
+// (up to 30 seconds) whenever the connection fails; meanwhile the
+// errors are queued, and dropped once the queue is full.
+//
+// Parameters:
+// - `aPath`: The collector's socket path.
+// - `aQueueSize`: The max. number of pending errors.
+//
+// Returns:
+// - `*Sink`: The new sink to be stopped by `Close()`.
+func New(aPath string, aQueueSize int) *Sink {
+	if 0 >= aQueueSize {
+		aQueueSize = DefaultQueueSize
+	}
+	result := &Sink{
+		path:  aPath,
+		queue: make(chan []byte, aQueueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go result.run()
+
+	return result
+} // New()
+
+/* _EoF_ */
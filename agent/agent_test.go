@@ -0,0 +1,181 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `socketPath()` returns a socket path short enough for all platforms.
+func socketPath(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "agent")
+	if nil != err {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return filepath.Join(dir, "s")
+} // socketPath()
+
+// `collect()` accepts a single connection and returns the first
+// `aNum` lines received.
+func collect(t *testing.T, aListener net.Listener, aNum int) []map[string]any {
+	_ = aListener.(*net.UnixListener).SetDeadline(time.Now().Add(5 * time.Second))
+	conn, err := aListener.Accept()
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var result []map[string]any
+	scanner := bufio.NewScanner(conn)
+	for (len(result) < aNum) && scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); nil != err {
+			t.Fatalf("collect() got %q: %v", scanner.Text(), err)
+		}
+		result = append(result, line)
+	}
+
+	return result
+} // collect()
+
+func TestSink(t *testing.T) {
+	path := socketPath(t)
+	sink := New(path, 0)
+	defer sink.Close()
+
+	e1 := sourceerror.Wrap(errors.New("some first error"), 0)
+	if err := sink.Send(e1); nil != err {
+		t.Fatalf("Sink.Send() = %v, want <nil>", err)
+	}
+	if n, err := sink.Write([]byte("some second error\n")); (18 != n) || (nil != err) {
+		t.Fatalf("Sink.Write() = %d, %v, want 18, <nil>", n, err)
+	}
+	_ = sink.Send(errors.New("some third error"))
+
+	// the collector starts after the errors were queued
+	listener, err := net.Listen("unix", path)
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	got := collect(t, listener, 3)
+	if 3 != len(got) {
+		t.Fatalf("Sink got %v, want 3 lines", got)
+	}
+	if msg := got[0]["message"]; "some first error" != msg {
+		t.Errorf("Sink.Send() sent message %v, want %q", msg, "some first error")
+	}
+	if _, ok := got[0]["stack"]; !ok {
+		t.Errorf("Sink.Send() sent %v, want a stack", got[0])
+	}
+	if text := got[1]["text"]; "some second error" != text {
+		t.Errorf("Sink.Write() sent text %v, want %q", text, "some second error")
+	}
+	if msg := got[2]["message"]; "some third error" != msg {
+		t.Errorf("Sink.Send() sent message %v, want %q", msg, "some third error")
+	}
+} // TestSink()
+
+func TestSink_Close(t *testing.T) {
+	// not running yet, so the queue doesn't get drained
+	sink := &Sink{
+		path:  socketPath(t),
+		queue: make(chan []byte, 1),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"0", nil, nil},
+		{"1", errors.New("some first error"), nil},
+		{"2", errors.New("some second error"), ErrQueueFull},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sink.Send(tt.err); !errors.Is(got, tt.want) {
+				t.Errorf("%q: Sink.Send() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	// there's no collector, hence the queued error gets dropped
+	go sink.run()
+	if err := sink.Close(); nil != err {
+		t.Errorf("Sink.Close() = %v, want <nil>", err)
+	}
+	if got := sink.Dropped(); 2 != got {
+		t.Errorf("Sink.Dropped() = %d, want 2", got)
+	}
+	if _, err := sink.Write([]byte("some third error")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Sink.Write() = %v, want %v", err, ErrClosed)
+	}
+	_ = sink.Close() // a second call is harmless
+} // TestSink_Close()
+
+func TestSink_run(t *testing.T) {
+	path := socketPath(t)
+	listener, err := net.Listen("unix", path)
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// a collector dropping each connection after the first line
+	var accepted atomic.Int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if nil != err {
+				return
+			}
+			accepted.Add(1)
+			_, _ = bufio.NewReader(conn).ReadBytes('\n')
+			_ = conn.Close()
+		}
+	}()
+
+	sink := New(path, 0)
+	for range 50 {
+		_ = sink.Send(errors.New("some first error"))
+		time.Sleep(10 * time.Millisecond)
+	}
+	start := time.Now()
+	_ = sink.Close()
+
+	if got := accepted.Load(); 10 < got {
+		t.Errorf("Sink connected %d times, want a backoff", got)
+	}
+	if elapsed := time.Since(start); time.Second < elapsed {
+		t.Errorf("Sink.Close() took %v, want less than 1s", elapsed)
+	}
+	if 0 == sink.Dropped() {
+		t.Error("Sink.Dropped() = 0, want the unsent errors")
+	}
+} // TestSink_run()
+
+/* _EoF_ */
@@ -0,0 +1,127 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `AuditEntry` is a wrapping site recorded in audit mode (see
+// `SetAudit()`).
+//
+// The fields are as follows:
+// - `Location`: The location of the errors wrapped.
+// - `Count`: The number of errors wrapped at the location.
+type AuditEntry struct {
+	Location
+	Count uint64
+}
+
+var (
+	// Whether the audit mode is enabled (checked without locking).
+	auditOn atomic.Bool
+
+	// The number of errors wrapped per location.
+	auditCounts map[Location]uint64
+
+	// Guard for `auditCounts`.
+	auditMtx sync.Mutex
+)
+
+// `String()` implements the `Stringer` interface.
+//
+// Returns:
+// - `string`: The entry as "count file:line function".
+func (ae AuditEntry) String() string {
+	return fmt.Sprintf("%8d %s %s", ae.Count, ae.Location, ae.Function)
+} // String()
+
+// --------------------------------------------------------------------------
+
+// `auditLocation()` counts an error wrapped at the given location if
+// the audit mode is enabled.
+//
+// Parameters:
+// - `aLocation`: The location of the wrapped error.
+func auditLocation(aLocation Location) {
+	if !auditOn.Load() {
+		return
+	}
+
+	auditMtx.Lock()
+	if nil != auditCounts {
+		auditCounts[aLocation]++
+	}
+	auditMtx.Unlock()
+} // auditLocation()
+
+// `AuditReport()` returns the wrapping sites recorded since the audit
+// mode was enabled, the most frequent first.
+//
+// The report includes all errors wrapped, even those discarded later
+// on, which helps to find dead or overly chatty wrapping sites e.g.
+// during a refactoring:
+//
+//	sourceerror.SetAudit(true)
+//	// ... run the test suite or a load test ...
+//	for _, entry := range sourceerror.AuditReport() {
+//		fmt.Println(entry)
+//	}
+//
+// Returns:
+// - `[]AuditEntry`: The recorded wrapping sites.
+func AuditReport() []AuditEntry {
+	auditMtx.Lock()
+	result := make([]AuditEntry, 0, len(auditCounts))
+	for loc, count := range auditCounts {
+		result = append(result, AuditEntry{
+			Location: loc,
+			Count:    count,
+		})
+	}
+	auditMtx.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if result[i].File != result[j].File {
+			return result[i].File < result[j].File
+		}
+		return result[i].Line < result[j].Line
+	})
+
+	return result
+} // AuditReport()
+
+// `SetAudit()` enables or disables the audit mode recording the
+// location of each error wrapped by this package (see `AuditReport()`).
+//
+// Enabling the audit mode discards the previously recorded sites;
+// disabling it keeps them for reporting.
+//
+// NOTE: Errors wrapped while locations are disabled (see
+// `Config.SetEnabled()`) have no location and are not recorded.
+//
+// Parameters:
+// - `aEnabled`: Whether to record the wrapping sites.
+func SetAudit(aEnabled bool) {
+	auditMtx.Lock()
+	defer auditMtx.Unlock()
+
+	if aEnabled {
+		auditCounts = make(map[Location]uint64)
+	}
+	auditOn.Store(aEnabled)
+} // SetAudit()
+
+/* _EoF_ */
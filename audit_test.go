@@ -0,0 +1,64 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestAuditReport(t *testing.T) {
+	e := errors.New("some first error")
+	loc := Location{"/src/app/x.go", "app.X", 7}
+	_ = Wrap(e, 0) // not recorded
+
+	SetAudit(true)
+	for range 3 {
+		_ = Wrap(e, 0)
+	}
+	_ = WithLocation(e, loc)
+	SetAudit(false)
+	_ = WithLocation(e, loc) // not recorded
+
+	report := AuditReport()
+	tests := []struct {
+		name      string
+		idx       int
+		wantFunc  string
+		wantCount uint64
+	}{
+		{"0", 0, thisPackage + ".TestAuditReport", 3},
+		{"1", 1, "app.X", 1},
+		// TODO: Add test cases.
+	}
+	if 2 != len(report) {
+		t.Fatalf("AuditReport() = %v, want 2 entries", report)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := report[tt.idx]
+			if (got.Function != tt.wantFunc) || (got.Count != tt.wantCount) {
+				t.Errorf("%q: AuditReport()[%d] = %v, want %q %d",
+					tt.name, tt.idx, got, tt.wantFunc, tt.wantCount)
+			}
+			if !strings.Contains(got.String(), tt.wantFunc) {
+				t.Errorf("%q: AuditEntry.String() = %q", tt.name, got.String())
+			}
+		})
+	}
+
+	SetAudit(true)
+	if got := AuditReport(); 0 != len(got) {
+		t.Errorf("SetAudit(true) kept %d entries", len(got))
+	}
+	SetAudit(false)
+} // TestAuditReport()
+
+/* _EoF_ */
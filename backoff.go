@@ -0,0 +1,174 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tRetryAfter` is an error carrying a server's retry hint (see
+	// `WithRetryAfter()`).
+	tRetryAfter struct {
+		err   error
+		delay time.Duration
+	}
+)
+
+var (
+	// `UnavailableBackoff` is the delay `BackoffHint()` suggests for
+	// errors wrapping `ErrUnavailable` without an explicit hint.
+	UnavailableBackoff = time.Second
+)
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+// - `string`: The wrapped error's text.
+func (ra tRetryAfter) Error() string {
+	return ra.err.Error()
+} // Error()
+
+// `RetryAfter()` returns the delay the server asked to wait before
+// retrying.
+//
+// Returns:
+// - `time.Duration`: The delay before the next attempt.
+func (ra tRetryAfter) RetryAfter() time.Duration {
+	return ra.delay
+} // RetryAfter()
+
+// `Unwrap()` returns the wrapped error.
+//
+// Returns:
+// - `error`: The wrapped error.
+func (ra tRetryAfter) Unwrap() error {
+	return ra.err
+} // Unwrap()
+
+// --------------------------------------------------------------------------
+
+// `BackoffHint()` returns the delay a retry loop should wait before
+// trying the failed operation again:
+//
+//	for attempt := 0; 3 > attempt; attempt++ {
+//		if err = call(); nil == err {
+//			break
+//		}
+//		delay, ok := sourceerror.BackoffHint(err)
+//		if !ok {
+//			break // not worth retrying
+//		}
+//		time.Sleep(max(delay, minDelay))
+//	}
+//
+// The first error in the chain providing a `RetryAfter()
+// time.Duration` method (e.g. added by `WithRetryAfter()`) determines
+// the hint if it returns a positive delay; otherwise errors wrapping
+// `ErrUnavailable` result in `UnavailableBackoff`.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `time.Duration`: The suggested delay.
+// - `bool`: `true` if there is a hint, `false` otherwise.
+func BackoffHint(aErr error) (time.Duration, bool) {
+	if nil == aErr {
+		return 0, false
+	}
+
+	var hinter interface{ RetryAfter() time.Duration }
+	if errors.As(aErr, &hinter) {
+		if delay := hinter.RetryAfter(); 0 < delay {
+			return delay, true
+		}
+	}
+	if errors.Is(aErr, ErrUnavailable) {
+		return UnavailableBackoff, true
+	}
+
+	return 0, false
+} // BackoffHint()
+
+// `ParseRetryAfter()` returns the delay requested by the given
+// response's `Retry-After` header, which is honoured for the status
+// codes "429 Too Many Requests" and "503 Service Unavailable" only.
+//
+// Parameters:
+// - `aResponse`: The HTTP response to inspect.
+//
+// Returns:
+// - `time.Duration`: The requested delay.
+// - `bool`: `true` if the response carries a valid hint, `false` otherwise.
+func ParseRetryAfter(aResponse *http.Response) (time.Duration, bool) {
+	return parseRetryAfter(aResponse, time.Now())
+} // ParseRetryAfter()
+
+// `parseRetryAfter()` returns the delay requested by the given
+// response's `Retry-After` header relative to the given time.
+//
+// Parameters:
+// - `aResponse`: The HTTP response to inspect.
+// - `aNow`: The time to compute the delay of an HTTP date from.
+//
+// Returns:
+// - `time.Duration`: The requested delay.
+// - `bool`: `true` if the response carries a valid hint, `false` otherwise.
+func parseRetryAfter(aResponse *http.Response, aNow time.Time) (time.Duration, bool) {
+	if nil == aResponse {
+		return 0, false
+	}
+	switch aResponse.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+	default:
+		return 0, false
+	}
+
+	value := strings.TrimSpace(aResponse.Header.Get("Retry-After"))
+	if "" == value {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseUint(value, 10, 32); nil == err {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); nil == err {
+		return max(date.Sub(aNow), 0), true
+	}
+
+	return 0, false
+} // parseRetryAfter()
+
+// `WithRetryAfter()` attaches the given retry hint (e.g. taken from a
+// response by `ParseRetryAfter()`) to the given error, so that retry
+// loops can respect it (see `BackoffHint()`):
+//
+//	if delay, ok := sourceerror.ParseRetryAfter(resp); ok {
+//		err = sourceerror.WithRetryAfter(err, delay)
+//	}
+//
+// Parameters:
+// - `aErr`: The error to annotate.
+// - `aDelay`: The delay before the next attempt.
+//
+// Returns:
+// - `error`: The annotated error, or `nil` if `aErr` is `nil`.
+func WithRetryAfter(aErr error, aDelay time.Duration) error {
+	if nil == aErr {
+		return nil
+	}
+
+	return tRetryAfter{err: aErr, delay: aDelay}
+} // WithRetryAfter()
+
+/* _EoF_ */
@@ -0,0 +1,91 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestBackoffHint(t *testing.T) {
+	e1 := errors.New("some first error")
+	e2 := WithRetryAfter(Wrap(e1, 0), 3*time.Second)
+
+	tests := []struct {
+		name   string
+		err    error
+		want   time.Duration
+		wantOk bool
+	}{
+		{"0", nil, 0, false},
+		{"1", e1, 0, false},
+		{"2", e2, 3 * time.Second, true},
+		{"3", Wrap(e2, 0), 3 * time.Second, true},
+		{"4", fmt.Errorf("%w: %w", ErrUnavailable, e1), UnavailableBackoff, true},
+		{"5", WithRetryAfter(ErrUnavailable, 0), UnavailableBackoff, true},
+		{"6", fmt.Errorf("%w: %w", ErrUnavailable, e2), 3 * time.Second, true},
+		{"7", WithRetryAfter(nil, time.Second), 0, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := BackoffHint(tt.err)
+			if (got != tt.want) || (ok != tt.wantOk) {
+				t.Errorf("%q: BackoffHint() = %v, %v, want %v, %v",
+					tt.name, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+
+	if !errors.Is(e2, e1) || (e2.Error() != e1.Error()) {
+		t.Errorf("WithRetryAfter() = %q, want a wrapper of %q", e2, e1)
+	}
+} // TestBackoffHint()
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	response := func(aStatus int, aValue string) *http.Response {
+		result := &http.Response{StatusCode: aStatus, Header: http.Header{}}
+		if "" != aValue {
+			result.Header.Set("Retry-After", aValue)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		response *http.Response
+		want     time.Duration
+		wantOk   bool
+	}{
+		{"0", nil, 0, false},
+		{"1", response(http.StatusTooManyRequests, ""), 0, false},
+		{"2", response(http.StatusTooManyRequests, "120"), 2 * time.Minute, true},
+		{"3", response(http.StatusServiceUnavailable, "Wed, 01 May 2024 12:00:30 GMT"), 30 * time.Second, true},
+		{"4", response(http.StatusServiceUnavailable, "Wed, 01 May 2024 11:00:00 GMT"), 0, true},
+		{"5", response(http.StatusInternalServerError, "120"), 0, false},
+		{"6", response(http.StatusTooManyRequests, "soon"), 0, false},
+		{"7", response(http.StatusTooManyRequests, "-5"), 0, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.response, now)
+			if (got != tt.want) || (ok != tt.wantOk) {
+				t.Errorf("%q: parseRetryAfter() = %v, %v, want %v, %v",
+					tt.name, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+} // Test_parseRetryAfter()
+
+/* _EoF_ */
@@ -0,0 +1,68 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Blame()` returns the location of the first frame belonging to the
+// given module, e.g. to attribute a failure to the right team even if
+// the error was wrapped in shared middleware.
+//
+// The `ErrSource` layers of the error's chain are inspected from the
+// outside in; for each layer its own location is checked first, and
+// then the frames of its call stack.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+// - `aModulePrefix`: The module (or package) path to look for, e.g.
+// "github.com/user/project".
+//
+// Returns:
+// - `Location`: The location within the given module.
+// - `bool`: `true` if a matching location was found, `false` otherwise.
+func Blame(aErr error, aModulePrefix string) (Location, bool) {
+	var (
+		result Location
+		found  bool
+	)
+	aModulePrefix = strings.TrimSuffix(aModulePrefix, "/")
+
+	_ = walkChain(aErr, func(aLink error) bool {
+		var se *ErrSource
+		switch link := aLink.(type) {
+		case *ErrSource:
+			if nil == link {
+				return false
+			}
+			se = link
+		case ErrSource:
+			se = &link
+		default:
+			return true
+		}
+
+		if ("" != se.File) && inModule(se.Function, aModulePrefix) {
+			result, found = se.Location(), true
+			return false
+		}
+		for frame := range se.All() {
+			if inModule(frame.Function, aModulePrefix) {
+				result, found = frame.Location(), true
+				return false
+			}
+		}
+		return true
+	})
+
+	return result, found
+} // Blame()
+
+/* _EoF_ */
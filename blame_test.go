@@ -0,0 +1,58 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestBlame(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	cl2 := fmt.Errorf("outer: %w", Wrap(fmt.Errorf("inner: %w", cl1), 0))
+	cl3 := WithLocation(cl1, Location{"/x/mw.go", "example.com/mw.Handler", 7})
+
+	tests := []struct {
+		name     string
+		err      error
+		module   string
+		wantFunc string
+		wantOK   bool
+	}{
+		{"0", nil, "testing", "", false},
+		{"1", e, "testing", "", false},
+		{"2", cl1, thisPackage, thisPackage + ".TestBlame", true},
+		{"3", cl1, "testing/", "testing.tRunner", true},
+		{"4", cl1, "example.com", "", false},
+		{"5", cl2, "testing", "testing.tRunner", true},
+		{"6", cl3, "example.com/mw", "example.com/mw.Handler", true},
+		{"7", cl3, "testing", "testing.tRunner", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Blame(tt.err, tt.module)
+			if ok != tt.wantOK {
+				t.Errorf("%q: Blame() = %v, want %v", tt.name, ok, tt.wantOK)
+				return
+			}
+			if got.Function != tt.wantFunc {
+				t.Errorf("%q: Blame() function = %q, want %q",
+					tt.name, got.Function, tt.wantFunc)
+			}
+			if ok && ((0 >= got.Line) || ("" == got.File)) {
+				t.Errorf("%q: Blame() location = %v", tt.name, got)
+			}
+		})
+	}
+} // TestBlame()
+
+/* _EoF_ */
@@ -0,0 +1,106 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"regexp"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// The boot phases are recognised from an error's call stack, hence
+// errors wrapped without call stack aren't labelled.
+const (
+	// The phase of errors wrapped while the packages are initialised,
+	// i.e. by `init()` functions or package level variables.
+	PhaseInit = "init"
+
+	// The phase of errors wrapped by a test binary's `TestMain()`
+	// outside of any test.
+	PhaseTestMain = "TestMain"
+)
+
+var (
+	// The start of the runtime's package initialisation frames.
+	stackDoInit = []byte("\nruntime.doInit")
+
+	// The part of the function line of a package's initialisation
+	// frame checked before `reInitFrame()` is used.
+	stackInitFunc = []byte(".init")
+
+	// Regular expression matching the function line of a package's
+	// initialisation frame, e.g. "example.com/app.init.0()"; it's
+	// compiled on first use.
+	reInitFrame = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`^[^\s()]+\.init(?:\.\d+)?\(\)$`)
+	})
+
+	// The start of a `TestMain()` frame.
+	stackTestMain = []byte(".TestMain(")
+
+	// The start of the frame running a test function.
+	stackTRunner = []byte("\ntesting.tRunner(")
+
+	// The frame of the generated `main()` of test binaries.
+	stackTestMainMain = []byte("\nmain.main()\n")
+)
+
+// `bootPhase()` checks whether the given call stack was captured while
+// the program was initialising its packages or running `TestMain()`.
+//
+// Parameters:
+// - `aStack`: The call stack as returned by `debug.Stack()`.
+//
+// Returns:
+// - `string`: The recognised phase or an empty string.
+// - `int`: The length of the stack without the runtime's bootstrap
+// frames (only valid if a phase was recognised).
+func bootPhase(aStack []byte) (string, int) {
+	if idx := bytes.Index(aStack, stackDoInit); 0 <= idx {
+		// runtime frames shown due to `GOTRACEBACK=system`
+		return PhaseInit, idx + 1
+	}
+	if last := lastFunction(aStack); bytes.Contains(last, stackInitFunc) &&
+		reInitFrame().Match(last) {
+		return PhaseInit, len(aStack)
+	}
+
+	idx := bytes.Index(aStack, stackTestMain)
+	if (0 > idx) || bytes.Contains(aStack, stackTRunner) {
+		return "", 0
+	}
+	if end := bytes.Index(aStack[idx:], stackTestMainMain); 0 <= end {
+		return PhaseTestMain, idx + end + 1
+	}
+
+	return "", 0
+} // bootPhase()
+
+// `lastFunction()` returns the function line of the given call stack's
+// outermost frame.
+//
+// Parameters:
+// - `aStack`: The call stack as returned by `debug.Stack()`.
+//
+// Returns:
+// - `[]byte`: The outermost frame's function line (if any).
+func lastFunction(aStack []byte) []byte {
+	stack := bytes.TrimRight(aStack, "\n")
+
+	// the function line precedes the outermost frame's file line
+	end := bytes.LastIndexByte(stack, '\n')
+	if 0 > end {
+		return nil
+	}
+	start := bytes.LastIndexByte(stack[:end], '\n') + 1
+
+	return stack[start:end]
+} // lastFunction()
+
+/* _EoF_ */
@@ -0,0 +1,128 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// an error wrapped while the package is initialised
+	initErr = Wrap(errors.New("some init error"), 0)
+)
+
+const (
+	// a call stack captured by `TestMain()`
+	testTestMainStack = `goroutine 1 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:26 +0x5e
+example.com/app.TestMain(0xc000100000)
+	/src/app/main_test.go:12 +0x25
+main.main()
+	_testmain.go:47 +0x1c5
+`
+
+	// a call stack captured by an `init()` function
+	testInitStack = `goroutine 1 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:26 +0x5e
+example.com/app.init.0()
+	/src/app/config.go:12 +0x25
+`
+
+	// a call stack captured by a goroutine started by `init()`
+	testInitGoStack = `goroutine 5 [running]:
+example.com/app.init.0.func1()
+	/src/app/config.go:14 +0x25
+created by example.com/app.init.0 in goroutine 1
+	/src/app/config.go:13 +0x1d
+`
+
+	// a call stack captured by a test function
+	testTestStack = `goroutine 7 [running]:
+example.com/app.TestLoad(0xc000100000)
+	/src/app/load_test.go:20 +0x25
+testing.tRunner(0xc000100000, 0x5c1e40)
+	/usr/local/go/src/testing/testing.go:1690 +0xf4
+`
+)
+
+func Test_bootPhase(t *testing.T) {
+	testMainEnd := bytes.Index([]byte(testTestMainStack), []byte("main.main()"))
+
+	tests := []struct {
+		name      string
+		stack     string
+		wantPhase string
+		wantEnd   int
+	}{
+		{"0", testTestMainStack, PhaseTestMain, testMainEnd},
+		{"1", testTestStack, "", 0},
+		{"2", testTestMainStack[:testMainEnd], "", 0},
+		{"3", "", "", 0},
+		{"4", testInitStack, PhaseInit, len(testInitStack)},
+		{"5", testInitGoStack, "", 0},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPhase, gotEnd := bootPhase([]byte(tt.stack))
+			if (gotPhase != tt.wantPhase) || (gotEnd != tt.wantEnd) {
+				t.Errorf("%q: bootPhase() = (%q, %d), want (%q, %d)",
+					tt.name, gotPhase, gotEnd, tt.wantPhase, tt.wantEnd)
+			}
+		})
+	}
+} // Test_bootPhase()
+
+func Test_lastFunction(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"0", "", ""},
+		{"1", "goroutine 1 [running]:\n", ""},
+		{"2", testInitStack, "example.com/app.init.0()"},
+		{"3", testTestStack, "testing.tRunner(0xc000100000, 0x5c1e40)"},
+		{"4", "main.main()\n\t/src/main.go:3\n\n", "main.main()"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(lastFunction([]byte(tt.data))); got != tt.want {
+				t.Errorf("%q: lastFunction() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_lastFunction()
+
+func TestPhaseInit(t *testing.T) {
+	if got := Phase(initErr); PhaseInit != got {
+		t.Errorf("Phase() = %q, want %q", got, PhaseInit)
+	}
+	se, _ := AsSource(initErr)
+	if nil == se {
+		t.Fatalf("AsSource() = <nil>, want %v", initErr)
+	}
+	if "bootphase_test.go" != se.Location().File[len(se.File)-len("bootphase_test.go"):] {
+		t.Errorf("Location() = %v, want bootphase_test.go", se.Location())
+	}
+	if bytes.Contains(se.Stack, []byte("runtime.doInit")) {
+		t.Errorf("Stack =\n%s\nwant no runtime frames", se.Stack)
+	}
+	if !bytes.HasSuffix(se.Stack, []byte("\n")) {
+		t.Errorf("Stack = %q, want trailing newline", se.Stack)
+	}
+} // TestPhaseInit()
+
+/* _EoF_ */
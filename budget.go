@@ -0,0 +1,211 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The response headers set by `CountErrors()`.
+	headerErrorCount = "X-Error-Count"
+	headerErrorID    = "X-Error-ID"
+)
+
+type (
+	// `tErrorCounter` counts the errors of a request.
+	tErrorCounter struct {
+		mtx     sync.Mutex
+		count   int
+		firstID string
+	}
+
+	// `tErrorCounterKey` is the context key of the error counter.
+	tErrorCounterKey struct{}
+
+	// `tCountingWriter` adds the error count to a response's header.
+	tCountingWriter struct {
+		http.ResponseWriter
+		counter *tErrorCounter
+		written bool
+	}
+)
+
+// `setHeader()` adds the error count (if any) to the response's
+// header unless it's already written.
+func (cw *tCountingWriter) setHeader() {
+	if cw.written {
+		return
+	}
+	cw.written = true
+
+	count, id := cw.counter.get()
+	if 0 == count {
+		return
+	}
+	header := cw.ResponseWriter.Header()
+	header.Set(headerErrorCount, strconv.Itoa(count))
+	if "" != id {
+		header.Set(headerErrorID, id)
+	}
+} // setHeader()
+
+// `Unwrap()` returns the original writer for `http.ResponseController`.
+//
+// Returns:
+// - `http.ResponseWriter`: The wrapped writer.
+func (cw *tCountingWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+} // Unwrap()
+
+// `Write()` implements the `io.Writer` interface.
+//
+// Parameters:
+// - `aData`: The data to write.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible writing error.
+func (cw *tCountingWriter) Write(aData []byte) (int, error) {
+	cw.setHeader()
+
+	return cw.ResponseWriter.Write(aData)
+} // Write()
+
+// `WriteHeader()` implements the `http.ResponseWriter` interface.
+//
+// Parameters:
+// - `aStatus`: The response's status code.
+func (cw *tCountingWriter) WriteHeader(aStatus int) {
+	cw.setHeader()
+	cw.ResponseWriter.WriteHeader(aStatus)
+} // WriteHeader()
+
+// `add()` counts the given error.
+//
+// Parameters:
+// - `aErr`: The error to count.
+func (ec *tErrorCounter) add(aErr error) {
+	ec.mtx.Lock()
+	defer ec.mtx.Unlock()
+
+	ec.count++
+	if "" == ec.firstID {
+		ec.firstID = ID(aErr)
+	}
+} // add()
+
+// `get()` returns the counted errors.
+//
+// Returns:
+// - `int`: The number of errors counted.
+// - `string`: The ID of the first error with an ID.
+func (ec *tErrorCounter) get() (int, string) {
+	ec.mtx.Lock()
+	defer ec.mtx.Unlock()
+
+	return ec.count, ec.firstID
+} // get()
+
+// --------------------------------------------------------------------------
+
+// `CountError()` counts the given error with the counter of the given
+// context (see `WithErrorCount()`) and returns it unchanged:
+//
+//	if err := repo.Save(ctx, user); nil != err {
+//		log.Println(sourceerror.CountError(ctx, err))
+//	}
+//
+// `RespondError()` counts the errors it reports automatically.
+//
+// Parameters:
+// - `aCtx`: The context carrying the error counter.
+// - `aErr`: The error to count (if not `nil`).
+//
+// Returns:
+// - `error`: The given error.
+func CountError(aCtx context.Context, aErr error) error {
+	if nil == aErr {
+		return nil
+	}
+	if counter, ok := aCtx.Value(tErrorCounterKey{}).(*tErrorCounter); ok {
+		counter.add(aErr)
+	}
+
+	return aErr
+} // CountError()
+
+// `CountErrors()` returns an HTTP middleware counting the errors of
+// each request (see `CountError()`) and adding their number (and the
+// ID of the first error) as `X-Error-Count` (and `X-Error-ID`) headers
+// to the response, giving immediate feedback on hidden failures:
+//
+//	http.ListenAndServe(":8080", sourceerror.CountErrors(mux))
+//
+// The headers are added in the `Development` profile only. Since
+// headers can't be changed once they're sent, errors counted after
+// the handler started writing the response body are not included.
+//
+// Parameters:
+// - `aNext`: The handler to count the errors of.
+//
+// Returns:
+// - `http.Handler`: The counting handler.
+func CountErrors(aNext http.Handler) http.Handler {
+	return http.HandlerFunc(func(aWriter http.ResponseWriter, aRequest *http.Request) {
+		if Development.Name != CurrentProfile().Name {
+			aNext.ServeHTTP(aWriter, aRequest)
+			return
+		}
+
+		counter := &tErrorCounter{}
+		cw := &tCountingWriter{
+			ResponseWriter: aWriter,
+			counter:        counter,
+		}
+		aNext.ServeHTTP(cw, aRequest.WithContext(
+			context.WithValue(aRequest.Context(), tErrorCounterKey{}, counter)))
+		cw.setHeader()
+	})
+} // CountErrors()
+
+// `ErrorCount()` returns the number of errors counted with the given
+// context (see `WithErrorCount()`).
+//
+// Parameters:
+// - `aCtx`: The context carrying the error counter.
+//
+// Returns:
+// - `int`: The number of errors counted.
+// - `string`: The ID of the first error with an ID.
+func ErrorCount(aCtx context.Context) (int, string) {
+	if counter, ok := aCtx.Value(tErrorCounterKey{}).(*tErrorCounter); ok {
+		return counter.get()
+	}
+
+	return 0, ""
+} // ErrorCount()
+
+// `WithErrorCount()` returns a copy of the given context carrying a
+// new error counter (see `CountError()` and `ErrorCount()`), e.g. for
+// background jobs; HTTP handlers get one from `CountErrors()`.
+//
+// Parameters:
+// - `aCtx`: The parent context.
+//
+// Returns:
+// - `context.Context`: The context carrying the error counter.
+func WithErrorCount(aCtx context.Context) context.Context {
+	return context.WithValue(aCtx, tErrorCounterKey{}, &tErrorCounter{})
+} // WithErrorCount()
+
+/* _EoF_ */
@@ -0,0 +1,88 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestCountError(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	ctx := WithErrorCount(context.Background())
+
+	if got := CountError(context.Background(), e); got != e {
+		t.Errorf("CountError() = %v, want %v", got, e)
+	}
+	_ = CountError(ctx, nil)
+	_ = CountError(ctx, e)
+	_ = CountError(ctx, cl1)
+	_ = CountError(ctx, Wrap(e, 0))
+
+	if count, id := ErrorCount(ctx); (3 != count) || (ID(cl1) != id) {
+		t.Errorf("ErrorCount() = %d, %q, want 3, %q", count, id, ID(cl1))
+	}
+	if count, id := ErrorCount(context.Background()); (0 != count) || ("" != id) {
+		t.Errorf("ErrorCount() = %d, %q without counter, want 0", count, id)
+	}
+} // TestCountError()
+
+func TestCountErrors(t *testing.T) {
+	defer UseProfile(CurrentProfile())
+	cl1 := Wrap(errors.New("some first error"), 0)
+
+	handlers := map[string]http.HandlerFunc{
+		"silent": func(aWriter http.ResponseWriter, aRequest *http.Request) {
+			_ = CountError(aRequest.Context(), cl1)
+		},
+		"respond": func(aWriter http.ResponseWriter, aRequest *http.Request) {
+			_ = CountError(aRequest.Context(), errors.New("hidden"))
+			RespondError(aWriter, aRequest, cl1)
+		},
+		"ok": func(aWriter http.ResponseWriter, aRequest *http.Request) {
+			_, _ = aWriter.Write([]byte("ok"))
+		},
+	}
+	tests := []struct {
+		name      string
+		profile   Profile
+		handler   string
+		wantCount string
+		wantID    string
+	}{
+		{"0", Development, "silent", "1", ID(cl1)},
+		{"1", Development, "respond", "2", ID(cl1)},
+		{"2", Development, "ok", "", ""},
+		{"3", Production, "respond", "", ""},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			UseProfile(tt.profile)
+			rec := httptest.NewRecorder()
+			CountErrors(handlers[tt.handler]).ServeHTTP(rec,
+				httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if got := rec.Header().Get(headerErrorCount); got != tt.wantCount {
+				t.Errorf("%q: %s = %q, want %q",
+					tt.name, headerErrorCount, got, tt.wantCount)
+			}
+			if got := rec.Header().Get(headerErrorID); got != tt.wantID {
+				t.Errorf("%q: %s = %q, want %q",
+					tt.name, headerErrorID, got, tt.wantID)
+			}
+		})
+	}
+} // TestCountErrors()
+
+/* _EoF_ */
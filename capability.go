@@ -0,0 +1,80 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `ErrNotImplemented` signals a code path that isn't implemented
+	// (yet); see `NotImplemented()`.
+	ErrNotImplemented = errors.New("not implemented")
+
+	// `ErrUnsupported` signals a requested operation that can't be
+	// supported; see `Unsupported()`.
+	//
+	// It's the same error as the standard library's
+	// `errors.ErrUnsupported` so both can be checked alike.
+	ErrUnsupported = errors.ErrUnsupported
+)
+
+// `capability()` wraps the given sentinel, optionally prefixed with
+// a description, with the location of the caller's caller.
+//
+// Parameters:
+// - `aSentinel`: The sentinel error to wrap.
+// - `aWhat`: The description of what is missing (may be empty).
+//
+// Returns:
+// - `error`: The located error.
+func capability(aSentinel error, aWhat string) error {
+	err := aSentinel
+	if "" != aWhat {
+		err = fmt.Errorf("%s: %w", aWhat, aSentinel)
+	}
+
+	return newSource(err, 0, 2, defaultConfig.TopFrame())
+} // capability()
+
+// `NotImplemented()` returns an `ErrNotImplemented` error located at
+// the caller, e.g. to mark stubs:
+//
+//	func (s *Store) Compact() error {
+//		return sourceerror.NotImplemented("Store.Compact")
+//	}
+//
+// Use `errors.Is(err, sourceerror.ErrNotImplemented)` to detect it.
+//
+// Parameters:
+// - `aWhat`: The description of what isn't implemented (may be empty).
+//
+// Returns:
+// - `error`: The located error.
+func NotImplemented(aWhat string) error {
+	return capability(ErrNotImplemented, aWhat)
+} // NotImplemented()
+
+// `Unsupported()` returns an `ErrUnsupported` error located at the
+// caller.
+//
+// Use `errors.Is(err, sourceerror.ErrUnsupported)` (or the standard
+// library's `errors.ErrUnsupported`) to detect it.
+//
+// Parameters:
+// - `aWhat`: The description of what isn't supported (may be empty).
+//
+// Returns:
+// - `error`: The located error.
+func Unsupported(aWhat string) error {
+	return capability(ErrUnsupported, aWhat)
+} // Unsupported()
+
+/* _EoF_ */
@@ -0,0 +1,46 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestNotImplemented(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		target  error
+		wantMsg string
+	}{
+		{"0", NotImplemented(""), ErrNotImplemented, "not implemented"},
+		{"1", NotImplemented("Store.Compact"), ErrNotImplemented,
+			"Store.Compact: not implemented"},
+		{"2", Unsupported("ARM32"), errors.ErrUnsupported,
+			"ARM32: unsupported operation"},
+		{"3", Unsupported(""), ErrUnsupported, "unsupported operation"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Expect(tt.err).
+				Is(tt.target).
+				MessageContains(tt.wantMsg).
+				Function(thisPackage + ".TestNotImplemented").
+				LocationIn("capability_test.go").
+				Err()
+			if nil != err {
+				t.Errorf("%q: %v", tt.name, err)
+			}
+		})
+	}
+} // TestNotImplemented()
+
+/* _EoF_ */
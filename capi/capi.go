@@ -0,0 +1,137 @@
+//go:build cgo
+
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+/*
+Package capi exports a minimal C ABI allowing non-Go host applications
+embedding a Go library (built with `-buildmode=c-shared` or
+`-buildmode=c-archive`) to obtain the structured payload of the errors
+the library reports.
+
+The library retains its errors and hands their IDs across the C
+boundary; the host then asks for the error's JSON payload:
+
+	// Go side
+	import "github.com/mwat56/sourceerror/capi"
+
+	//export LoadConfig
+	func LoadConfig(aName *C.char) *C.char {
+		if err := load(C.GoString(aName)); nil != err {
+			return C.CString(capi.Retain(err))
+		}
+		return nil
+	}
+
+	// C side
+	char *id = LoadConfig("app.ini");
+	if (NULL != id) {
+		char *payload = SourceErrorJSON(id);
+		// ... log or display the payload ...
+		SourceErrorFree(payload);
+		SourceErrorFree(id);
+	}
+
+The payload's fields are those of `sourceerror.ErrSource.MarshalLog()`.
+*/
+package capi
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"time"
+	"unsafe"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// How long a retained error is available to the host.
+	retainTTL = time.Hour
+)
+
+var (
+	// The errors retained for the host.
+	retained = sourceerror.NewStore(retainTTL)
+)
+
+// `payload()` returns the JSON payload of the retained error with
+// the given ID.
+//
+// Parameters:
+// - `aID`: The ID of the error to look up.
+//
+// Returns:
+// - `[]byte`: The error's payload or `nil` if there's no such error.
+func payload(aID string) []byte {
+	se, ok := retained.Get(aID)
+	if !ok {
+		return nil
+	}
+	result, err := json.Marshal(se.MarshalLog())
+	if nil != err {
+		return nil
+	}
+
+	return result
+} // payload()
+
+// `Retain()` keeps the outermost `ErrSource` of the given error's
+// chain for an hour so that the host can retrieve its payload by
+// `SourceErrorJSON()`.
+//
+// Parameters:
+// - `aErr`: The error to retain.
+//
+// Returns:
+// - `string`: The retained error's ID or an empty string if `aErr`
+// doesn't contain an `ErrSource` with an ID.
+func Retain(aErr error) string {
+	return retained.Add(aErr)
+} // Retain()
+
+// `SourceErrorFree()` releases a string returned across the C boundary.
+//
+// Parameters:
+// - `aText`: The string to release; `NULL` is ignored.
+//
+//export SourceErrorFree
+func SourceErrorFree(aText *C.char) {
+	C.free(unsafe.Pointer(aText))
+} // SourceErrorFree()
+
+// `SourceErrorJSON()` returns the JSON payload of the error retained
+// with the given ID (see `Retain()`).
+//
+// The caller must release the result by `SourceErrorFree()`.
+//
+// Parameters:
+// - `aID`: The ID of the error to look up.
+//
+// Returns:
+// - `*C.char`: The error's payload or `NULL` if there's no such error.
+//
+//export SourceErrorJSON
+func SourceErrorJSON(aID *C.char) *C.char {
+	if nil == aID {
+		return nil
+	}
+	data := payload(C.GoString(aID))
+	if nil == data {
+		return nil
+	}
+
+	return C.CString(string(data))
+} // SourceErrorJSON()
+
+/* _EoF_ */
@@ -0,0 +1,66 @@
+//go:build cgo
+
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+package capi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_payload(t *testing.T) {
+	cl1 := sourceerror.Wrap(errors.New("some first error"), 0)
+	id := Retain(cl1)
+	if "" == id {
+		t.Fatalf("Retain() = %q, want an ID", id)
+	}
+	if got := Retain(errors.New("plain")); "" != got {
+		t.Errorf("Retain() = %q, want empty ID", got)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		wantMsg string
+	}{
+		{"0", id, "some first error"},
+		{"1", "unknown", ""},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := payload(tt.id)
+			if "" == tt.wantMsg {
+				if nil != data {
+					t.Errorf("%q: payload() = %s, want <nil>", tt.name, data)
+				}
+				return
+			}
+			var got map[string]any
+			if err := json.Unmarshal(data, &got); nil != err {
+				t.Fatalf("%q: payload() = %s: %v", tt.name, data, err)
+			}
+			if got["msg"] != tt.wantMsg {
+				t.Errorf("%q: payload() msg = %v, want %q",
+					tt.name, got["msg"], tt.wantMsg)
+			}
+			if got["id"] != tt.id {
+				t.Errorf("%q: payload() id = %v, want %q",
+					tt.name, got["id"], tt.id)
+			}
+		})
+	}
+} // Test_payload()
+
+/* _EoF_ */
@@ -0,0 +1,361 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// The maximum number of errors inspected when walking an error's
+	// chain; longer chains are truncated (see `ErrChainTooDeep`).
+	//
+	// Deprecated: Changing the limit at runtime races with the chain
+	// traversing functions; use `DefaultConfig().SetMaxChainDepth()`
+	// instead.
+	MaxChainDepth = 100
+
+	// `ErrChainCycle` marks an error chain whose `Unwrap()` methods
+	// lead back to an error already seen.
+	ErrChainCycle = errors.New("error chain: cycle detected")
+
+	// `ErrChainTooDeep` marks an error chain longer than `MaxChainDepth`.
+	ErrChainTooDeep = errors.New("error chain: too deep")
+
+	// The registered cause extractors.
+	causeExtractors []*tCauseExtractor
+
+	// Guard for `causeExtractors`.
+	causeMtx sync.RWMutex
+
+	// The number of registered extractors (checked without locking).
+	causeCount atomic.Int32
+)
+
+type (
+	// `tCauseExtractor` is a function registered by
+	// `RegisterCauseExtractor()`.
+	tCauseExtractor struct {
+		extract func(error) (error, bool)
+	}
+
+	// `tChainWalk` is the state of a `walkChain()` call.
+	tChainWalk struct {
+		visit func(aLink error) bool
+		limit int     // the maximum number of errors to visit
+		count int     // the number of errors visited
+		path  []error // the errors wrapping the current one
+	}
+)
+
+// `clone()` returns a deep copy of the error, sharing only the wrapped
+// error and the lazily resolved call stack (which is immutable).
+//
+// Returns:
+// - `*ErrSource`: The copy of the error.
+func (se *ErrSource) clone() *ErrSource {
+	result := *se
+	result.Stack = slices.Clone(se.Stack)
+	result.pcs = slices.Clone(se.pcs)
+	if nil != se.External {
+		external := *se.External
+		result.External = &external
+	}
+	if nil != se.Input {
+		input := *se.Input
+		result.Input = &input
+	}
+	if nil != se.Foreign {
+		result.Foreign = make([]ForeignStack, len(se.Foreign))
+		for idx, stack := range se.Foreign {
+			stack.Frames = slices.Clone(stack.Frames)
+			result.Foreign[idx] = stack
+		}
+	}
+
+	return &result
+} // clone()
+
+// `walk()` visits the given error and the errors it wraps, depth
+// first, like `errors.Is()` does.
+//
+// Parameters:
+// - `aErr`: The error to visit.
+//
+// Returns:
+// - `bool`: `true` if the walk is to continue, `false` otherwise.
+// - `error`: `ErrChainCycle` or `ErrChainTooDeep` if the walk was
+// aborted, `nil` otherwise.
+func (cw *tChainWalk) walk(aErr error) (bool, error) {
+	depth := len(cw.path)
+	for nil != aErr {
+		for _, outer := range cw.path {
+			if sameError(aErr, outer) {
+				return false, ErrChainCycle
+			}
+		}
+		if cw.limit <= cw.count {
+			return false, ErrChainTooDeep
+		}
+		cw.count++
+		if !cw.visit(aErr) {
+			return false, nil
+		}
+		cw.path = append(cw.path, aErr)
+
+		if multi, ok := aErr.(interface{ Unwrap() []error }); ok {
+			for _, branch := range multi.Unwrap() {
+				if goOn, marker := cw.walk(branch); !goOn {
+					return false, marker
+				}
+			}
+			break
+		}
+		aErr = unwrap(aErr)
+	}
+	cw.path = cw.path[:depth]
+
+	return true, nil
+} // walk()
+
+// `asSource()` returns the outermost `ErrSource` in the given error's
+// chain, regardless whether it's stored as value or pointer.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `*ErrSource`: The error's location data.
+// - `bool`: `true` if an `ErrSource` was found, `false` otherwise.
+func asSource(aErr error) (rSource *ErrSource, rOK bool) {
+	_ = walkChain(aErr, func(aLink error) bool {
+		switch se := aLink.(type) {
+		case *ErrSource:
+			rSource, rOK = se, (nil != se)
+			return false
+		case ErrSource:
+			rSource, rOK = &se, true
+			return false
+		}
+		return true
+	})
+
+	return
+} // asSource()
+
+// `unwrap()` returns the error wrapped by the given one, using the
+// registered cause extractors for errors without an `Unwrap()` method.
+//
+// Parameters:
+// - `aErr`: The error to unwrap.
+//
+// Returns:
+// - `error`: The wrapped error or `nil`.
+func unwrap(aErr error) error {
+	if result := errors.Unwrap(aErr); (nil != result) || (0 == causeCount.Load()) {
+		return result
+	}
+
+	causeMtx.RLock()
+	defer causeMtx.RUnlock()
+	for _, ce := range causeExtractors {
+		if cause, ok := ce.extract(aErr); ok && (nil != cause) {
+			return cause
+		}
+	}
+
+	return nil
+} // unwrap()
+
+// `sameError()` checks whether the given errors are identical without
+// panicking on non-comparable error types.
+//
+// Parameters:
+// - `aErr1`: The first error to compare.
+// - `aErr2`: The second error to compare.
+//
+// Returns:
+// - `bool`: `true` if both errors are identical, `false` otherwise.
+func sameError(aErr1, aErr2 error) (rSame bool) {
+	if (nil == aErr1) || (nil == aErr2) {
+		return false
+	}
+	eType := reflect.TypeOf(aErr1)
+	if (eType != reflect.TypeOf(aErr2)) || !eType.Comparable() {
+		return false
+	}
+	// comparable structs may still hold non-comparable interface values
+	defer func() {
+		if nil != recover() {
+			rSame = false
+		}
+	}()
+
+	return aErr1 == aErr2
+} // sameError()
+
+// `walkChain()` calls the given function for each error of the given
+// error's chain (following `errors.Unwrap()` and the registered cause
+// extractors) until the function returns `false` or the chain ends.
+// Errors wrapping several errors (e.g. by `errors.Join()` or multiple
+// `%w` verbs) are walked depth first, like `errors.Is()` does.
+//
+// The walk stops at `Config.MaxChainDepth()` errors and when an error
+// wraps one of the errors wrapping it.
+//
+// Parameters:
+// - `aErr`: The error whose chain to walk.
+// - `aFunc`: The function to call for each error of the chain.
+//
+// Returns:
+// - `error`: `ErrChainCycle` or `ErrChainTooDeep` if the walk was
+// aborted, `nil` otherwise.
+func walkChain(aErr error, aFunc func(aLink error) bool) error {
+	var outer [8]error
+	cw := tChainWalk{
+		visit: aFunc,
+		limit: defaultConfig.MaxChainDepth(),
+		path:  outer[:0],
+	}
+	_, result := cw.walk(aErr)
+
+	return result
+} // walkChain()
+
+// --------------------------------------------------------------------------
+
+// `AsSource()` returns a copy of the outermost `ErrSource` in the given
+// error's chain, regardless whether it's stored as value or pointer.
+//
+// Since the result is a copy, changing its fields doesn't affect the
+// error, which may be shared by other goroutines, hooks, or loggers;
+// use the annotating functions (e.g. `WithPhase()`) to derive a
+// changed error instead.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `*ErrSource`: A copy of the error's location data.
+// - `bool`: `true` if an `ErrSource` was found, `false` otherwise.
+func AsSource(aErr error) (*ErrSource, bool) {
+	se, ok := asSource(aErr)
+	if !ok {
+		return nil, false
+	}
+
+	return se.clone(), true
+} // AsSource()
+
+// `Chain()` returns all errors of the given error's chain (following
+// `errors.Unwrap()` and the registered cause extractors, see
+// `RegisterCauseExtractor()`), starting with `aErr` itself.
+//
+// Errors wrapping several errors (e.g. by `errors.Join()`) are
+// followed by all their branches, depth first.
+//
+// If the chain contains a cycle or is longer than `MaxChainDepth()`
+// of `DefaultConfig()`, the result is truncated and ends with
+// `ErrChainCycle` or `ErrChainTooDeep` respectively.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `[]error`: The errors of the chain, outermost first.
+func Chain(aErr error) []error {
+	var result []error
+	marker := walkChain(aErr, func(aLink error) bool {
+		result = append(result, aLink)
+		return true
+	})
+	if nil != marker {
+		result = append(result, marker)
+	}
+
+	return result
+} // Chain()
+
+// `RegisterCauseExtractor()` registers a function returning the cause
+// of errors that don't implement `Unwrap()`, e.g. the wrapper types of
+// legacy code:
+//
+//	remove := sourceerror.RegisterCauseExtractor(func(aErr error) (error, bool) {
+//		if c, ok := aErr.(interface{ Cause() error }); ok {
+//			return c.Cause(), true
+//		}
+//		return nil, false
+//	})
+//	defer remove()
+//
+// The extractors are consulted in the order of their registration
+// by all chain traversing functions of this package (e.g. `Chain()`,
+// `Root()`, and `AsSource()`); note that `errors.Is()` and
+// `errors.As()` of the standard library don't use them.
+//
+// Parameters:
+// - `aExtractor`: The function returning an error's cause and `true`,
+// or `false` if it doesn't handle the given error.
+//
+// Returns:
+// - `func()`: A function removing the registered extractor.
+func RegisterCauseExtractor(aExtractor func(error) (error, bool)) func() {
+	ce := &tCauseExtractor{
+		extract: aExtractor,
+	}
+
+	causeMtx.Lock()
+	causeExtractors = append(causeExtractors, ce)
+	causeCount.Store(int32(len(causeExtractors)))
+	causeMtx.Unlock()
+
+	return func() {
+		causeMtx.Lock()
+		defer causeMtx.Unlock()
+
+		for idx, c := range causeExtractors {
+			if c == ce {
+				causeExtractors = append(causeExtractors[:idx:idx], causeExtractors[idx+1:]...)
+				break
+			}
+		}
+		causeCount.Store(int32(len(causeExtractors)))
+	}
+} // RegisterCauseExtractor()
+
+// `Root()` returns the innermost error of the given error's chain,
+// i.e. the original cause; of errors wrapping several errors (e.g. by
+// `errors.Join()`) the first one is followed.
+//
+// If the chain contains a cycle or is longer than `MaxChainDepth()`
+// of `DefaultConfig()`, the last error inspected is returned.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `error`: The chain's innermost error or `nil` if `aErr` is `nil`.
+func Root(aErr error) error {
+	var result error
+	_ = walkChain(aErr, func(aLink error) bool {
+		result = aLink
+		if multi, ok := aLink.(interface{ Unwrap() []error }); ok {
+			return 0 < len(multi.Unwrap())
+		}
+		return nil != unwrap(aLink)
+	})
+
+	return result
+} // Root()
+
+/* _EoF_ */
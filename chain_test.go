@@ -0,0 +1,228 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// an error whose `Unwrap()` may lead back to itself
+	tLoopErr struct {
+		next error
+	}
+
+	// an error with an endless chain of distinct errors
+	tDeepErr int
+
+	// a legacy wrapper providing its cause by `Cause()`
+	tCauseErr struct {
+		cause error
+	}
+)
+
+func (e *tLoopErr) Error() string { return "loop" }
+func (e *tLoopErr) Unwrap() error { return e.next }
+
+func (e tDeepErr) Error() string { return fmt.Sprintf("deep %d", int(e)) }
+func (e tDeepErr) Unwrap() error { return e + 1 }
+
+func (e tCauseErr) Error() string { return "legacy: " + e.cause.Error() }
+func (e tCauseErr) Cause() error  { return e.cause }
+
+// `causeOf()` is a cause extractor for legacy `Cause()` errors.
+func causeOf(aErr error) (error, bool) {
+	if c, ok := aErr.(interface{ Cause() error }); ok {
+		return c.Cause(), true
+	}
+	return nil, false
+} // causeOf()
+
+func TestChain(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	cl2 := fmt.Errorf("outer: %w", cl1)
+	self := &tLoopErr{}
+	self.next = self
+	loop1, loop2 := &tLoopErr{}, &tLoopErr{}
+	loop1.next, loop2.next = loop2, loop1
+	entry := fmt.Errorf("entry: %w", &tLoopErr{next: loop1})
+	e2 := errors.New("some second error")
+	joined := errors.Join(cl2, e2)
+	multi := fmt.Errorf("multi: %w, %w", e2, self)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantLen    int
+		wantMarker error
+	}{
+		{"0", nil, 0, nil},
+		{"1", e, 1, nil},
+		{"2", cl2, 3, nil},
+		{"3", self, 2, ErrChainCycle},
+		{"4", loop1, 3, ErrChainCycle},
+		{"5", entry, 5, ErrChainCycle},
+		{"6", tDeepErr(0), DefaultConfig().MaxChainDepth() + 1, ErrChainTooDeep},
+		{"7", joined, 5, nil},
+		{"8", errors.Join(e, joined), 7, nil},
+		{"9", multi, 4, ErrChainCycle},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Chain(tt.err)
+			if len(got) != tt.wantLen {
+				t.Errorf("%q: Chain() = %d errors, want %d: %v",
+					tt.name, len(got), tt.wantLen, got)
+				return
+			}
+			var last error
+			if 0 < len(got) {
+				last = got[len(got)-1]
+			}
+			if isMarker := (ErrChainCycle == last) || (ErrChainTooDeep == last); isMarker != (nil != tt.wantMarker) {
+				t.Errorf("%q: Chain() marker = %v, want %v",
+					tt.name, last, tt.wantMarker)
+			} else if isMarker && (last != tt.wantMarker) {
+				t.Errorf("%q: Chain() marker = %v, want %v",
+					tt.name, last, tt.wantMarker)
+			}
+		})
+	}
+} // TestChain()
+
+func TestRoot(t *testing.T) {
+	e := errors.New("some first error")
+	self := &tLoopErr{}
+	self.next = self
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"0", nil, nil},
+		{"1", e, e},
+		{"2", fmt.Errorf("outer: %w", Wrap(e, 0)), e},
+		{"3", self, self},
+		{"4", tDeepErr(0), tDeepErr(DefaultConfig().MaxChainDepth() - 1)},
+		{"5", errors.Join(fmt.Errorf("outer: %w", e), self), e},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Root(tt.err); got != tt.want {
+				t.Errorf("%q: Root() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestRoot()
+
+func TestAsSource(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0).(*ErrSource)
+	cl1.External = &Location{File: "a.go", Function: "pkg.F", Line: 1}
+	cl1.Input = &Position{Record: 2}
+	cl1.Foreign = []ForeignStack{{Name: "py", Frames: []Frame{{Line: 3}}}}
+	self := &tLoopErr{}
+	self.next = self
+
+	tests := []struct {
+		name   string
+		err    error
+		want   *ErrSource
+		wantOK bool
+	}{
+		{"0", nil, nil, false},
+		{"1", e, nil, false},
+		{"2", fmt.Errorf("outer: %w", cl1), cl1, true},
+		{"3", (*ErrSource)(nil), nil, false},
+		{"4", self, nil, false},
+		{"5", tDeepErr(0), nil, false},
+		{"6", errors.Join(e, cl1), cl1, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := AsSource(tt.err)
+			if (ok != tt.wantOK) || ((nil == got) != (nil == tt.want)) {
+				t.Fatalf("%q: AsSource() = %v, %v, want %v, %v",
+					tt.name, got, ok, tt.want, tt.wantOK)
+			}
+			if nil == got {
+				return
+			}
+			if !reflect.DeepEqual(*got, *tt.want) {
+				t.Errorf("%q: AsSource() = %v, want %v", tt.name, got, tt.want)
+			}
+			// the result is a copy
+			got.Line, got.Stack[0], got.pcs[0] = 0, 'X', 0
+			got.External.Line, got.Input.Record = 0, 0
+			got.Foreign[0].Frames[0].Line = 0
+			if (0 == tt.want.Line) || ('X' == tt.want.Stack[0]) ||
+				(0 == tt.want.pcs[0]) || (0 == tt.want.External.Line) ||
+				(0 == tt.want.Input.Record) || (0 == tt.want.Foreign[0].Frames[0].Line) {
+				t.Errorf("%q: AsSource() returned the shared instance", tt.name)
+			}
+		})
+	}
+} // TestAsSource()
+
+func TestRegisterCauseExtractor(t *testing.T) {
+	e := errors.New("some first error")
+	se := Wrap(e, 0)
+	legacy := fmt.Errorf("outer: %w", tCauseErr{cause: se})
+
+	if got := len(Chain(legacy)); 2 != got {
+		t.Errorf("Chain() = %d errors without extractor, want 2", got)
+	}
+	if _, ok := AsSource(legacy); ok {
+		t.Error("AsSource() found source without extractor")
+	}
+
+	remove := RegisterCauseExtractor(causeOf)
+	tests := []struct {
+		name     string
+		err      error
+		wantLen  int
+		wantRoot error
+	}{
+		{"0", legacy, 4, e},
+		{"1", tCauseErr{cause: e}, 2, e},
+		{"2", se, 2, e},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(Chain(tt.err)); got != tt.wantLen {
+				t.Errorf("%q: Chain() = %d errors, want %d",
+					tt.name, got, tt.wantLen)
+			}
+			if got := Root(tt.err); got != tt.wantRoot {
+				t.Errorf("%q: Root() = %v, want %v",
+					tt.name, got, tt.wantRoot)
+			}
+		})
+	}
+	if got, ok := AsSource(legacy); !ok || (got.ID != ID(se)) {
+		t.Errorf("AsSource() = %v, %v, want %v", got, ok, se)
+	}
+
+	remove()
+	remove()
+	if got := len(Chain(legacy)); 2 != got {
+		t.Errorf("Chain() = %d errors after removal, want 2", got)
+	}
+} // TestRegisterCauseExtractor()
+
+/* _EoF_ */
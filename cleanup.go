@@ -0,0 +1,54 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"io"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `CloseAndWrap()` closes the given `io.Closer` and combines a possible
+// `Close()` error with the error pointed to by `aErrPtr`. It's meant
+// to be deferred by functions with a named error result:
+//
+//	func save(aName string) (rErr error) {
+//		f, err := os.Create(aName)
+//		if nil != err {
+//			return sourceerror.Wrap(err, 2)
+//		}
+//		defer sourceerror.CloseAndWrap(&rErr, f)
+//		// ...
+//	}
+//
+// A `Close()` error is wrapped with the location of the function
+// deferring the call; if there was no error before it becomes the
+// function's result, otherwise both errors are combined by `Merge()`
+// with the former error as the primary one.
+//
+// Parameters:
+// - `aErrPtr`: Pointer to the function's error result; may be `nil`
+// if the `Close()` error is to be ignored.
+// - `aCloser`: The resource to close; may be `nil`.
+func CloseAndWrap(aErrPtr *error, aCloser io.Closer) {
+	if nil == aCloser {
+		return
+	}
+	err := aCloser.Close()
+	if (nil == err) || (nil == aErrPtr) {
+		return
+	}
+
+	closeErr := newSource(err, 0, 1, defaultConfig.TopFrame())
+	if nil == *aErrPtr {
+		*aErrPtr = closeErr
+		return
+	}
+	*aErrPtr = merge(*aErrPtr, closeErr, 1)
+} // CloseAndWrap()
+
+/* _EoF_ */
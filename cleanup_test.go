@@ -0,0 +1,87 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `tTestCloser` is an `io.Closer` returning a preset error.
+type tTestCloser struct {
+	err    error
+	closed bool
+}
+
+func (tc *tTestCloser) Close() error {
+	tc.closed = true
+	return tc.err
+}
+
+// `testCloseUse()` simulates a function deferring `CloseAndWrap()`.
+func testCloseUse(aCloser *tTestCloser, aErr error) (rErr error) {
+	defer CloseAndWrap(&rErr, aCloser)
+
+	return aErr
+} // testCloseUse()
+
+func TestCloseAndWrap(t *testing.T) {
+	eClose := errors.New("close failed")
+	eWork := errors.New("work failed")
+
+	tests := []struct {
+		name     string
+		closer   *tTestCloser
+		err      error
+		wantNil  bool
+		wantIs   []error
+		wantFunc string
+	}{
+		{"1", &tTestCloser{}, nil, true, nil, ""},
+		{"2", &tTestCloser{}, eWork, false, []error{eWork}, ""},
+		{"3", &tTestCloser{err: eClose}, nil, false, []error{eClose}, "testCloseUse"},
+		{"4", &tTestCloser{err: eClose}, eWork, false, []error{eWork, eClose}, "testCloseUse"},
+		{"5", &tTestCloser{err: eClose}, Wrap(eWork, 0), false, []error{eWork, eClose}, "TestCloseAndWrap"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testCloseUse(tt.closer, tt.err)
+			if !tt.closer.closed {
+				t.Errorf("%q: CloseAndWrap() didn't close", tt.name)
+			}
+			if tt.wantNil {
+				if nil != got {
+					t.Errorf("%q: CloseAndWrap() = %v, want <nil>", tt.name, got)
+				}
+				return
+			}
+			for _, target := range tt.wantIs {
+				if !errors.Is(got, target) {
+					t.Errorf("%q: CloseAndWrap() = %v, want %v in chain",
+						tt.name, got, target)
+				}
+			}
+			if "" == tt.wantFunc {
+				return
+			}
+			se, ok := asSource(got)
+			if !ok || !strings.Contains(se.Function, tt.wantFunc) {
+				t.Errorf("%q: CloseAndWrap() function = %v, want %q",
+					tt.name, se, tt.wantFunc)
+			}
+		})
+	}
+
+	CloseAndWrap(nil, &tTestCloser{err: eClose}) // must not panic
+	CloseAndWrap(nil, nil)
+} // TestCloseAndWrap()
+
+/* _EoF_ */
@@ -0,0 +1,307 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	htmltemplate "html/template"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tEntry` is a single received error.
+	tEntry struct {
+		ID          string    `json:"id,omitempty"`
+		Fingerprint string    `json:"fingerprint"`
+		Message     string    `json:"message"`
+		Location    string    `json:"location,omitempty"`
+		Intentional bool      `json:"intentional,omitempty"`
+		Received    time.Time `json:"received"`
+	}
+
+	// `tGroup` aggregates the errors sharing a fingerprint.
+	tGroup struct {
+		Fingerprint string    `json:"fingerprint"`
+		Message     string    `json:"message"` // of the latest error
+		Location    string    `json:"location,omitempty"`
+		Intentional bool      `json:"intentional,omitempty"` // a deliberate abort
+		Count       int       `json:"count"`
+		First       time.Time `json:"first"`
+		Last        time.Time `json:"last"`
+		LastID      string    `json:"last_id,omitempty"`
+	}
+
+	// `tLine` holds the fields used to tell the kinds of lines sent
+	// by the `agent` package apart.
+	tLine struct {
+		Version int    `json:"v"`
+		Message string `json:"message"`
+		Text    string `json:"text"`
+	}
+
+	// `tCollector` aggregates the errors received from the agents.
+	tCollector struct {
+		mtx       sync.Mutex
+		groups    map[string]*tGroup // indexed by fingerprint
+		maxGroups int                // the limit of `groups`
+		recent    []tEntry           // ring buffer of the latest errors
+		next      int                // ring buffer's next write position
+		store     *sourceerror.Store // full data of the latest errors
+		now       func() time.Time   // replaceable for testing
+	}
+)
+
+const (
+	// The max. length of a single line sent by an agent.
+	maxLineSize = 1 << 20
+
+	// The max. number of error groups kept; the least recently seen
+	// group is dropped when a new one exceeds the limit.
+	maxGroups = 10000
+)
+
+var (
+	// The overview page.
+	indexPage = htmltemplate.Must(htmltemplate.New("index").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>secollect</title></head>
+<body><h1>Errors</h1>
+<table>
+<tr><th>Count</th><th>Last seen</th><th>Message</th><th>Location</th></tr>
+{{- range .}}
+<tr><td>{{.Count}}</td><td>{{.Last.Format "2006-01-02 15:04:05"}}</td>
+<td>{{if .LastID}}<a href="errors/{{.LastID}}">{{.Message}}</a>{{else}}{{.Message}}{{end}}
+{{- if .Intentional}} <em>(intentional)</em>{{end}}</td>
+<td>{{.Location}}</td></tr>
+{{- end}}
+</table>
+</body></html>
+`))
+)
+
+// `add()` aggregates the given line sent by an agent.
+//
+// Parameters:
+// - `aLine`: The JSON object received.
+//
+// Returns:
+// - `error`: A possible decoding error.
+func (c *tCollector) add(aLine []byte) error {
+	var line tLine
+	if err := json.Unmarshal(aLine, &line); nil != err {
+		return err
+	}
+
+	entry := tEntry{Received: c.now()}
+	if 0 < line.Version {
+		se := &sourceerror.ErrSource{}
+		if err := json.Unmarshal(aLine, se); nil != err {
+			return err
+		}
+		entry.ID = se.ID
+		entry.Fingerprint = sourceerror.Fingerprint(se)
+		entry.Message = se.Error()
+		entry.Intentional = sourceerror.IsIntentional(se)
+		if "" != se.File {
+			entry.Location = se.Location().String()
+		}
+		c.store.Add(se)
+	} else {
+		entry.Message = line.Message
+		if "" == entry.Message {
+			// a rendered error: its first line is the summary
+			entry.Message, _, _ = strings.Cut(line.Text, "\n")
+		}
+		hash := fnv.New64a()
+		_, _ = io.WriteString(hash, entry.Message)
+		entry.Fingerprint = fmt.Sprintf("%016x", hash.Sum64())
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	group, ok := c.groups[entry.Fingerprint]
+	if !ok {
+		if len(c.groups) >= c.maxGroups {
+			c.evictGroup()
+		}
+		group = &tGroup{
+			Fingerprint: entry.Fingerprint,
+			First:       entry.Received,
+		}
+		c.groups[entry.Fingerprint] = group
+	}
+	group.Message, group.Location = entry.Message, entry.Location
+	group.Intentional = entry.Intentional
+	group.Last, group.LastID = entry.Received, entry.ID
+	group.Count++
+
+	if len(c.recent) < cap(c.recent) {
+		c.recent = append(c.recent, entry)
+	} else {
+		c.recent[c.next] = entry
+	}
+	c.next = (c.next + 1) % cap(c.recent)
+
+	return nil
+} // add()
+
+// `evictGroup()` removes the least recently seen error group.
+//
+// NOTE: The caller must hold the collector's lock.
+func (c *tCollector) evictGroup() {
+	var oldest *tGroup
+	for _, group := range c.groups {
+		if (nil == oldest) || group.Last.Before(oldest.Last) {
+			oldest = group
+		}
+	}
+	if nil != oldest {
+		delete(c.groups, oldest.Fingerprint)
+	}
+} // evictGroup()
+
+// `groupList()` returns the error groups, most frequent first, with
+// the deliberate aborts (see `sourceerror.MarkIntentional()`) after
+// the incidents.
+//
+// Returns:
+// - `[]tGroup`: The current error groups.
+func (c *tCollector) groupList() []tGroup {
+	c.mtx.Lock()
+	result := make([]tGroup, 0, len(c.groups))
+	for _, group := range c.groups {
+		result = append(result, *group)
+	}
+	c.mtx.Unlock()
+
+	slices.SortFunc(result, func(a, b tGroup) int {
+		if a.Intentional != b.Intentional {
+			if a.Intentional {
+				return 1
+			}
+			return -1
+		}
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return b.Last.Compare(a.Last)
+	})
+
+	return result
+} // groupList()
+
+// `handle()` aggregates the lines received from the given agent's
+// connection until it's closed.
+//
+// Parameters:
+// - `aConn`: The agent's connection.
+//
+// Returns:
+// - `int`: The number of lines that couldn't be decoded.
+func (c *tCollector) handle(aConn io.Reader) int {
+	result := 0
+	scanner := bufio.NewScanner(aConn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		if 0 == len(scanner.Bytes()) {
+			continue
+		}
+		if err := c.add(scanner.Bytes()); nil != err {
+			result++
+		}
+	}
+
+	return result
+} // handle()
+
+// `recentList()` returns the latest errors, newest first.
+//
+// Returns:
+// - `[]tEntry`: The latest received errors.
+func (c *tCollector) recentList() []tEntry {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	result := make([]tEntry, 0, len(c.recent))
+	for idx := range len(c.recent) {
+		// walk backwards from the latest entry
+		pos := (c.next - 1 - idx + 2*len(c.recent)) % len(c.recent)
+		result = append(result, c.recent[pos])
+	}
+
+	return result
+} // recentList()
+
+// `routes()` returns the collector's read-only HTTP interface:
+// - "/": an overview page of the error groups;
+// - "/api/groups": the error groups as JSON, incidents and the most
+// frequent first;
+// - "/api/recent": the latest errors as JSON, newest first;
+// - "/errors/{id}": the full data of a recent error.
+//
+// Returns:
+// - `http.Handler`: The collector's HTTP interface.
+func (c *tCollector) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(aWriter http.ResponseWriter, _ *http.Request) {
+		aWriter.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = indexPage.Execute(aWriter, c.groupList())
+	})
+	mux.HandleFunc("GET /api/groups", func(aWriter http.ResponseWriter, _ *http.Request) {
+		writeJSON(aWriter, c.groupList())
+	})
+	mux.HandleFunc("GET /api/recent", func(aWriter http.ResponseWriter, _ *http.Request) {
+		writeJSON(aWriter, c.recentList())
+	})
+	mux.Handle("GET /errors/{id}", c.store)
+
+	return mux
+} // routes()
+
+// --------------------------------------------------------------------------
+
+// `newCollector()` returns a new collector.
+//
+// Parameters:
+// - `aKeep`: The number of recent errors to keep.
+// - `aTTL`: How long to keep the full data of an error.
+//
+// Returns:
+// - `*tCollector`: The new collector.
+func newCollector(aKeep int, aTTL time.Duration) *tCollector {
+	return &tCollector{
+		groups:    make(map[string]*tGroup),
+		maxGroups: maxGroups,
+		recent:    make([]tEntry, 0, max(aKeep, 1)),
+		store:     sourceerror.NewStore(aTTL),
+		now:       time.Now,
+	}
+} // newCollector()
+
+// `writeJSON()` sends the given data as JSON response.
+//
+// Parameters:
+// - `aWriter`: Used to send the response.
+// - `aData`: The data to send.
+func writeJSON(aWriter http.ResponseWriter, aData any) {
+	aWriter.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(aWriter).Encode(aData)
+} // writeJSON()
+
+/* _EoF_ */
@@ -0,0 +1,181 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `newTestCollector()` returns a collector with a fixed clock
+// advancing by a second per received error.
+func newTestCollector(aKeep int) *tCollector {
+	result := newCollector(aKeep, 0)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	result.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	return result
+} // newTestCollector()
+
+func Test_tCollector_add(t *testing.T) {
+	se := sourceerror.Wrap(errors.New("some first error"), 0)
+	line, _ := json.Marshal(se)
+
+	tests := []struct {
+		name    string
+		line    string
+		wantMsg string
+		wantErr bool
+	}{
+		{"0", "not JSON", "", true},
+		{"1", string(line), "some first error", false},
+		{"2", `{"message":"some second error"}`, "some second error", false},
+		{"3", `{"text":"main.go:7: some third error\n\tOwner: me\n"}`, "main.go:7: some third error", false},
+		{"4", `{"v":1,"message":[]}`, "", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCollector(2)
+			err := c.add([]byte(tt.line))
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("%q: tCollector.add() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := c.recentList()
+			if (1 != len(got)) || (got[0].Message != tt.wantMsg) {
+				t.Errorf("%q: tCollector.add() = %v, want message %q",
+					tt.name, got, tt.wantMsg)
+			}
+		})
+	}
+} // Test_tCollector_add()
+
+func Test_tCollector_evictGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxGroups int
+		messages  []string
+		want      []string
+	}{
+		{"0", 2, []string{"a", "b"}, []string{"a", "b"}},
+		{"1", 2, []string{"a", "b", "c"}, []string{"b", "c"}},
+		{"2", 2, []string{"a", "b", "a", "c"}, []string{"a", "c"}},
+		{"3", 1, []string{"a", "b", "c", "d"}, []string{"d"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCollector(2)
+			c.maxGroups = tt.maxGroups
+			for _, msg := range tt.messages {
+				line, _ := json.Marshal(map[string]string{"message": msg})
+				if err := c.add(line); nil != err {
+					t.Fatalf("%q: tCollector.add() error = %v", tt.name, err)
+				}
+			}
+			var got []string
+			for _, group := range c.groupList() {
+				got = append(got, group.Message)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("%q: tCollector.groupList() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_tCollector_evictGroup()
+
+func Test_tCollector_handle(t *testing.T) {
+	e1 := sourceerror.Wrap(errors.New("some first error"), 0)
+	line, _ := json.Marshal(e1)
+	input := string(line) + "\n" +
+		`{"message":"some second error"}` + "\n" +
+		"\n" +
+		"garbage\n" +
+		string(line) + "\n"
+
+	c := newTestCollector(2)
+	if got := c.handle(strings.NewReader(input)); 1 != got {
+		t.Errorf("tCollector.handle() = %d, want 1", got)
+	}
+
+	groups := c.groupList()
+	if (2 != len(groups)) || (2 != groups[0].Count) ||
+		(sourceerror.Fingerprint(e1) != groups[0].Fingerprint) {
+		t.Fatalf("tCollector.groupList() = %v, want 2 groups with %s first",
+			groups, sourceerror.Fingerprint(e1))
+	}
+
+	// the ring buffer keeps the latest two errors, newest first
+	recent := c.recentList()
+	if (2 != len(recent)) || ("some first error" != recent[0].Message) ||
+		("some second error" != recent[1].Message) {
+		t.Errorf("tCollector.recentList() = %v, want the last two errors", recent)
+	}
+
+	// deliberate aborts are listed after the incidents
+	other := newTestCollector(2)
+	aborted, _ := json.Marshal(sourceerror.Wrap(
+		sourceerror.MarkIntentional(errors.New("maintenance")), 0))
+	for _, line := range [][]byte{aborted, aborted, line} {
+		if err := other.add(line); nil != err {
+			t.Fatalf("tCollector.add() error = %v", err)
+		}
+	}
+	if got := other.groupList(); (2 != len(got)) || got[0].Intentional ||
+		!got[1].Intentional || (2 != got[1].Count) {
+		t.Errorf("tCollector.groupList() = %v, want the intentional group last", got)
+	}
+
+	routes := c.routes()
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{"0", http.MethodGet, "/", http.StatusOK, "some first error"},
+		{"1", http.MethodGet, "/api/groups", http.StatusOK, `"count":2`},
+		{"2", http.MethodGet, "/api/recent", http.StatusOK, `"some second error"`},
+		{"3", http.MethodGet, "/errors/" + groups[0].LastID, http.StatusOK, "some first error"},
+		{"4", http.MethodGet, "/errors/unknown", http.StatusNotFound, "not found"},
+		{"5", http.MethodPost, "/api/groups", http.StatusMethodNotAllowed, ""},
+		{"6", http.MethodGet, "/missing", http.StatusNotFound, ""},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			routes.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+			if (rec.Code != tt.wantStatus) || !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("%q: routes() = %d %q, want %d containing %q",
+					tt.name, rec.Code, rec.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+} // Test_tCollector_handle()
+
+/* _EoF_ */
@@ -0,0 +1,107 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+/*
+Command secollect is a small, self-hostable error tracker: it receives
+the errors sent by the `agent` package over a unix domain socket,
+aggregates them by their fingerprint, and offers a read-only HTTP
+interface to inspect them.
+
+Usage:
+
+	secollect [-socket path] [-http address] [-keep n] [-ttl duration]
+
+The HTTP interface provides an overview page ("/"), the error groups
+("/api/groups") and the latest errors ("/api/recent") as JSON, and the
+full data of a recent error ("/errors/{id}").
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `listen()` accepts the agents' connections on the given listener
+// until it's closed.
+//
+// Parameters:
+// - `aListener`: The unix domain socket to accept connections on.
+// - `aCollector`: The collector to aggregate the received errors.
+func listen(aListener net.Listener, aCollector *tCollector) {
+	for {
+		conn, err := aListener.Accept()
+		if nil != err {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("accept: %v", err)
+			}
+			return
+		}
+		go func() {
+			defer conn.Close()
+			if bad := aCollector.handle(conn); 0 < bad {
+				log.Printf("%d undecodable lines from agent", bad)
+			}
+		}()
+	}
+} // listen()
+
+func main() {
+	var (
+		socket = flag.String("socket", "/tmp/secollect.sock", "the unix domain socket to receive errors on")
+		addr   = flag.String("http", "localhost:8089", "the address of the HTTP interface")
+		keep   = flag.Int("keep", 1000, "the number of recent errors to keep")
+		ttl    = flag.Duration("ttl", 24*time.Hour, "how long to keep the full data of an error")
+	)
+	flag.Parse()
+
+	// remove a stale socket of a previous run
+	_ = os.Remove(*socket)
+	listener, err := net.Listen("unix", *socket)
+	if nil != err {
+		sourceerror.Exit(sourceerror.Wrap(err, 2))
+	}
+	defer os.Remove(*socket)
+
+	collector := newCollector(*keep, *ttl)
+	go listen(listener, collector)
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           collector.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("receiving errors on %s, serving http://%s/", *socket, *addr)
+	if err = server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		_ = os.Remove(*socket)
+		sourceerror.Exit(sourceerror.Wrap(err, 2))
+	}
+} // main()
+
+/* _EoF_ */
@@ -0,0 +1,291 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// Returned by `Decode()` for data in none of the known forms.
+	errUnknownForm = errors.New("unknown error representation")
+
+	// Regular expression matching the text produced by
+	// `ErrSource.String()` (see `stringPattern` and `idPattern`), and
+	// by `ErrSource.Error()` of older versions which started with the
+	// quoted `StringSourceLocation`.
+	reTextForm = regexp.MustCompile(`(?s)^(?:` +
+		regexp.QuoteMeta(strconv.Quote(StringSourceLocation)) + `\n)?` +
+		`(?:ID: (\S*)\n)?Error: (.*?)\n` +
+		`File: ("(?:[^"\\]|\\.)*")\nLine: (-?\d+)\n` +
+		`Function: ("(?:[^"\\]|\\.)*")\nStack: (.*)$`)
+
+	// Regular expressions matching the parts of `Position.String()`.
+	rePositionParts = map[string]*regexp.Regexp{
+		"record": regexp.MustCompile(`record (\d+)`),
+		"column": regexp.MustCompile(`column (\d+)`),
+		"offset": regexp.MustCompile(`offset (\d+)`),
+	}
+
+	// The fields following the call stack in the text produced by
+	// `ErrSource.String()`.
+	textTrailers = []string{
+		"\nForeign stack ", "\nExternal: ", "\nInput: ", "\nElapsed: ",
+		"\nPhase: ", "\nSeq: ", "\nTest: ", "\nChain:",
+	}
+)
+
+// `parsePositionText()` restores a position from its text as returned
+// by `Position.String()`.
+//
+// Parameters:
+// - `aText`: The position's text, e.g. "record 3, column 7".
+//
+// Returns:
+// - `*Position`: The restored position or `nil` if it's unknown.
+func parsePositionText(aText string) *Position {
+	var (
+		result Position
+		found  bool
+	)
+	for part, re := range rePositionParts {
+		match := re.FindStringSubmatch(aText)
+		if nil == match {
+			continue
+		}
+		value, err := strconv.ParseInt(match[1], 10, 64)
+		if nil != err {
+			continue
+		}
+		switch part {
+		case "record":
+			result.Record = int(value)
+		case "column":
+			result.Column = int(value)
+		case "offset":
+			result.Offset = value
+		}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+
+	return &result
+} // parsePositionText()
+
+// `parseTextForm()` restores the record of an error from the text
+// produced by `ErrSource.String()` (or `Detail()`).
+//
+// Parameters:
+// - `aText`: The error's text representation.
+//
+// Returns:
+// - `*tRecord`: The restored record.
+// - `error`: `errUnknownForm` if the text doesn't match.
+func parseTextForm(aText string) (*tRecord, error) {
+	match := reTextForm.FindStringSubmatch(aText)
+	if nil == match {
+		return nil, errUnknownForm
+	}
+	file, err := strconv.Unquote(match[3])
+	if nil != err {
+		return nil, errUnknownForm
+	}
+	function, err := strconv.Unquote(match[5])
+	if nil != err {
+		return nil, errUnknownForm
+	}
+	line, _ := strconv.Atoi(match[4])
+
+	result := &tRecord{
+		Version:  recordVersion,
+		ID:       match[1],
+		Message:  match[2],
+		File:     file,
+		Line:     line,
+		Function: function,
+	}
+	if "<nil>" == result.Message {
+		result.Message = ""
+	}
+
+	stack, trailers := match[6], ""
+	for _, trailer := range textTrailers {
+		if idx := strings.Index(stack, trailer); 0 <= idx {
+			stack, trailers = stack[:idx], stack[idx:]+trailers
+		}
+	}
+	result.Stack = stack
+
+	for _, field := range strings.Split(trailers, "\n") {
+		key, value, _ := strings.Cut(field, ": ")
+		switch key {
+		case "Elapsed":
+			result.Elapsed, _ = time.ParseDuration(value)
+		case "Input":
+			result.Input = parsePositionText(value)
+		case "Phase":
+			result.Phase = value
+		case "Seq":
+			result.Seq, _ = strconv.ParseUint(value, 10, 64)
+		case "Test":
+			result.Test = value
+		}
+	}
+
+	return result, nil
+} // parseTextForm()
+
+// `restoredStack()` returns the call stack text of the given frames in
+// the format of `debug.Stack()` (without the functions' arguments).
+//
+// Parameters:
+// - `aFrames`: The frames to render.
+//
+// Returns:
+// - `[]byte`: The restored call stack.
+func restoredStack(aFrames []Frame) []byte {
+	var sb strings.Builder
+	sb.WriteString(restoredHeader)
+	for _, frame := range aFrames {
+		sb.WriteString(formatRuntimeFrame(frame))
+	}
+
+	return []byte(sb.String())
+} // restoredStack()
+
+// `upgradeJSON()` restores the record of an error from any of the
+// JSON objects produced by this package (or its former versions):
+// - the records used by `Store.Persist()`, `SignedEncode()`, and
+// `EncryptedEncode()` with the call stack as text;
+// - the output of `ErrSource.MarshalJSON()` with the call stack as a
+// list of frames;
+// - the fields logged by `Attr()` and `ErrSource.MarshalLog()` (e.g.
+// "msg" instead of "message").
+//
+// Records of a newer version are restored as far as their fields are
+// known, so that binaries of different versions can exchange errors.
+//
+// Parameters:
+// - `aData`: The JSON object to decode.
+//
+// Returns:
+// - `*tRecord`: The restored record of the current version.
+// - `error`: A possible decoding error.
+func upgradeJSON(aData []byte) (*tRecord, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(aData, &fields); nil != err {
+		return nil, err
+	}
+	if nil == fields {
+		return nil, errUnknownForm
+	}
+
+	// the log fields name the message "msg"
+	if msg, ok := fields["msg"]; ok {
+		if _, ok := fields["message"]; !ok {
+			fields["message"] = msg
+		}
+		delete(fields, "msg")
+	}
+
+	var frames []Frame
+	if stack, ok := fields["stack"]; ok && bytes.HasPrefix(bytes.TrimSpace(stack), []byte("[")) {
+		if err := json.Unmarshal(stack, &frames); nil != err {
+			return nil, err
+		}
+		delete(fields, "stack")
+	}
+
+	// the log fields hold the duration and the input position in
+	// other forms than the records
+	elapsed, hasElapsed := fields["elapsed"]
+	delete(fields, "elapsed")
+	var inputText string
+	if input, ok := fields["input"]; ok && (nil == json.Unmarshal(input, &inputText)) {
+		delete(fields, "input")
+	}
+
+	data, err := json.Marshal(fields)
+	if nil != err {
+		return nil, err
+	}
+	result := &tRecord{}
+	if err = json.Unmarshal(data, result); nil != err {
+		return nil, err
+	}
+
+	if 0 < len(frames) {
+		result.Stack = string(restoredStack(frames))
+	}
+	if hasElapsed && (0 == result.Elapsed) {
+		var nanos int64
+		if nil == json.Unmarshal(elapsed, &nanos) {
+			result.Elapsed = time.Duration(nanos)
+		} else {
+			var text string
+			if nil == json.Unmarshal(elapsed, &text) {
+				result.Elapsed, _ = time.ParseDuration(text)
+			}
+		}
+	}
+	if "" != inputText {
+		result.Input = parsePositionText(inputText)
+	}
+	result.Version = recordVersion
+
+	return result, nil
+} // upgradeJSON()
+
+// --------------------------------------------------------------------------
+
+// `Decode()` restores an error from any of the representations
+// produced by this package, including those of its former versions,
+// so that programs of different versions can exchange errors (e.g.
+// during a rollout):
+// - JSON objects as produced by `ErrSource.MarshalJSON()`, by
+// `ErrSource.MarshalLog()`, or used by `Store.Persist()`;
+// - the text returned by `ErrSource.String()` and `Detail()`.
+//
+// NOTE: The wrapped error is restored as a plain error with the
+// original error's message only; the chain's inner layers, foreign
+// call stacks, and external locations of the text representation are
+// not restored.
+//
+// Parameters:
+// - `aData`: The serialised error.
+//
+// Returns:
+// - `*ErrSource`: The restored error.
+// - `error`: A possible decoding error.
+func Decode(aData []byte) (*ErrSource, error) {
+	var (
+		record *tRecord
+		err    error
+	)
+	if trimmed := bytes.TrimSpace(aData); bytes.HasPrefix(trimmed, []byte("{")) {
+		record, err = upgradeJSON(trimmed)
+	} else {
+		record, err = parseTextForm(string(aData))
+	}
+	if nil != err {
+		return nil, err
+	}
+
+	return record.source(), nil
+} // Decode()
+
+/* _EoF_ */
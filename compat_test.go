@@ -0,0 +1,105 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestDecode(t *testing.T) {
+	rich := *(Wrap(errors.New("some first error"), 0).(*ErrSource))
+	rich.Phase, rich.Elapsed, rich.Seq = "startup", 1500*time.Millisecond, 7
+	rich.Test, rich.Input = "TestDecode", &Position{Record: 3, Column: 7}
+
+	record, _ := json.Marshal(newRecord(&rich))
+	marshalled, _ := json.Marshal(rich)
+	logged, _ := json.Marshal(rich.MarshalLog())
+	untested := rich // the log fields omit the test's name
+	untested.Test = ""
+
+	tests := []struct {
+		name      string
+		data      string
+		want      *ErrSource
+		wantStack bool
+		wantErr   bool
+	}{
+		{"0", "", nil, false, true},
+		{"1", "some first error", nil, false, true},
+		{"2", "{broken", nil, false, true},
+		{"3", "null", nil, false, true},
+		{"4", string(record), &rich, true, false},
+		{"5", string(marshalled), &rich, true, false},
+		{"6", string(logged), &untested, false, false},
+		{"7", rich.String(), &rich, true, false},
+		{"8", `{"message":"some second error","file":"/src/a.go","line":7,"function":"main.f"}`,
+			&ErrSource{err: errors.New("some second error"), File: "/src/a.go", Line: 7, Function: "main.f"},
+			false, false},
+		{"9", `{"v":2,"message":"some second error","line":7,"owner":"team","elapsed":"2s"}`,
+			&ErrSource{err: errors.New("some second error"), Line: 7, Elapsed: 2 * time.Second},
+			false, false},
+		{"10", "Error: some second error\nFile: \"/src/a.go\"\nLine: 7\nFunction: \"main.f\"\nStack: ",
+			&ErrSource{err: errors.New("some second error"), File: "/src/a.go", Line: 7, Function: "main.f"},
+			false, false},
+		{"11", fmt.Sprintf("%q\n%s", StringSourceLocation, rich.primStr()), &rich, true, false},
+		{"12", fmt.Sprintf("%q\n"+stringPattern, StringSourceLocation, "some second error", "/src/a.go", 7, "main.f", ""),
+			&ErrSource{err: errors.New("some second error"), File: "/src/a.go", Line: 7, Function: "main.f"},
+			false, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode([]byte(tt.data))
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("%q: Decode() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got.Error() != tt.want.Error()) || (got.ID != tt.want.ID) ||
+				(got.Location() != tt.want.Location()) || (got.Phase != tt.want.Phase) ||
+				(got.Elapsed != tt.want.Elapsed) || (got.Seq != tt.want.Seq) ||
+				(got.Test != tt.want.Test) || !reflect.DeepEqual(got.Input, tt.want.Input) {
+				t.Errorf("%q: Decode() =\n%s\nwant\n%s", tt.name, got.Detail(), tt.want.Detail())
+			}
+			if frames := got.Frames(); (0 < len(frames)) != tt.wantStack {
+				t.Errorf("%q: Decode() stack = %v, want a stack: %v",
+					tt.name, frames, tt.wantStack)
+			}
+		})
+	}
+} // TestDecode()
+
+func Test_parsePositionText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want *Position
+	}{
+		{"0", "unknown position", nil},
+		{"1", "record 3, column 7", &Position{Record: 3, Column: 7}},
+		{"2", "offset 42", &Position{Offset: 42}},
+		{"3", (Position{Record: 1, Column: 2, Offset: 3}).String(), &Position{Record: 1, Column: 2, Offset: 3}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePositionText(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: parsePositionText() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_parsePositionText()
+
+/* _EoF_ */
@@ -0,0 +1,292 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sync/atomic"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Config` holds the settings controlling how much data is captured
+// for the wrapped errors.
+//
+// The settings can be read and changed concurrently at runtime, e.g.
+// to capture call stacks temporarily while investigating an incident:
+//
+//	sourceerror.DefaultConfig().SetCaptureStack(true)
+//
+// The zero value enables both the locations and the call stacks.
+// The package's wrapping functions use `DefaultConfig()`, while
+// `NewOpts()` accepts another configuration by `WithConfig()`.
+type Config struct {
+	disabled  atomic.Bool // whether locations are not captured
+	noStack   atomic.Bool // whether call stacks are not captured
+	lazyStack atomic.Bool // whether call stacks are resolved on demand
+	debugPage atomic.Bool // whether `RespondError()` may send debug pages
+
+	topFrame atomic.Pointer[FrameStrategy] // the location's strategy
+	maxDepth atomic.Int32                  // the limit of chain walks
+}
+
+var (
+	// The configuration used by the package's wrapping functions.
+	defaultConfig Config
+)
+
+// `ConfigError` describes an invalid configuration setting of this
+// package.
+//
+// The fields are as follows:
+// - `Setting`: The name of the invalid setting, e.g. "topframe".
+// - `Value`: The offending value (if any).
+// - `Reason`: Why the setting is invalid.
+type ConfigError struct {
+	Setting string
+	Value   string
+	Reason  string
+}
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+// - `string`: The error's description.
+func (ce *ConfigError) Error() string {
+	if "" == ce.Value {
+		return fmt.Sprintf("%s: %s", ce.Setting, ce.Reason)
+	}
+
+	return fmt.Sprintf("%s: %s %q", ce.Setting, ce.Reason, ce.Value)
+} // Error()
+
+// `CaptureStack()` tells whether the errors' call stacks are captured.
+//
+// NOTE: The deprecated `NOSTACK` and `NODEBUG` flags take precedence
+// if they are set.
+//
+// Returns:
+// - `bool`: `true` if call stacks are captured, `false` otherwise.
+func (c *Config) CaptureStack() bool {
+	return !NODEBUG && !c.noStacks()
+} // CaptureStack()
+
+// `DebugPage()` tells whether `RespondError()` may answer with an HTML
+// debug page (see `SetDebugPage()`).
+//
+// Returns:
+// - `bool`: `true` if debug pages are enabled, `false` otherwise.
+func (c *Config) DebugPage() bool {
+	return c.debugPage.Load()
+} // DebugPage()
+
+// `Enabled()` tells whether the errors' locations (and call stacks)
+// are captured at all.
+//
+// NOTE: The deprecated `NODEBUG` flag takes precedence if it's set.
+//
+// Returns:
+// - `bool`: `true` if locations are captured, `false` otherwise.
+func (c *Config) Enabled() bool {
+	return !c.noDebug()
+} // Enabled()
+
+// `LazyStack()` tells whether the errors' call stacks are captured as
+// program counters only and resolved on demand.
+//
+// Returns:
+// - `bool`: `true` if call stacks are resolved lazily, `false` otherwise.
+func (c *Config) LazyStack() bool {
+	return c.lazyStack.Load()
+} // LazyStack()
+
+// `MaxChainDepth()` returns the maximum number of errors inspected
+// when walking an error's chain (see `SetMaxChainDepth()`).
+//
+// If no limit was set, the configuration returned by `DefaultConfig()`
+// uses the deprecated `MaxChainDepth` variable while all others use
+// the limit of `DefaultConfig()`.
+//
+// Returns:
+// - `int`: The maximum number of errors of a chain.
+func (c *Config) MaxChainDepth() int {
+	if depth := c.maxDepth.Load(); 0 < depth {
+		return int(depth)
+	}
+	if &defaultConfig != c {
+		return defaultConfig.MaxChainDepth()
+	}
+
+	return MaxChainDepth
+} // MaxChainDepth()
+
+// `noDebug()` tells whether no locations are to be captured, taking
+// the deprecated `NODEBUG` flag into account.
+//
+// Returns:
+// - `bool`: `true` if locations are disabled, `false` otherwise.
+func (c *Config) noDebug() bool {
+	return NODEBUG || c.disabled.Load()
+} // noDebug()
+
+// `noStacks()` tells whether no call stacks are to be captured, taking
+// the deprecated `NOSTACK` flag into account.
+//
+// Returns:
+// - `bool`: `true` if call stacks are disabled, `false` otherwise.
+func (c *Config) noStacks() bool {
+	return NOSTACK || c.noStack.Load()
+} // noStacks()
+
+// `SetCaptureStack()` sets whether the errors' call stacks are
+// captured.
+//
+// Parameters:
+// - `aCapture`: Whether to capture call stacks.
+func (c *Config) SetCaptureStack(aCapture bool) {
+	c.noStack.Store(!aCapture)
+} // SetCaptureStack()
+
+// `SetDebugPage()` sets whether `RespondError()` may answer requests
+// accepting HTML with a debug page showing the error's locations and
+// call stack, and whether `ToGraphQLError()` adds the error's location
+// and function; that's meant for local development only, since it
+// reveals source paths and internals to every client.
+//
+// NOTE: Debug output is sent in the `Development` profile only and is
+// disabled by default.
+//
+// Parameters:
+// - `aEnabled`: Whether to enable debug pages.
+func (c *Config) SetDebugPage(aEnabled bool) {
+	c.debugPage.Store(aEnabled)
+} // SetDebugPage()
+
+// `SetEnabled()` sets whether the errors' locations (and call stacks)
+// are captured at all; production ready code may disable them without
+// any need to change the source code otherwise.
+//
+// Parameters:
+// - `aEnabled`: Whether to capture locations.
+func (c *Config) SetEnabled(aEnabled bool) {
+	c.disabled.Store(!aEnabled)
+} // SetEnabled()
+
+// `SetLazyStack()` sets whether the errors' call stacks are captured
+// as program counters only, deferring the expensive formatting of
+// `debug.Stack()` until the stack is actually used (e.g. by `String()`,
+// `Frames()`, or `StackTrace()`); that's meant for hot paths creating
+// many errors which are mostly handled without ever being reported.
+//
+// NOTE: The `Stack` field of lazily captured errors remains empty, use
+// `ErrSource.StackTrace()` to get the call stack's text. The resolved
+// stack lacks the functions' arguments and the program's boot phase
+// (see `PhaseInit`) isn't recognised.
+//
+// Parameters:
+// - `aLazy`: Whether to resolve call stacks lazily.
+func (c *Config) SetLazyStack(aLazy bool) {
+	c.lazyStack.Store(aLazy)
+} // SetLazyStack()
+
+// `SetMaxChainDepth()` sets the maximum number of errors inspected
+// when walking an error's chain; longer chains are truncated (see
+// `ErrChainTooDeep`). It can be changed safely while errors are
+// inspected concurrently.
+//
+// NOTE: The chain traversing functions of this package (e.g. `Chain()`
+// or `AsSource()`) use the limit of `DefaultConfig()`.
+//
+// Parameters:
+// - `aDepth`: The maximum number of errors; values less than `1` reset
+// the limit to its default.
+func (c *Config) SetMaxChainDepth(aDepth int) {
+	c.maxDepth.Store(int32(max(0, min(aDepth, math.MaxInt32))))
+} // SetMaxChainDepth()
+
+// `SetTopFrame()` sets the strategy selecting the frame that becomes
+// the location of an error, e.g. `FirstNonInternal`; it can be changed
+// safely while errors are wrapped concurrently.
+//
+// Parameters:
+// - `aStrategy`: The strategy to use; `nil` is treated like `Raw`.
+func (c *Config) SetTopFrame(aStrategy FrameStrategy) {
+	c.topFrame.Store(&aStrategy)
+} // SetTopFrame()
+
+// `TopFrame()` returns the strategy selecting the frame that becomes
+// the location of an error (see `SetTopFrame()`).
+//
+// If no strategy was set, the configuration returned by
+// `DefaultConfig()` uses the deprecated `TopFrame` variable while all
+// other configurations use the strategy of `DefaultConfig()`.
+//
+// Returns:
+// - `FrameStrategy`: The strategy to select the errors' locations.
+func (c *Config) TopFrame() FrameStrategy {
+	if strategy := c.topFrame.Load(); nil != strategy {
+		return *strategy
+	}
+	if &defaultConfig != c {
+		return defaultConfig.TopFrame()
+	}
+
+	return TopFrame
+} // TopFrame()
+
+// --------------------------------------------------------------------------
+
+// `DefaultConfig()` returns the configuration used by the package's
+// wrapping functions (which is also changed by `UseProfile()`).
+//
+// Returns:
+// - `*Config`: The package's default configuration.
+func DefaultConfig() *Config {
+	return &defaultConfig
+} // DefaultConfig()
+
+// `ValidateConfig()` checks the package's current configuration so
+// that misconfigurations can fail fast at program start instead of
+// producing useless error data later on.
+//
+// The following problems are reported:
+// - an unknown profile name in the `SOURCEERROR_PROFILE` environment
+// variable (which is otherwise silently ignored);
+// - faults installed by `InjectFault()`, which are meant for tests only;
+// - options misused with `NewOpts()` so far (e.g. a negative skip).
+//
+// Returns:
+// - `error`: `nil` or the joined `*ConfigError`s describing all
+// problems found.
+func ValidateConfig() error {
+	var errs []error
+
+	if name := os.Getenv(ProfileEnvVar); "" != name {
+		if _, ok := ProfileByName(name); !ok {
+			errs = append(errs, &ConfigError{
+				Setting: ProfileEnvVar,
+				Value:   name,
+				Reason:  "unknown profile",
+			})
+		}
+	}
+	if n := faultCount.Load(); 0 < n {
+		errs = append(errs, &ConfigError{
+			Setting: "faults",
+			Reason:  fmt.Sprintf("%d injected fault(s) active", n),
+		})
+	}
+
+	errs = append(errs, optionProblemList()...)
+
+	return errors.Join(errs...)
+} // ValidateConfig()
+
+/* _EoF_ */
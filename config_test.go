@@ -0,0 +1,241 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestConfig(t *testing.T) {
+	defer func() {
+		NODEBUG, NOSTACK = false, false
+	}()
+
+	tests := []struct {
+		name         string
+		enabled      bool
+		capture      bool
+		nodebug      bool
+		nostack      bool
+		wantNoDebug  bool
+		wantNoStacks bool
+		wantEnabled  bool
+		wantCapture  bool
+	}{
+		{"0", true, true, false, false, false, false, true, true},
+		{"1", false, true, false, false, true, false, false, true},
+		{"2", true, false, false, false, false, true, true, false},
+		{"3", true, true, true, false, true, false, false, false},
+		{"4", true, true, false, true, false, true, true, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Config
+			c.SetEnabled(tt.enabled)
+			c.SetCaptureStack(tt.capture)
+			NODEBUG, NOSTACK = tt.nodebug, tt.nostack
+			if (c.Enabled() != tt.wantEnabled) || (c.CaptureStack() != tt.wantCapture) {
+				t.Errorf("%q: Config = %v, %v, want %v, %v",
+					tt.name, c.Enabled(), c.CaptureStack(), tt.wantEnabled, tt.wantCapture)
+			}
+			if (c.noDebug() != tt.wantNoDebug) || (c.noStacks() != tt.wantNoStacks) {
+				t.Errorf("%q: Config.noDebug(), noStacks() = %v, %v, want %v, %v",
+					tt.name, c.noDebug(), c.noStacks(), tt.wantNoDebug, tt.wantNoStacks)
+			}
+		})
+	}
+	NODEBUG, NOSTACK = false, false
+
+	// toggling the default configuration while wrapping errors
+	defer DefaultConfig().SetCaptureStack(true)
+	var wg sync.WaitGroup
+	for idx := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				if 0 == idx {
+					DefaultConfig().SetCaptureStack(!DefaultConfig().CaptureStack())
+				}
+				_ = Wrap(errors.New("some first error"), 0)
+			}
+		}()
+	}
+	wg.Wait()
+
+	DefaultConfig().SetCaptureStack(false)
+	if se := Wrap(errors.New("some first error"), 0).(*ErrSource); !se.StackOmitted {
+		t.Error("Wrap() captured a stack, want none")
+	}
+} // TestConfig()
+
+func TestConfig_TopFrame(t *testing.T) {
+	defer UseProfile(CurrentProfile())
+	var calls [2]int
+	counting := func(aIdx int) FrameStrategy {
+		return func([]Frame) int {
+			calls[aIdx]++
+			return 0
+		}
+	}
+	var custom Config
+
+	tests := []struct {
+		name      string
+		config    *Config
+		deflt     FrameStrategy
+		strategy  FrameStrategy
+		wantCalls [2]int
+	}{
+		{"0", DefaultConfig(), counting(0), nil, [2]int{1, 0}},
+		{"1", &custom, counting(0), nil, [2]int{1, 0}},
+		{"2", &custom, counting(0), counting(1), [2]int{0, 1}},
+		{"3", DefaultConfig(), counting(1), nil, [2]int{0, 1}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = [2]int{}
+			DefaultConfig().SetTopFrame(tt.deflt)
+			if nil != tt.strategy {
+				tt.config.SetTopFrame(tt.strategy)
+			}
+			_ = NewOpts(errors.New("some first error"), WithConfig(tt.config))
+			if calls != tt.wantCalls {
+				t.Errorf("%q: Config.TopFrame() calls = %v, want %v",
+					tt.name, calls, tt.wantCalls)
+			}
+		})
+	}
+
+	// switching profiles while wrapping errors
+	UseProfile(Development) // drop the counting strategies
+	var wg sync.WaitGroup
+	for idx := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				if 0 == idx {
+					UseProfile(Staging)
+					UseProfile(Development)
+				}
+				_ = Wrap(errors.New("some first error"), 0)
+			}
+		}()
+	}
+	wg.Wait()
+} // TestConfig_TopFrame()
+
+func TestConfig_MaxChainDepth(t *testing.T) {
+	defer DefaultConfig().SetMaxChainDepth(0)
+	var custom Config
+
+	tests := []struct {
+		name   string
+		config *Config
+		deflt  int
+		depth  int
+		want   int
+	}{
+		{"0", DefaultConfig(), 0, 0, MaxChainDepth},
+		{"1", DefaultConfig(), 5, 0, 5},
+		{"2", &custom, 5, 0, 5},
+		{"3", &custom, 5, 7, 7},
+		{"4", DefaultConfig(), -1, 0, MaxChainDepth},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			DefaultConfig().SetMaxChainDepth(tt.deflt)
+			if 0 != tt.depth {
+				tt.config.SetMaxChainDepth(tt.depth)
+			}
+			if got := tt.config.MaxChainDepth(); got != tt.want {
+				t.Errorf("%q: Config.MaxChainDepth() = %d, want %d",
+					tt.name, got, tt.want)
+			}
+			if got := len(Chain(tDeepErr(0))); got != DefaultConfig().MaxChainDepth()+1 {
+				t.Errorf("%q: Chain() = %d errors, want %d",
+					tt.name, got, DefaultConfig().MaxChainDepth()+1)
+			}
+		})
+	}
+} // TestConfig_MaxChainDepth()
+
+func TestConfigError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		ce   *ConfigError
+		want string
+	}{
+		{"0", &ConfigError{"key", "", "unknown key"}, "key: unknown key"},
+		{"1", &ConfigError{"nodebug", "maybe", "invalid boolean"},
+			`nodebug: invalid boolean "maybe"`},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ce.Error(); got != tt.want {
+				t.Errorf("%q: ConfigError.Error() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestConfigError_Error()
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		fault   bool
+		misuse  Option
+		wantErr string
+	}{
+		{"0", "", false, nil, ""},
+		{"1", "prod", false, nil, ""},
+		{"2", "testing", false, nil, "unknown profile"},
+		{"3", "", true, nil, "injected fault"},
+		{"4", "", false, WithSkip(-1), `WithSkip: negative frame count "-1"`},
+		{"5", "", false, WithLineOffset(-2), `WithLineOffset: negative line offset "-2"`},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ProfileEnvVar, tt.env)
+			if tt.fault {
+				defer InjectFault(Fault{Message: "injected"})()
+			}
+			optionMtx.Lock()
+			optionProblems = nil
+			optionMtx.Unlock()
+			if nil != tt.misuse {
+				_ = NewOpts(errors.New("some first error"), tt.misuse)
+			}
+			err := ValidateConfig()
+			if "" == tt.wantErr {
+				if nil != err {
+					t.Errorf("%q: ValidateConfig() = %v, want <nil>", tt.name, err)
+				}
+				return
+			}
+			var ce *ConfigError
+			if !errors.As(err, &ce) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%q: ValidateConfig() = %v, want %q",
+					tt.name, err, tt.wantErr)
+			}
+		})
+	}
+} // TestValidateConfig()
+
+/* _EoF_ */
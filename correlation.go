@@ -0,0 +1,103 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `correlationKey()` implements `CorrelationKey()`.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+// - `aWindow`: The duration of the time buckets.
+// - `aNow`: The current time.
+//
+// Returns:
+// - `string`: The error's correlation key.
+func correlationKey(aErr error, aWindow time.Duration, aNow time.Time) string {
+	fingerprint := Fingerprint(aErr)
+	if ("" == fingerprint) || (0 >= aWindow) {
+		return fingerprint
+	}
+
+	return fingerprint + "@" +
+		strconv.FormatInt(aNow.Truncate(aWindow).Unix(), 10)
+} // correlationKey()
+
+// `CorrelationKey()` returns a key identifying the given error's site
+// within the current time window, e.g. for sampling decisions of an
+// external logger:
+//
+//	key := sourceerror.CorrelationKey(err, time.Minute)
+//	if keep(hash(key) % 100 < 10) { ... }
+//
+// All errors of the same site (see `Fingerprint()`) share the same key
+// within a window so that they are consistently kept or dropped by a
+// sampler hashing the key, while the next window yields a new key and
+// hence a new sampling decision.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+// - `aWindow`: The duration of the time windows; a value <= 0 returns
+// the error's fingerprint only.
+//
+// Returns:
+// - `string`: The error's correlation key or an empty string for `nil`.
+func CorrelationKey(aErr error, aWindow time.Duration) string {
+	return correlationKey(aErr, aWindow, time.Now())
+} // CorrelationKey()
+
+// `Fingerprint()` returns a hash identifying the site of the given
+// error, i.e. the functions and lines of its chain's `ErrSource`
+// layers as well as the type of the chain's root error.
+//
+// Unlike the instance ID (see `ID()`), the fingerprint is the same for
+// all errors produced by the same code path, regardless of their
+// messages which often contain variable data.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `string`: The error's fingerprint (16 hex digits) or an empty
+// string for `nil`.
+func Fingerprint(aErr error) string {
+	if nil == aErr {
+		return ""
+	}
+
+	hash := fnv.New64a()
+	root := aErr
+	_ = walkChain(aErr, func(aLink error) bool {
+		root = aLink
+		var loc Location
+		switch se := aLink.(type) {
+		case *ErrSource:
+			if nil == se {
+				return false
+			}
+			loc = se.Location()
+		case ErrSource:
+			loc = se.Location()
+		default:
+			return true
+		}
+		fmt.Fprintf(hash, "%s:%d\n", loc.Function, loc.Line)
+		return true
+	})
+	fmt.Fprintf(hash, "%T", root)
+
+	return fmt.Sprintf("%016x", hash.Sum64())
+} // Fingerprint()
+
+/* _EoF_ */
@@ -0,0 +1,87 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `failAt()` returns an error wrapped at always the same site.
+func failAt(aMessage string) error {
+	return Wrap(errors.New(aMessage), 0)
+} // failAt()
+
+func TestFingerprint(t *testing.T) {
+	cl1 := failAt("user 1 not found")
+
+	tests := []struct {
+		name      string
+		err1      error
+		err2      error
+		wantEqual bool
+	}{
+		{"0", cl1, failAt("user 2 not found"), true},
+		{"1", cl1, Wrap(errors.New("user 1 not found"), 0), false},
+		{"2", cl1, failAt("x"), true},
+		{"3", errors.New("a"), errors.New("b"), true},
+		{"4", errors.New("a"), os.ErrNotExist, true},
+		{"5", errors.New("a"), fmt.Errorf("x: %w", &os.PathError{}), false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp1, fp2 := Fingerprint(tt.err1), Fingerprint(tt.err2)
+			if 16 != len(fp1) {
+				t.Errorf("%q: Fingerprint() = %q, want 16 hex digits", tt.name, fp1)
+			}
+			if (fp1 == fp2) != tt.wantEqual {
+				t.Errorf("%q: Fingerprint() = %q and %q, wantEqual %v",
+					tt.name, fp1, fp2, tt.wantEqual)
+			}
+		})
+	}
+
+	if got := Fingerprint(nil); "" != got {
+		t.Errorf("Fingerprint(nil) = %q, want empty", got)
+	}
+} // TestFingerprint()
+
+func Test_correlationKey(t *testing.T) {
+	cl1 := failAt("some first error")
+	fp := Fingerprint(cl1)
+	now := time.Unix(1000, 0)
+
+	tests := []struct {
+		name   string
+		err    error
+		window time.Duration
+		now    time.Time
+		want   string
+	}{
+		{"0", nil, time.Minute, now, ""},
+		{"1", cl1, 0, now, fp},
+		{"2", cl1, time.Minute, now, fp + "@960"},
+		{"3", failAt("another error"), time.Minute, now.Add(19 * time.Second), fp + "@960"},
+		{"4", cl1, time.Minute, now.Add(20 * time.Second), fp + "@1020"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := correlationKey(tt.err, tt.window, tt.now); got != tt.want {
+				t.Errorf("%q: correlationKey() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_correlationKey()
+
+/* _EoF_ */
@@ -0,0 +1,66 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The prefix of the compiler's names for generic shape types.
+	shapePrefix = "go.shape."
+)
+
+var (
+	// If set `true` (the default), the compiler's shape names of generic
+	// instantiations (e.g. `pkg.Func[go.shape.int_0]`) are simplified
+	// to their type arguments (e.g. `pkg.Func[int]`) in function names
+	// and rendered call stacks.
+	DemangleGenerics = true
+
+	// Regular expression matching a shape type argument along with its
+	// optional (older compilers') numeric suffix.
+	reShape = regexp.MustCompile(`go\.shape\.([^,\]]+?)(?:_\d+)?([,\]])`)
+)
+
+// `demangle()` simplifies the shape names of generic instantiations
+// in the given function name (see `DemangleGenerics`).
+//
+// Parameters:
+// - `aFunction`: The function name to simplify.
+//
+// Returns:
+// - `string`: The simplified function name.
+func demangle(aFunction string) string {
+	if !DemangleGenerics || !strings.Contains(aFunction, shapePrefix) {
+		return aFunction
+	}
+
+	return reShape.ReplaceAllString(aFunction, "$1$2")
+} // demangle()
+
+// `demangleStack()` simplifies the shape names of generic
+// instantiations in the given call stack text (see `DemangleGenerics`).
+//
+// Parameters:
+// - `aStack`: The call stack to simplify.
+//
+// Returns:
+// - `[]byte`: The simplified call stack.
+func demangleStack(aStack []byte) []byte {
+	if !DemangleGenerics || !bytes.Contains(aStack, []byte(shapePrefix)) {
+		return aStack
+	}
+
+	return reShape.ReplaceAll(aStack, []byte("$1$2"))
+} // demangleStack()
+
+/* _EoF_ */
@@ -0,0 +1,50 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_demangle(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		disabled bool
+		want     string
+	}{
+		{"0", "main.main", false, "main.main"},
+		{"1", "main.F[...]", false, "main.F[...]"},
+		{"2", "pkg.Func[go.shape.int_0]", false, "pkg.Func[int]"},
+		{"3", "pkg.Map[go.shape.string,go.shape.*uint8]", false, "pkg.Map[string,*uint8]"},
+		{"4", "pkg.(*List[go.shape.struct { a int }]).Push", false,
+			"pkg.(*List[struct { a int }]).Push"},
+		{"5", "pkg.Func[go.shape.int_0]", true, "pkg.Func[go.shape.int_0]"},
+		{"6", "pkg.Func[go.shape.[]int]", false, "pkg.Func[[]int]"},
+		// TODO: Add test cases.
+	}
+	defer func() {
+		DemangleGenerics = true
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			DemangleGenerics = !tt.disabled
+			if got := demangle(tt.function); got != tt.want {
+				t.Errorf("%q: demangle() = %q, want %q", tt.name, got, tt.want)
+			}
+			stack := "goroutine 1 [running]:\n" + tt.function + "(...)\n\tx.go:1\n"
+			want := "goroutine 1 [running]:\n" + tt.want + "(...)\n\tx.go:1\n"
+			if got := string(demangleStack([]byte(stack))); got != want {
+				t.Errorf("%q: demangleStack() = %q, want %q", tt.name, got, want)
+			}
+		})
+	}
+} // Test_demangle()
+
+/* _EoF_ */
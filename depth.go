@@ -0,0 +1,91 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Depth()` returns the number of errors in the given error's chain
+// (following `errors.Unwrap()` and the registered cause extractors),
+// including the error itself.
+//
+// The count is limited to `MaxChainDepth()` of `DefaultConfig()`.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `int`: The length of the error's chain or `0` for `nil`.
+func Depth(aErr error) int {
+	result := 0
+	_ = walkChain(aErr, func(aLink error) bool {
+		result++
+		se, ok := aLink.(*ErrSource)
+		return !ok || (nil != se)
+	})
+
+	return result
+} // Depth()
+
+// `RegisterDepthWarning()` registers a hook called whenever an error
+// is wrapped that contains more than the given number of `ErrSource`
+// layers (see `WrapCount()`), helping to detect accidental wrapping
+// in a loop:
+//
+//	defer sourceerror.RegisterDepthWarning(10, func(w sourceerror.Warning) {
+//		log.Println(w)
+//	})()
+//
+// The warning's location is the one of the newly wrapped error.
+//
+// Parameters:
+// - `aThreshold`: The maximum number of layers not warned about.
+// - `aHook`: The function to call with the warning.
+//
+// Returns:
+// - `func()`: A function removing the registered hook.
+func RegisterDepthWarning(aThreshold int, aHook func(Warning)) func() {
+	return RegisterWrapHook(func(aSource *ErrSource) {
+		if count := WrapCount(aSource); count > aThreshold {
+			aHook(Warning{
+				Location: aSource.Location(),
+				Message:  fmt.Sprintf("error wrapped %d times", count),
+			})
+		}
+	})
+} // RegisterDepthWarning()
+
+// `WrapCount()` returns the number of `ErrSource` layers in the given
+// error's chain.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `int`: The number of `ErrSource` layers.
+func WrapCount(aErr error) int {
+	result := 0
+	_ = walkChain(aErr, func(aLink error) bool {
+		switch se := aLink.(type) {
+		case *ErrSource:
+			if nil == se {
+				return false
+			}
+			result++
+		case ErrSource:
+			result++
+		}
+		return true
+	})
+
+	return result
+} // WrapCount()
+
+/* _EoF_ */
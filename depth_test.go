@@ -0,0 +1,72 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestDepth(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	cl2 := Wrap(fmt.Errorf("repo: %w", cl1), 0)
+	var nilSource *ErrSource
+
+	tests := []struct {
+		name      string
+		err       error
+		wantDepth int
+		wantWraps int
+	}{
+		{"0", nil, 0, 0},
+		{"1", e, 1, 0},
+		{"2", cl1, 2, 1},
+		{"3", cl2, 4, 2},
+		{"4", ErrSource{err: cl1}, 3, 2},
+		{"5", nilSource, 1, 0},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Depth(tt.err); got != tt.wantDepth {
+				t.Errorf("%q: Depth() = %d, want %d", tt.name, got, tt.wantDepth)
+			}
+			if got := WrapCount(tt.err); got != tt.wantWraps {
+				t.Errorf("%q: WrapCount() = %d, want %d", tt.name, got, tt.wantWraps)
+			}
+		})
+	}
+} // TestDepth()
+
+func TestRegisterDepthWarning(t *testing.T) {
+	var warnings []Warning
+	remove := RegisterDepthWarning(2, func(aWarning Warning) {
+		warnings = append(warnings, aWarning)
+	})
+
+	err := errors.New("some first error")
+	for range 4 {
+		err = Wrap(err, 0)
+	}
+	remove()
+	_ = Wrap(err, 0)
+
+	if 2 != len(warnings) {
+		t.Fatalf("RegisterDepthWarning() = %v, want 2 warnings", warnings)
+	}
+	if got := warnings[1].String(); !strings.Contains(got, "depth_test.go:") ||
+		!strings.HasSuffix(got, "warning: error wrapped 4 times") {
+		t.Errorf("RegisterDepthWarning() = %q", got)
+	}
+} // TestRegisterDepthWarning()
+
+/* _EoF_ */
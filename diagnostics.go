@@ -0,0 +1,46 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Diagnostics()` returns the problems of the options the given error
+// was created with by `NewOpts()`, e.g. a negative skip or a `nil`
+// option, which were ignored while creating the error:
+//
+//	err := sourceerror.NewOpts(err, opts...)
+//	if diag := sourceerror.Diagnostics(err); nil != diag {
+//		log.Println("misconfigured error:", diag)
+//	}
+//
+// The first `ErrSource` in the chain with diagnostics determines the
+// result.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `error`: `nil` or the joined `*ConfigError`s describing all
+// misused options.
+func Diagnostics(aErr error) error {
+	var result error
+	_ = walkChain(aErr, func(aLink error) bool {
+		switch se := aLink.(type) {
+		case *ErrSource:
+			if nil != se {
+				result = se.diagnostics
+			}
+		case ErrSource:
+			result = se.diagnostics
+		}
+		return nil == result
+	})
+
+	return result
+} // Diagnostics()
+
+/* _EoF_ */
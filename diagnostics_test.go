@@ -0,0 +1,61 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestDiagnostics(t *testing.T) {
+	e1 := errors.New("some first error")
+	misused := NewOpts(e1, WithSkip(-1), nil, WithLineOffset(-2), WithConfig(nil))
+
+	tests := []struct {
+		name     string
+		err      error
+		wantDiag int
+	}{
+		{"0", nil, 0},
+		{"1", e1, 0},
+		{"2", Wrap(e1, 0), 0},
+		{"3", NewOpts(e1, WithSkip(0), WithLineOffset(1)), 0},
+		{"4", misused, 4},
+		{"5", Wrap(fmt.Errorf("loading: %w", misused), 0), 4},
+		{"6", WithPhase(misused, "startup"), 4},
+		{"7", NewOpts(e1, nil), 1},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diagnostics(tt.err)
+			var problems []error
+			if joined, ok := got.(interface{ Unwrap() []error }); ok {
+				problems = joined.Unwrap()
+			}
+			if len(problems) != tt.wantDiag {
+				t.Errorf("%q: Diagnostics() = %v, want %d problems",
+					tt.name, got, tt.wantDiag)
+			}
+			for _, problem := range problems {
+				var ce *ConfigError
+				if !errors.As(problem, &ce) {
+					t.Errorf("%q: Diagnostics() = %T, want *ConfigError", tt.name, problem)
+				}
+			}
+		})
+	}
+
+	if se := misused.(*ErrSource); nil == se.Stack {
+		t.Errorf("NewOpts() = %s, want an error despite the misused options", se)
+	}
+} // TestDiagnostics()
+
+/* _EoF_ */
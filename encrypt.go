@@ -0,0 +1,173 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tEncrypted` is the envelope of an encrypted error record.
+	tEncrypted struct {
+		Algorithm  string `json:"alg"`
+		Ephemeral  []byte `json:"epk"`
+		Nonce      []byte `json:"nonce"`
+		Ciphertext []byte `json:"ct"`
+	}
+)
+
+const (
+	// The encryption scheme used by `EncryptedEncode()`.
+	encryptAlgorithm = "X25519-A256GCM"
+)
+
+var (
+	// Error returned if no (or a non-X25519) key is given.
+	errBadKey = errors.New("missing or non-X25519 key")
+)
+
+// `sealKey()` derives the symmetric key from an X25519 key exchange.
+//
+// Parameters:
+// - `aShared`: The shared secret of the key exchange.
+// - `aEphemeral`: The sender's ephemeral public key.
+// - `aRecipient`: The recipient's public key.
+//
+// Returns:
+// - `cipher.AEAD`: The cipher to seal or open the payload.
+// - `error`: A possible error during cipher setup.
+func sealKey(aShared []byte, aEphemeral, aRecipient *ecdh.PublicKey) (cipher.AEAD, error) {
+	hash := sha256.New()
+	hash.Write(aShared)
+	hash.Write(aEphemeral.Bytes())
+	hash.Write(aRecipient.Bytes())
+
+	block, err := aes.NewCipher(hash.Sum(nil))
+	if nil != err {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+} // sealKey()
+
+// `EncryptedEncode()` serialises the given error (as JSON) and
+// encrypts it for the holder of the private key matching the given
+// public key, so that error reports with possibly sensitive data can
+// be shipped through untrusted intermediaries.
+//
+// Each report is encrypted with a fresh ephemeral X25519 key and
+// AES-256-GCM; use `DecryptDecode()` to restore the error.
+//
+// Parameters:
+// - `aErr`: The error to encode.
+// - `aPublicKey`: The recipient's X25519 public key.
+//
+// Returns:
+// - `[]byte`: The encrypted error report.
+// - `error`: A possible error during encoding.
+func EncryptedEncode(aErr error, aPublicKey *ecdh.PublicKey) ([]byte, error) {
+	if (nil == aPublicKey) || (ecdh.X25519() != aPublicKey.Curve()) {
+		return nil, errBadKey
+	}
+	if nil == aErr {
+		return nil, errNilError
+	}
+
+	payload, err := json.Marshal(recordOf(aErr))
+	if nil != err {
+		return nil, err
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if nil != err {
+		return nil, err
+	}
+	shared, err := ephemeral.ECDH(aPublicKey)
+	if nil != err {
+		return nil, err
+	}
+	aead, err := sealKey(shared, ephemeral.PublicKey(), aPublicKey)
+	if nil != err {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); nil != err {
+		return nil, err
+	}
+
+	return json.Marshal(tEncrypted{
+		Algorithm:  encryptAlgorithm,
+		Ephemeral:  ephemeral.PublicKey().Bytes(),
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, payload, []byte(encryptAlgorithm)),
+	})
+} // EncryptedEncode()
+
+// `DecryptDecode()` decrypts an error report created by
+// `EncryptedEncode()` and restores the error.
+//
+// NOTE: The wrapped error is restored as a plain error with the
+// original error's message only.
+//
+// Parameters:
+// - `aData`: The encrypted error report.
+// - `aPrivateKey`: The recipient's X25519 private key.
+//
+// Returns:
+// - `*ErrSource`: The restored error.
+// - `error`: An error if the report is malformed or can't be decrypted.
+func DecryptDecode(aData []byte, aPrivateKey *ecdh.PrivateKey) (*ErrSource, error) {
+	if (nil == aPrivateKey) || (ecdh.X25519() != aPrivateKey.Curve()) {
+		return nil, errBadKey
+	}
+
+	var sealed tEncrypted
+	if err := json.Unmarshal(aData, &sealed); nil != err {
+		return nil, err
+	}
+	if encryptAlgorithm != sealed.Algorithm {
+		return nil, fmt.Errorf("unsupported encryption algorithm %q",
+			sealed.Algorithm)
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(sealed.Ephemeral)
+	if nil != err {
+		return nil, err
+	}
+	shared, err := aPrivateKey.ECDH(ephemeral)
+	if nil != err {
+		return nil, err
+	}
+	aead, err := sealKey(shared, ephemeral, aPrivateKey.PublicKey())
+	if nil != err {
+		return nil, err
+	}
+	if aead.NonceSize() != len(sealed.Nonce) {
+		return nil, fmt.Errorf("invalid nonce size %d", len(sealed.Nonce))
+	}
+	payload, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext,
+		[]byte(encryptAlgorithm))
+	if nil != err {
+		return nil, err
+	}
+
+	record, err := upgradeJSON(payload)
+	if nil != err {
+		return nil, err
+	}
+
+	return record.source(), nil
+} // DecryptDecode()
+
+/* _EoF_ */
@@ -0,0 +1,102 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestEncryptedEncode(t *testing.T) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if nil != err {
+		t.Fatalf("GenerateKey() = %v", err)
+	}
+	p256, _ := ecdh.P256().GenerateKey(rand.Reader)
+	e := errors.New("some first error")
+	tests := []struct {
+		name    string
+		err     error
+		key     *ecdh.PublicKey
+		wantErr bool
+	}{
+		{"0", nil, key.PublicKey(), true},
+		{"1", e, nil, true},
+		{"2", e, p256.PublicKey(), true},
+		{"3", e, key.PublicKey(), false},
+		{"4", Wrap(e, 0), key.PublicKey(), false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncryptedEncode(tt.err, tt.key)
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: EncryptedEncode() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if nil != err {
+				return
+			}
+			if bytes.Contains(data, []byte(e.Error())) {
+				t.Errorf("%q: EncryptedEncode() contains plain text", tt.name)
+			}
+			got, err := DecryptDecode(data, key)
+			if nil != err {
+				t.Errorf("%q: DecryptDecode() error = %v", tt.name, err)
+				return
+			}
+			if got.message() != e.Error() {
+				t.Errorf("%q: DecryptDecode() message = %q, want %q",
+					tt.name, got.message(), e.Error())
+			}
+			if se, ok := asSource(tt.err); ok && (got.Location() != se.Location()) {
+				t.Errorf("%q: DecryptDecode() location = %v, want %v",
+					tt.name, got.Location(), se.Location())
+			}
+		})
+	}
+} // TestEncryptedEncode()
+
+func TestDecryptDecode(t *testing.T) {
+	key, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	other, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	data, err := EncryptedEncode(errors.New("some first error"), key.PublicKey())
+	if nil != err {
+		t.Fatalf("EncryptedEncode() = %v", err)
+	}
+	tampered := bytes.Replace(data, []byte(`"ct":"`), []byte(`"ct":"AAAA`), 1)
+	tests := []struct {
+		name    string
+		data    []byte
+		key     *ecdh.PrivateKey
+		wantErr bool
+	}{
+		{"0", data, key, false},
+		{"1", data, other, true},
+		{"2", data, nil, true},
+		{"3", tampered, key, true},
+		{"4", []byte("garbage"), key, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecryptDecode(tt.data, tt.key)
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: DecryptDecode() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+		})
+	}
+} // TestDecryptDecode()
+
+/* _EoF_ */
@@ -0,0 +1,39 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Errorf()` works like `fmt.Errorf()` but returns the formatted error
+// wrapped in an `ErrSource` with the caller's location and call stack:
+//
+//	if nil != err {
+//		return sourceerror.Errorf("saving %q: %w", name, err)
+//	}
+//
+// Like with `fmt.Errorf()` the `%w` verb wraps its operand (which may
+// be given several times), so that `errors.Is()` and `errors.As()`
+// find all the wrapped errors.
+// Operands of the `ErrSource` type are formatted by their message
+// only (see `ErrSource.Format()`).
+//
+// Parameters:
+// - `aFormat`: The format of the error's message.
+// - `aArgs`: The values to format.
+//
+// Returns:
+// - `error`: A new `ErrSource` instance that contains the formatted
+// error, as well as file, function, and line number of the caller.
+func Errorf(aFormat string, aArgs ...any) error {
+	return newSource(fmt.Errorf(aFormat, aArgs...), 0, 1, defaultConfig.TopFrame())
+} // Errorf()
+
+/* _EoF_ */
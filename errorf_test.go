@@ -0,0 +1,56 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestErrorf(t *testing.T) {
+	e1 := errors.New("some first error")
+	e2 := Wrap(errors.New("some second error"), 0)
+
+	tests := []struct {
+		name    string
+		format  string
+		args    []any
+		want    string
+		wantIs  []error
+		wantNot error
+	}{
+		{"0", "plain", nil, "plain", nil, e1},
+		{"1", "saving %q: %w", []any{"a.txt", e1}, `saving "a.txt": some first error`, []error{e1}, e2},
+		{"2", "%w and %w", []any{e1, e2}, "some first error and some second error", []error{e1, e2}, nil},
+		{"3", "saving: %v", []any{e1}, "saving: some first error", nil, e1},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, line, _ := runtime.Caller(0)
+			err := Errorf(tt.format, tt.args...)
+			se, ok := err.(*ErrSource)
+			if !ok || (se.Error() != tt.want) || (se.Line != line+1) {
+				t.Fatalf("%q: Errorf() = %q, want %q at line %d",
+					tt.name, err, tt.want, line+1)
+			}
+			for _, target := range tt.wantIs {
+				if !errors.Is(err, target) {
+					t.Errorf("%q: Errorf() doesn't wrap %q", tt.name, target)
+				}
+			}
+			if (nil != tt.wantNot) && errors.Is(err, tt.wantNot) {
+				t.Errorf("%q: Errorf() wraps %q", tt.name, tt.wantNot)
+			}
+		})
+	}
+} // TestErrorf()
+
+/* _EoF_ */
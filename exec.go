@@ -0,0 +1,62 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"context"
+	"errors"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `WrapExec()` wraps the error of a command run with the given context
+// (e.g. by `exec.CommandContext()`) with the caller's location:
+//
+//	cmd := exec.CommandContext(ctx, "pg_dump", dbName)
+//	if err := cmd.Run(); nil != err {
+//		return sourceerror.WrapExec(ctx, err)
+//	}
+//
+// If the context is done, the command usually fails with a rather
+// meaningless "signal: killed" error, while the actual reason is the
+// context's cause (see `context.Cause()`).
+// In that case the result joins the command's error, the context's
+// error, and the context's cause (if it's a different one), so that
+// all of them can be found by `errors.Is()` and `errors.As()`.
+// The result's location is the caller's one, while it keeps the call
+// stack of the context's cause as well if that's an `ErrSource` (e.g.
+// created where the context was cancelled by a
+// `context.CancelCauseFunc`).
+//
+// Parameters:
+// - `aCtx`: The context the command was run with.
+// - `aErr`: The command's error.
+//
+// Returns:
+// - `error`: A new `ErrSource` instance, or `nil` if `aErr` is `nil`.
+func WrapExec(aCtx context.Context, aErr error) error {
+	if nil == aErr {
+		return nil
+	}
+
+	if (nil == aCtx) || (nil == aCtx.Err()) || errors.Is(aErr, aCtx.Err()) {
+		return newSource(aErr, 0, 1, defaultConfig.TopFrame())
+	}
+
+	errs := []error{aErr, aCtx.Err()}
+	cause := context.Cause(aCtx)
+	if cause != aCtx.Err() {
+		errs = append(errs, cause)
+	}
+	result := buildSource(errors.Join(errs...), 0, 1, defaultConfig.TopFrame())
+	mergeSecondary(result, cause)
+	notifyWrapHooks(result)
+
+	return result
+} // WrapExec()
+
+/* _EoF_ */
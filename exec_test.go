@@ -0,0 +1,92 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestWrapExec(t *testing.T) {
+	killed := errors.New("signal: killed")
+	abort := Wrap(errors.New("some first error"), 0).(*ErrSource)
+
+	canceled, cancel := context.WithCancelCause(context.Background())
+	cancel(abort)
+	expired, cancel2 := context.WithDeadline(context.Background(), time.Now())
+	defer cancel2()
+	<-expired.Done()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		err        error
+		wantIs     []error
+		wantStacks int
+	}{
+		{"0", context.Background(), nil, nil, 0},
+		{"1", context.Background(), killed, []error{killed}, 1},
+		{"2", nil, killed, []error{killed}, 1},
+		{"3", canceled, killed, []error{killed, abort, context.Canceled}, 2},
+		{"4", expired, killed, []error{killed, context.DeadlineExceeded}, 1},
+		{"5", expired, context.DeadlineExceeded, []error{context.DeadlineExceeded}, 1},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, line, _ := runtime.Caller(0)
+			err := WrapExec(tt.ctx, tt.err)
+			if nil == tt.err {
+				if nil != err {
+					t.Errorf("%q: WrapExec() = %v, want <nil>", tt.name, err)
+				}
+				return
+			}
+			se, ok := err.(*ErrSource)
+			if !ok || (se.Line != line+1) {
+				t.Fatalf("%q: WrapExec() = %v, want an ErrSource at line %d",
+					tt.name, err, line+1)
+			}
+			for _, target := range tt.wantIs {
+				if !errors.Is(err, target) {
+					t.Errorf("%q: WrapExec() doesn't wrap %q", tt.name, target)
+				}
+			}
+			if got := bytes.Count(se.Stack, []byte(" [running]:\n")); got != tt.wantStacks {
+				t.Errorf("%q: WrapExec() has %d call stacks, want %d",
+					tt.name, got, tt.wantStacks)
+			}
+		})
+	}
+} // TestWrapExec()
+
+func TestWrapExec_command(t *testing.T) {
+	sleep, err := exec.LookPath("sleep")
+	if nil != err {
+		t.Skip("no sleep command:", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = WrapExec(ctx, exec.CommandContext(ctx, sleep, "5").Run())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WrapExec() = %v, want it to wrap %v", err, context.DeadlineExceeded)
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Errorf("WrapExec() = %v, want it to wrap an *exec.ExitError", err)
+	}
+} // TestWrapExec_command()
+
+/* _EoF_ */
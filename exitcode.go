@@ -0,0 +1,171 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// The exit codes of BSD's `sysexits.h` commonly used by CLI programs.
+const (
+	ExitOK          = 0  // successful termination
+	ExitFailure     = 1  // unspecified failure
+	ExitUsage       = 64 // EX_USAGE: command line usage error
+	ExitDataErr     = 65 // EX_DATAERR: data format error
+	ExitNoInput     = 66 // EX_NOINPUT: cannot open input
+	ExitUnavailable = 69 // EX_UNAVAILABLE: service unavailable
+	ExitSoftware    = 70 // EX_SOFTWARE: internal software error
+	ExitIOErr       = 74 // EX_IOERR: input/output error
+	ExitTempFail    = 75 // EX_TEMPFAIL: temporary failure, retry later
+	ExitNoPerm      = 77 // EX_NOPERM: permission denied
+	ExitConfig      = 78 // EX_CONFIG: configuration error
+)
+
+type (
+	// `tExitCode` is a mapping registered by `RegisterExitCode()`.
+	tExitCode struct {
+		target error
+		code   int
+	}
+)
+
+var (
+	// The registered exit code mappings, the most recent last.
+	exitCodes = []*tExitCode{
+		{ErrExternalDependency, ExitUnavailable},
+		{ErrUnavailable, ExitTempFail},
+	}
+
+	// Guard for `exitCodes`.
+	exitCodeMtx sync.RWMutex
+)
+
+// `Exit()` reports the given error and terminates the program with
+// the error's exit code (see `ExitCode()`), unifying the error
+// handling of CLI programs:
+//
+//	func main() {
+//		sourceerror.Exit(run(os.Args[1:]))
+//	}
+//
+// The error is reported by `Report()` if any sinks are registered
+// (see `RegisterSink()`); otherwise it's written to `os.Stderr` with
+// the `VerbosityCompact` rendering.
+// Before terminating, the registered shutdown hooks are run (see
+// `RunShutdownHooks()`).
+//
+// Parameters:
+// - `aErr`: The error the program terminates with, or `nil` for
+// a successful termination.
+func Exit(aErr error) {
+	if nil != aErr {
+		sinkMtx.RLock()
+		sinked := 0 < len(sinks)
+		sinkMtx.RUnlock()
+
+		if sinked {
+			_ = Report(aErr)
+		} else {
+			fmt.Fprint(fatalWriter, render(aErr, VerbosityCompact))
+		}
+	}
+	RunShutdownHooks()
+	osExit(ExitCode(aErr))
+} // Exit()
+
+// `ExitCode()` returns the process exit code for the given error.
+//
+// The code is determined by the first of the following rules:
+// - `nil` yields `ExitOK`;
+// - the most recently registered mapping of an error found in the
+// error's chain (see `RegisterExitCode()`);
+// - the code of an error in the chain providing an `ExitCode() int`
+// method (like `*exec.ExitError`);
+// - `ExitFailure` otherwise.
+//
+// By default, `ErrUnavailable` maps to `ExitTempFail` and
+// `ErrExternalDependency` to `ExitUnavailable`.
+//
+// Parameters:
+// - `aErr`: The error to map.
+//
+// Returns:
+// - `int`: The error's exit code.
+func ExitCode(aErr error) int {
+	if nil == aErr {
+		return ExitOK
+	}
+
+	exitCodeMtx.RLock()
+	list := exitCodes
+	exitCodeMtx.RUnlock()
+
+	for idx := len(list) - 1; 0 <= idx; idx-- {
+		if errors.Is(aErr, list[idx].target) {
+			return list[idx].code
+		}
+	}
+
+	var coder interface{ ExitCode() int }
+	if errors.As(aErr, &coder) {
+		if code := coder.ExitCode(); 0 < code {
+			return code
+		}
+	}
+
+	return ExitFailure
+} // ExitCode()
+
+// `RegisterExitCode()` maps the errors whose chain contains the given
+// error (as reported by `errors.Is()`) to the given exit code:
+//
+//	var ErrBadFlag = errors.New("bad flag")
+//
+//	func init() {
+//		sourceerror.RegisterExitCode(ErrBadFlag, sourceerror.ExitUsage)
+//	}
+//
+// A mapping registered later takes precedence over earlier ones
+// (including the default mappings).
+//
+// Parameters:
+// - `aTarget`: The error (usually a sentinel) to map.
+// - `aCode`: The exit code to use for the error.
+//
+// Returns:
+// - `func()`: A function removing the registered mapping.
+func RegisterExitCode(aTarget error, aCode int) func() {
+	if nil == aTarget {
+		return func() {}
+	}
+	ec := &tExitCode{
+		target: aTarget,
+		code:   aCode,
+	}
+
+	exitCodeMtx.Lock()
+	exitCodes = append(exitCodes[:len(exitCodes):len(exitCodes)], ec)
+	exitCodeMtx.Unlock()
+
+	return func() {
+		exitCodeMtx.Lock()
+		defer exitCodeMtx.Unlock()
+
+		for idx, c := range exitCodes {
+			if c == ec {
+				exitCodes = append(exitCodes[:idx:idx], exitCodes[idx+1:]...)
+				break
+			}
+		}
+	}
+} // RegisterExitCode()
+
+/* _EoF_ */
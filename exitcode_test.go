@@ -0,0 +1,97 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `tExitCoder` is an error providing an exit code.
+type tExitCoder int
+
+func (c tExitCoder) Error() string { return fmt.Sprintf("exit %d", int(c)) }
+func (c tExitCoder) ExitCode() int { return int(c) }
+
+func TestExitCode(t *testing.T) {
+	errBadFlag := errors.New("bad flag")
+	remove := RegisterExitCode(errBadFlag, ExitUsage)
+	defer remove()
+	defer RegisterExitCode(ErrUnavailable, ExitNoInput)()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"0", nil, ExitOK},
+		{"1", errors.New("some error"), ExitFailure},
+		{"2", Wrap(fmt.Errorf("parse: %w", errBadFlag), 0), ExitUsage},
+		{"3", fmt.Errorf("%w: db", ErrExternalDependency), ExitUnavailable},
+		{"4", Wrap(fmt.Errorf("%w: db", ErrUnavailable), 0), ExitNoInput},
+		{"5", Wrap(tExitCoder(3), 0), 3},
+		{"6", tExitCoder(-1), ExitFailure},
+		{"7", &exec.ExitError{}, ExitFailure},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("%q: ExitCode() = %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	remove()
+	if got := ExitCode(errBadFlag); ExitFailure != got {
+		t.Errorf("ExitCode() = %d after removal, want %d", got, ExitFailure)
+	}
+} // TestExitCode()
+
+func TestExit(t *testing.T) {
+	var (
+		buf      bytes.Buffer
+		exitCode = -1
+	)
+	fatalWriter, osExit = &buf, func(aCode int) { exitCode = aCode }
+	defer func() {
+		fatalWriter, osExit = os.Stderr, os.Exit
+		shuttingDown.Store(false)
+	}()
+
+	Exit(Wrap(fmt.Errorf("%w: db", ErrUnavailable), 0))
+	if ExitTempFail != exitCode {
+		t.Errorf("Exit() exit code = %d, want %d", exitCode, ExitTempFail)
+	}
+	if got := buf.String(); !strings.Contains(got, "exitcode_test.go:") ||
+		(1 != strings.Count(got, "\n")) {
+		t.Errorf("Exit() output = %q, want compact rendering", got)
+	}
+
+	var sink strings.Builder
+	defer RegisterSink(&sink, VerbosityFull)()
+	buf.Reset()
+	Exit(errors.New("some error"))
+	if (ExitFailure != exitCode) || (0 != buf.Len()) || ("some error\n" != sink.String()) {
+		t.Errorf("Exit() = (%d, %q, %q), want reported error",
+			exitCode, buf.String(), sink.String())
+	}
+
+	Exit(nil)
+	if ExitOK != exitCode {
+		t.Errorf("Exit() exit code = %d, want %d", exitCode, ExitOK)
+	}
+} // TestExit()
+
+/* _EoF_ */
@@ -0,0 +1,241 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `ExpectT` is the subset of `testing.TB` used by `Assert()`.
+	//
+	// It allows to use the expectations without importing the
+	// `testing` package into production code.
+	ExpectT interface {
+		Helper()
+		Errorf(aFormat string, aArgs ...any)
+	}
+
+	// `Expectation` collects the results of a chain of checks
+	// performed on a (wrapped) error.
+	//
+	// Each check method returns the same instance so calls can be
+	// chained; the first failing checks do not stop the following
+	// ones so that all problems are reported at once.
+	Expectation struct {
+		err      error    // the error under test
+		failures []string // the failed checks' descriptions
+	}
+)
+
+// `Expect()` starts a chain of checks on the given error.
+//
+// Example:
+//
+//	err := sourceerror.Expect(err).
+//		Is(fs.ErrNotExist).
+//		MessageContains("users.json").
+//		LocationIn("internal/users").
+//		Err()
+//
+// Parameters:
+// - `aErr`: The error to check.
+//
+// Returns:
+// - `*Expectation`: The expectation to chain the checks on.
+func Expect(aErr error) *Expectation {
+	return &Expectation{
+		err: aErr,
+	}
+} // Expect()
+
+// `Assert()` reports all failed checks (if any) to the given test.
+//
+// Parameters:
+// - `aT`: The test to report the failures to.
+//
+// Returns:
+// - `bool`: `true` if all checks succeeded, `false` otherwise.
+func (ex *Expectation) Assert(aT ExpectT) bool {
+	aT.Helper()
+	if 0 == len(ex.failures) {
+		return true
+	}
+	for _, msg := range ex.failures {
+		aT.Errorf("%s", msg)
+	}
+
+	return false
+} // Assert()
+
+// `Err()` returns an error describing all failed checks.
+//
+// Returns:
+// - `error`: `nil` if all checks succeeded, a describing error otherwise.
+func (ex *Expectation) Err() error {
+	if 0 == len(ex.failures) {
+		return nil
+	}
+
+	return errors.New(strings.Join(ex.failures, "\n"))
+} // Err()
+
+// `fail()` records a failed check.
+//
+// Parameters:
+// - `aFormat`: The format string of the failure's description.
+// - `aArgs`: The arguments for `aFormat`.
+func (ex *Expectation) fail(aFormat string, aArgs ...any) {
+	ex.failures = append(ex.failures, fmt.Sprintf(aFormat, aArgs...))
+} // fail()
+
+// `source()` returns the outermost `ErrSource` of the error under test,
+// recording a failure if there is none.
+//
+// Parameters:
+// - `aCheck`: The name of the check requesting the source.
+//
+// Returns:
+// - `*ErrSource`: The error's location data or `nil`.
+func (ex *Expectation) source(aCheck string) *ErrSource {
+	if nil == ex.err {
+		ex.fail("%s: expected an error, got <nil>", aCheck)
+		return nil
+	}
+	se, ok := asSource(ex.err)
+	if !ok {
+		ex.fail("%s: expected an `ErrSource` in chain of %T: %q",
+			aCheck, ex.err, ex.err.Error())
+		return nil
+	}
+
+	return se
+} // source()
+
+// `Function()` checks whether the error was encountered in a function
+// whose (fully qualified) name contains the given text.
+//
+// Parameters:
+// - `aName`: The (partial) function name to look for.
+//
+// Returns:
+// - `*Expectation`: The current expectation.
+func (ex *Expectation) Function(aName string) *Expectation {
+	if se := ex.source("Function"); nil != se {
+		if !strings.Contains(se.Function, aName) {
+			ex.fail("Function: want function containing %q, got %q",
+				aName, se.Function)
+		}
+	}
+
+	return ex
+} // Function()
+
+// `HasFrame()` checks whether the error's call stack contains a frame
+// matched by the given matcher.
+//
+// Parameters:
+// - `aMatcher`: The matcher to look for, e.g. `InPackage("example.com/db")`.
+//
+// Returns:
+// - `*Expectation`: The current expectation.
+func (ex *Expectation) HasFrame(aMatcher FrameMatcher) *Expectation {
+	if se := ex.source("HasFrame"); nil != se {
+		frames := se.Frames()
+		if 0 == len(FilterFrames(frames, aMatcher)) {
+			ex.fail("HasFrame: no matching frame in %d frames of %s:%d",
+				len(frames), se.File, se.Line)
+		}
+	}
+
+	return ex
+} // HasFrame()
+
+// `Is()` checks whether the error's chain contains the given target.
+//
+// Parameters:
+// - `aTarget`: The error to look for with `errors.Is()`.
+//
+// Returns:
+// - `*Expectation`: The current expectation.
+func (ex *Expectation) Is(aTarget error) *Expectation {
+	if !errors.Is(ex.err, aTarget) {
+		ex.fail("Is: want chain containing %v, got %v", aTarget, ex.err)
+	}
+
+	return ex
+} // Is()
+
+// `Line()` checks whether the error was encountered at the given line.
+//
+// Parameters:
+// - `aLine`: The expected source code line.
+//
+// Returns:
+// - `*Expectation`: The current expectation.
+func (ex *Expectation) Line(aLine int) *Expectation {
+	if se := ex.source("Line"); nil != se {
+		if se.Line != aLine {
+			ex.fail("Line: want line %d, got %d (%s)",
+				aLine, se.Line, se.File)
+		}
+	}
+
+	return ex
+} // Line()
+
+// `LocationIn()` checks whether the error was encountered in a source
+// file whose path contains the given (slash separated) path.
+//
+// Parameters:
+// - `aPath`: The (partial) path to look for, e.g. "internal/users".
+//
+// Returns:
+// - `*Expectation`: The current expectation.
+func (ex *Expectation) LocationIn(aPath string) *Expectation {
+	if se := ex.source("LocationIn"); nil != se {
+		if !strings.Contains(filepath.ToSlash(se.File), aPath) {
+			ex.fail("LocationIn: want location in %q, got %s:%d",
+				aPath, se.File, se.Line)
+		}
+	}
+
+	return ex
+} // LocationIn()
+
+// `MessageContains()` checks whether the wrapped error's message
+// contains the given text.
+//
+// Parameters:
+// - `aText`: The text to look for.
+//
+// Returns:
+// - `*Expectation`: The current expectation.
+func (ex *Expectation) MessageContains(aText string) *Expectation {
+	if nil == ex.err {
+		ex.fail("MessageContains: expected an error, got <nil>")
+		return ex
+	}
+
+	msg := ex.err.Error()
+	if se, ok := asSource(ex.err); ok {
+		msg = se.message()
+	}
+	if !strings.Contains(msg, aText) {
+		ex.fail("MessageContains: want message containing %q, got %q",
+			aText, msg)
+	}
+
+	return ex
+} // MessageContains()
+
+/* _EoF_ */
@@ -0,0 +1,88 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestExpect(t *testing.T) {
+	e1 := fmt.Errorf("reading %q: %w", "users.json", fs.ErrNotExist)
+	cl1 := Wrap(e1, 0)
+	cl2 := fmt.Errorf("outer: %w", cl1)
+
+	tests := []struct {
+		name    string
+		ex      *Expectation
+		wantErr string // expected part of the failure message
+	}{
+		{"1", Expect(cl1).Is(fs.ErrNotExist), ""},
+		{"2", Expect(cl1).MessageContains("users.json"), ""},
+		{"3", Expect(cl2).LocationIn("expect_test.go"), ""},
+		{"4", Expect(cl2).Function("TestExpect"), ""},
+		{"5", Expect(cl1).Line(cl1.(*ErrSource).Line), ""},
+		{"6", Expect(e1).LocationIn("expect_test.go"), "expected an `ErrSource`"},
+		{"7", Expect(nil).Line(1), "expected an error"},
+		{"8", Expect(cl1).Is(fs.ErrExist), "Is: want chain"},
+		{"9", Expect(cl1).LocationIn("internal/users"), "LocationIn: want"},
+		{"10", Expect(cl1).MessageContains("x").Line(-1), "Line: want line -1"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ex.Err()
+			if "" == tt.wantErr {
+				if nil != err {
+					t.Errorf("%q: Expect() =\n%v,\nwant <nil>", tt.name, err)
+				}
+				return
+			}
+			if nil == err {
+				t.Errorf("%q: Expect() = <nil>, want %q", tt.name, tt.wantErr)
+				return
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%q: Expect() =\n%v,\nwant %q", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+} // TestExpect()
+
+func Test_asSource(t *testing.T) {
+	e1 := errors.New("some first error")
+	cl1 := Wrap(e1, 0)
+	cl2 := fmt.Errorf("outer: %w", *cl1.(*ErrSource))
+
+	tests := []struct {
+		name   string
+		err    error
+		wantOK bool
+	}{
+		{"0", nil, false},
+		{"1", e1, false},
+		{"2", cl1, true},
+		{"3", cl2, true},
+		{"4", (*ErrSource)(nil), false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := asSource(tt.err); got != tt.wantOK {
+				t.Errorf("%q: asSource() = %v, want %v",
+					tt.name, got, tt.wantOK)
+			}
+		})
+	}
+} // Test_asSource()
+
+/* _EoF_ */
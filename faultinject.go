@@ -0,0 +1,150 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Fault` describes how to mutate errors wrapped at certain locations.
+// It's meant for (chaos-style) tests verifying that downstream code
+// (e.g. log pipelines) copes with malformed or minimal errors.
+//
+// The fields are as follows:
+// - `File`: The (partial, slash separated) path of the source files
+// whose errors are to be mutated; empty matches all files.
+// - `Function`: The (partial) name of the functions whose errors are
+// to be mutated; empty matches all functions.
+// - `Message`: If not empty the wrapped error is replaced by a plain
+// error with this message.
+// - `DropStack`: Whether to remove the call stack.
+// - `DropLocation`: Whether to remove file, function, and line.
+type Fault struct {
+	File         string
+	Function     string
+	Message      string
+	DropStack    bool
+	DropLocation bool
+}
+
+var (
+	// The currently injected faults.
+	faults []*Fault
+
+	// Guard for `faults`.
+	faultMtx sync.RWMutex
+
+	// The number of injected faults (checked without locking).
+	faultCount atomic.Int32
+)
+
+// `ClearFaults()` removes all injected faults.
+func ClearFaults() {
+	faultMtx.Lock()
+	defer faultMtx.Unlock()
+
+	faults = nil
+	faultCount.Store(0)
+} // ClearFaults()
+
+// `InjectFault()` registers a fault to be applied to all errors
+// subsequently wrapped at a matching location.
+//
+// Example:
+//
+//	remove := sourceerror.InjectFault(sourceerror.Fault{
+//		Function:  "store.(*DB).Save",
+//		DropStack: true,
+//	})
+//	defer remove()
+//
+// Parameters:
+// - `aFault`: The fault to inject.
+//
+// Returns:
+// - `func()`: A function removing the injected fault.
+func InjectFault(aFault Fault) func() {
+	fault := &aFault
+
+	faultMtx.Lock()
+	faults = append(faults, fault)
+	faultCount.Store(int32(len(faults)))
+	faultMtx.Unlock()
+
+	return func() {
+		faultMtx.Lock()
+		defer faultMtx.Unlock()
+
+		for idx, f := range faults {
+			if f == fault {
+				faults = append(faults[:idx:idx], faults[idx+1:]...)
+				break
+			}
+		}
+		faultCount.Store(int32(len(faults)))
+	}
+} // InjectFault()
+
+// `matches()` reports whether the fault applies to the given error.
+//
+// Parameters:
+// - `aSource`: The error to check.
+//
+// Returns:
+// - `bool`: `true` if the fault applies, `false` otherwise.
+func (f *Fault) matches(aSource *ErrSource) bool {
+	if ("" != f.File) &&
+		!strings.Contains(filepath.ToSlash(aSource.File), f.File) {
+		return false
+	}
+
+	return ("" == f.Function) || strings.Contains(aSource.Function, f.Function)
+} // matches()
+
+// `applyFaults()` mutates the given error according to all matching
+// injected faults.
+//
+// Parameters:
+// - `aSource`: The error to mutate.
+//
+// Returns:
+// - `*ErrSource`: The (possibly mutated) error.
+func applyFaults(aSource *ErrSource) *ErrSource {
+	if 0 == faultCount.Load() {
+		return aSource
+	}
+
+	faultMtx.RLock()
+	defer faultMtx.RUnlock()
+
+	for _, f := range faults {
+		if !f.matches(aSource) {
+			continue
+		}
+		if "" != f.Message {
+			aSource.err = errors.New(f.Message)
+		}
+		if f.DropStack && ((nil != aSource.Stack) || (nil != aSource.lazy)) {
+			aSource.Stack, aSource.pcs, aSource.lazy = nil, nil, nil
+			aSource.StackOmitted = true
+			aSource.OmitReason = ReasonFaultInjected
+		}
+		if f.DropLocation {
+			aSource.File, aSource.Function, aSource.Line = "", "", 0
+		}
+	}
+
+	return aSource
+} // applyFaults()
+
+/* _EoF_ */
@@ -0,0 +1,69 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestInjectFault(t *testing.T) {
+	e := errors.New("some first error")
+	defer ClearFaults()
+
+	tests := []struct {
+		name      string
+		fault     Fault
+		wantMsg   string
+		wantStack bool
+		wantFile  bool
+	}{
+		{"1", Fault{Function: "NoSuchFunction", DropStack: true}, "some first error", true, true},
+		{"2", Fault{Function: "TestInjectFault", DropStack: true}, "some first error", false, true},
+		{"3", Fault{File: "faultinject_test.go", Message: "mutated"}, "mutated", true, true},
+		{"4", Fault{DropLocation: true}, "some first error", true, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := Stats()
+			remove := InjectFault(tt.fault)
+			se := Wrap(e, 0).(*ErrSource)
+			remove()
+			after := Stats()
+
+			if got := se.message(); got != tt.wantMsg {
+				t.Errorf("%q: message = %q, want %q", tt.name, got, tt.wantMsg)
+			}
+			if got := (nil != se.Stack); got != tt.wantStack {
+				t.Errorf("%q: stack = %v, want %v", tt.name, got, tt.wantStack)
+			}
+			if !tt.wantStack && (ReasonFaultInjected != se.OmitReason) {
+				t.Errorf("%q: OmitReason = %q, want %q",
+					tt.name, se.OmitReason, ReasonFaultInjected)
+			}
+			captured := after.StacksCaptured - before.StacksCaptured
+			dropped := after.StacksOmitted[ReasonFaultInjected] -
+				before.StacksOmitted[ReasonFaultInjected]
+			if (1 == captured) != tt.wantStack || (1 == dropped) == tt.wantStack {
+				t.Errorf("%q: Stats() captured = %d, dropped = %d, want a stack: %v",
+					tt.name, captured, dropped, tt.wantStack)
+			}
+			if got := ("" != se.File); got != tt.wantFile {
+				t.Errorf("%q: file = %v, want %v", tt.name, got, tt.wantFile)
+			}
+		})
+	}
+
+	if 0 != faultCount.Load() {
+		t.Errorf("InjectFault() remove left %d faults", faultCount.Load())
+	}
+} // TestInjectFault()
+
+/* _EoF_ */
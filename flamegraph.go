@@ -0,0 +1,199 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tSpeedscopeFrame` is a frame of a speedscope profile.
+	tSpeedscopeFrame struct {
+		Name string `json:"name"`
+		File string `json:"file,omitempty"`
+		Line int    `json:"line,omitempty"`
+	}
+
+	// `tSpeedscopeProfile` is a sampled speedscope profile.
+	tSpeedscopeProfile struct {
+		Type       string  `json:"type"`
+		Name       string  `json:"name"`
+		Unit       string  `json:"unit"`
+		StartValue int     `json:"startValue"`
+		EndValue   int     `json:"endValue"`
+		Samples    [][]int `json:"samples"`
+		Weights    []int   `json:"weights"`
+	}
+
+	// `tSpeedscopeFile` is the document read by speedscope.
+	tSpeedscopeFile struct {
+		Schema   string `json:"$schema"`
+		Exporter string `json:"exporter"`
+		Name     string `json:"name"`
+		Shared   struct {
+			Frames []tSpeedscopeFrame `json:"frames"`
+		} `json:"shared"`
+		Profiles []tSpeedscopeProfile `json:"profiles"`
+	}
+)
+
+const (
+	// The JSON schema of speedscope's file format.
+	speedscopeSchema = "https://www.speedscope.app/file-format-schema.json"
+)
+
+// `originFrames()` returns the frames leading to the error's location,
+// outermost (i.e. the goroutine's entry) first.
+//
+// The frames of the error capturing machinery above the error's
+// location are dropped; errors without a call stack are represented
+// by their location only.
+//
+// Returns:
+// - `[]Frame`: The frames leading to the error's location.
+func (se ErrSource) originFrames() []Frame {
+	frames := se.Frames()
+	if idx := slices.IndexFunc(frames, func(aFrame Frame) bool {
+		return aFrame.Function == se.Function
+	}); 0 <= idx {
+		frames = frames[idx:]
+	} else if "" != se.File {
+		frames = []Frame{{
+			File:     se.File,
+			Function: se.Function,
+			Line:     se.Line,
+		}}
+	}
+	slices.Reverse(frames)
+
+	return frames
+} // originFrames()
+
+// `sources()` returns the store's (not expired) errors in the order
+// they were added.
+//
+// Returns:
+// - `[]*ErrSource`: The stored errors.
+func (s *Store) sources() []*ErrSource {
+	s.mtx.Lock()
+	evicted := s.evict(time.Now())
+	result := make([]*ErrSource, 0, len(s.order))
+	for _, id := range s.order {
+		result = append(result, s.entries[id].err)
+	}
+	s.mtx.Unlock()
+	s.notify(evicted)
+
+	return result
+} // sources()
+
+// `WriteFolded()` writes the call stacks of the stored errors in the
+// "folded stacks" format read by flamegraph tools (e.g. Brendan
+// Gregg's `flamegraph.pl` or `inferno`), visualising where the errors
+// come from:
+//
+//	main.main;app.(*Server).handle;app.loadUser 3
+//
+// Each line holds the semicolon separated functions leading to an
+// error's location and the number of stored errors sharing that path.
+//
+// Parameters:
+// - `aWriter`: The destination to write the stacks to.
+//
+// Returns:
+// - `error`: A possible error during writing.
+func (s *Store) WriteFolded(aWriter io.Writer) error {
+	counts := make(map[string]int)
+	for _, se := range s.sources() {
+		frames := se.originFrames()
+		if 0 == len(frames) {
+			continue
+		}
+		names := make([]string, len(frames))
+		for idx, frame := range frames {
+			names[idx] = strings.ReplaceAll(frame.Function, ";", ":")
+		}
+		counts[strings.Join(names, ";")]++
+	}
+
+	stacks := make([]string, 0, len(counts))
+	for stack := range counts {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+	for _, stack := range stacks {
+		if _, err := fmt.Fprintf(aWriter, "%s %d\n", stack, counts[stack]); nil != err {
+			return err
+		}
+	}
+
+	return nil
+} // WriteFolded()
+
+// `WriteSpeedscope()` writes the call stacks of the stored errors as
+// a sampled profile in speedscope's JSON format (see
+// https://www.speedscope.app), each stored error being one sample.
+//
+// Parameters:
+// - `aWriter`: The destination to write the profile to.
+// - `aName`: The profile's name shown by speedscope.
+//
+// Returns:
+// - `error`: A possible error during encoding or writing.
+func (s *Store) WriteSpeedscope(aWriter io.Writer, aName string) error {
+	var doc tSpeedscopeFile
+	doc.Schema = speedscopeSchema
+	doc.Exporter = "github.com/mwat56/sourceerror"
+	doc.Name = aName
+	doc.Shared.Frames = []tSpeedscopeFrame{}
+
+	profile := tSpeedscopeProfile{
+		Type:    "sampled",
+		Name:    aName,
+		Unit:    "none",
+		Samples: [][]int{},
+		Weights: []int{},
+	}
+	index := make(map[tSpeedscopeFrame]int)
+	for _, se := range s.sources() {
+		frames := se.originFrames()
+		if 0 == len(frames) {
+			continue
+		}
+		sample := make([]int, len(frames))
+		for idx, frame := range frames {
+			key := tSpeedscopeFrame{
+				Name: frame.Function,
+				File: frame.File,
+				Line: frame.Line,
+			}
+			fIdx, ok := index[key]
+			if !ok {
+				fIdx = len(doc.Shared.Frames)
+				index[key] = fIdx
+				doc.Shared.Frames = append(doc.Shared.Frames, key)
+			}
+			sample[idx] = fIdx
+		}
+		profile.Samples = append(profile.Samples, sample)
+		profile.Weights = append(profile.Weights, 1)
+	}
+	profile.EndValue = len(profile.Samples)
+	doc.Profiles = []tSpeedscopeProfile{profile}
+
+	return json.NewEncoder(aWriter).Encode(doc)
+} // WriteSpeedscope()
+
+/* _EoF_ */
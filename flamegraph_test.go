@@ -0,0 +1,90 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestStore_WriteFolded(t *testing.T) {
+	s := NewStore(0)
+	for range 2 {
+		s.Add(Wrap(errors.New("some first error"), 0))
+	}
+	s.Add(WithLocation(errors.New("some other error"),
+		Location{"/src/app/x.go", "app.X", 7}))
+
+	var sb strings.Builder
+	if err := s.WriteFolded(&sb); nil != err {
+		t.Fatalf("Store.WriteFolded() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+
+	tests := []struct {
+		name       string
+		wantSuffix string
+	}{
+		{"0", "app.X 1"},
+		{"1", ";" + thisPackage + ".TestStore_WriteFolded 2"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, line := range lines {
+				if strings.HasSuffix(line, tt.wantSuffix) {
+					return
+				}
+			}
+			t.Errorf("%q: Store.WriteFolded() =\n%s\nwant line ending with %q",
+				tt.name, sb.String(), tt.wantSuffix)
+		})
+	}
+	if 2 != len(lines) {
+		t.Errorf("Store.WriteFolded() = %d lines, want 2", len(lines))
+	}
+	if strings.Contains(sb.String(), "newSource") {
+		t.Errorf("Store.WriteFolded() contains internal frames:\n%s", sb.String())
+	}
+} // TestStore_WriteFolded()
+
+func TestStore_WriteSpeedscope(t *testing.T) {
+	s := NewStore(0)
+	s.Add(Wrap(errors.New("some first error"), 0))
+	s.Add(WithLocation(errors.New("some other error"),
+		Location{"/src/app/x.go", "app.X", 7}))
+
+	var sb strings.Builder
+	if err := s.WriteSpeedscope(&sb, "errors"); nil != err {
+		t.Fatalf("Store.WriteSpeedscope() error = %v", err)
+	}
+	var doc tSpeedscopeFile
+	if err := json.Unmarshal([]byte(sb.String()), &doc); nil != err {
+		t.Fatalf("Store.WriteSpeedscope() invalid JSON: %v", err)
+	}
+	if (1 != len(doc.Profiles)) || (2 != len(doc.Profiles[0].Samples)) {
+		t.Fatalf("Store.WriteSpeedscope() = %+v, want 1 profile with 2 samples",
+			doc.Profiles)
+	}
+	for _, sample := range doc.Profiles[0].Samples {
+		for _, idx := range sample {
+			if (0 > idx) || (len(doc.Shared.Frames) <= idx) {
+				t.Errorf("Store.WriteSpeedscope() invalid frame index %d", idx)
+			}
+		}
+	}
+	leaf := doc.Profiles[0].Samples[0]
+	if got := doc.Shared.Frames[leaf[len(leaf)-1]].Name; got != thisPackage+".TestStore_WriteSpeedscope" {
+		t.Errorf("Store.WriteSpeedscope() leaf = %q", got)
+	}
+} // TestStore_WriteSpeedscope()
+
+/* _EoF_ */
@@ -0,0 +1,186 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The max. number of frames of a recursion cycle to look for.
+	maxFoldPeriod = 16
+
+	// The min. number of consecutive occurrences of a frame sequence
+	// to be folded.
+	minFoldRepeats = 3
+
+	// How to render a folded recursion cycle:
+	foldPattern = "… frames %d–%d repeated %d times …\n"
+)
+
+// `tFrameSegment` is a part of a call stack: a frame sequence which
+// is possibly repeated.
+type tFrameSegment struct {
+	frames []Frame // the frames of this segment
+	first  int     // index of the first frame in the whole stack
+	times  int     // how often the sequence is repeated after itself
+}
+
+// `equalFrames()` reports whether the two frame sequences are equal.
+//
+// Parameters:
+// - `aFrames1`: The first sequence.
+// - `aFrames2`: The second sequence.
+//
+// Returns:
+// - `bool`: `true` if both sequences are equal.
+func equalFrames(aFrames1, aFrames2 []Frame) bool {
+	if len(aFrames1) != len(aFrames2) {
+		return false
+	}
+	for idx, frame := range aFrames1 {
+		if frame != aFrames2[idx] {
+			return false
+		}
+	}
+
+	return true
+} // equalFrames()
+
+// `foldFrames()` splits the given call stack into segments folding
+// consecutively repeated frame sequences (i.e. recursion cycles).
+//
+// Parameters:
+// - `aFrames`: The call stack to fold.
+//
+// Returns:
+// - `[]tFrameSegment`: The stack's segments.
+func foldFrames(aFrames []Frame) []tFrameSegment {
+	var result []tFrameSegment
+
+	plain := 0 // start of the current unfolded segment
+	flush := func(aEnd int) {
+		if plain < aEnd {
+			result = append(result, tFrameSegment{
+				frames: aFrames[plain:aEnd],
+				first:  plain,
+			})
+		}
+	}
+
+	for idx := 0; idx < len(aFrames); {
+		bestPeriod, bestRepeats := 0, 0
+		for period := 1; (period <= maxFoldPeriod) &&
+			(idx+minFoldRepeats*period <= len(aFrames)); period++ {
+			repeats := 1
+			for (idx+(repeats+1)*period <= len(aFrames)) &&
+				equalFrames(aFrames[idx:idx+period],
+					aFrames[idx+repeats*period:idx+(repeats+1)*period]) {
+				repeats++
+			}
+			if (minFoldRepeats <= repeats) &&
+				(repeats*period > bestRepeats*bestPeriod) {
+				bestPeriod, bestRepeats = period, repeats
+			}
+		}
+		if 0 == bestPeriod {
+			idx++
+			continue
+		}
+
+		flush(idx)
+		result = append(result, tFrameSegment{
+			frames: aFrames[idx : idx+bestPeriod],
+			first:  idx,
+			times:  bestRepeats - 1,
+		})
+		idx += bestPeriod * bestRepeats
+		plain = idx
+	}
+	flush(len(aFrames))
+
+	return result
+} // foldFrames()
+
+// `writeFrames()` renders the given call stack with recursion cycles
+// folded.
+//
+// Parameters:
+// - `aBuilder`: The builder to write to.
+// - `aFrames`: The call stack to render.
+// - `aFormat`: The function rendering a single frame.
+//
+// Returns:
+// - `bool`: `true` if any recursion cycle was folded.
+func writeFrames(aBuilder *strings.Builder, aFrames []Frame, aFormat func(Frame) string) bool {
+	folded := false
+	for _, seg := range foldFrames(aFrames) {
+		for _, frame := range seg.frames {
+			aBuilder.WriteString(aFormat(frame))
+		}
+		if 0 < seg.times {
+			fmt.Fprintf(aBuilder, foldPattern,
+				seg.first, seg.first+len(seg.frames)-1, seg.times)
+			folded = true
+		}
+	}
+
+	return folded
+} // writeFrames()
+
+// `foldStack()` folds the recursion cycles of a call stack as
+// returned by `debug.Stack()`.
+//
+// Parameters:
+// - `aStack`: The call stack to fold.
+//
+// Returns:
+// - `[]byte`: The folded stack or `aStack` if there's nothing to fold.
+func foldStack(aStack []byte) []byte {
+	if 0 == len(aStack) {
+		return aStack
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(aStack))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	goroutines, err := parseGoroutines(scanner)
+	if (nil != err) || (1 != len(goroutines)) {
+		return aStack
+	}
+
+	var sb strings.Builder
+	g := goroutines[0]
+	fmt.Fprintf(&sb, "goroutine %d [%s]:\n", g.Goroutine, g.State)
+	if !writeFrames(&sb, g.Frames, formatRuntimeFrame) {
+		return aStack
+	}
+	if nil != g.CreatedBy {
+		fmt.Fprintf(&sb, "created by %s\n\t%s:%d\n",
+			g.CreatedBy.Function, g.CreatedBy.File, g.CreatedBy.Line)
+	}
+
+	return []byte(sb.String())
+} // foldStack()
+
+// `formatRuntimeFrame()` renders a frame like the Go runtime does.
+//
+// Parameters:
+// - `aFrame`: The frame to render.
+//
+// Returns:
+// - `string`: The frame's text.
+func formatRuntimeFrame(aFrame Frame) string {
+	return fmt.Sprintf("%s(...)\n\t%s:%d\n",
+		aFrame.Function, aFrame.File, aFrame.Line)
+} // formatRuntimeFrame()
+
+/* _EoF_ */
@@ -0,0 +1,81 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `testRecurse()` wraps an error at the given recursion depth.
+func testRecurse(aDepth int) error {
+	if 0 == aDepth {
+		return Wrap(errors.New("too deep"), 0)
+	}
+
+	return testRecurse(aDepth - 1)
+} // testRecurse()
+
+func Test_foldFrames(t *testing.T) {
+	a, b, c := Frame{"a.go", "a", 1, 0}, Frame{"b.go", "b", 2, 0}, Frame{"c.go", "c", 3, 0}
+
+	tests := []struct {
+		name   string
+		frames []Frame
+		want   string
+	}{
+		{"0", nil, ""},
+		{"1", []Frame{a, b, c}, "a b c "},
+		{"2", []Frame{a, b, b, c}, "a b b c "},
+		{"3", []Frame{a, b, b, b, b, c}, "a b [1–1 x3] c "},
+		{"4", []Frame{c, a, b, a, b, a, b, a, b, c}, "c a b [1–2 x3] c "},
+		{"5", []Frame{a, a, a}, "a [0–0 x2] "},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			for _, seg := range foldFrames(tt.frames) {
+				for _, frame := range seg.frames {
+					sb.WriteString(frame.Function + " ")
+				}
+				if 0 < seg.times {
+					sb.WriteString("[" + strconv.Itoa(seg.first) + "–" +
+						strconv.Itoa(seg.first+len(seg.frames)-1) +
+						" x" + strconv.Itoa(seg.times) + "] ")
+				}
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("%q: foldFrames() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_foldFrames()
+
+func Test_foldStack(t *testing.T) {
+	se := testRecurse(20).(*ErrSource)
+	got := string(foldStack(se.Stack))
+	if !strings.Contains(got, "repeated 19 times") {
+		t.Errorf("foldStack() =\n%s\nwant folded recursion", got)
+	}
+	// the wrapping frame plus the folded recursive one
+	if 2 != strings.Count(got, ".testRecurse(") {
+		t.Errorf("foldStack() =\n%s\nwant two testRecurse frames", got)
+	}
+
+	plain := Wrap(errors.New("flat"), 0).(*ErrSource)
+	if got := foldStack(plain.Stack); string(got) != string(plain.Stack) {
+		t.Errorf("foldStack() =\n%s\nwant unchanged\n%s", got, plain.Stack)
+	}
+} // Test_foldStack()
+
+/* _EoF_ */
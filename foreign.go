@@ -0,0 +1,124 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `ForeignStackDecoder` extracts the call stack of an embedded
+	// interpreter (e.g. Lua, Starlark, JavaScript) from the given error.
+	// It returns `nil` if the error doesn't originate from the
+	// decoder's interpreter.
+	ForeignStackDecoder func(aErr error) []Frame
+
+	// `ForeignStack` is a non-Go call stack attached to an `ErrSource`.
+	//
+	// The fields are as follows:
+	// - `Name`: The name of the decoder that provided the stack.
+	// - `Frames`: The call stack's frames, innermost first.
+	ForeignStack struct {
+		Name   string  `json:"name"`
+		Frames []Frame `json:"frames"`
+	}
+
+	// `tForeignDecoder` is a registered `ForeignStackDecoder`.
+	tForeignDecoder struct {
+		name string
+		fn   ForeignStackDecoder
+	}
+)
+
+var (
+	// The registered decoders, sorted by name.
+	foreignDecoders []tForeignDecoder
+
+	// Guard for `foreignDecoders`.
+	foreignMtx sync.RWMutex
+)
+
+// `RegisterForeignStackDecoder()` registers a decoder whose frames are
+// added to each newly wrapped error (unless locations are disabled).
+//
+// Registering another decoder with the same name replaces the former
+// one, and registering a `nil` decoder removes it.
+//
+// Parameters:
+// - `aName`: The name of the decoder, e.g. "lua".
+// - `aDecoder`: The function to extract the interpreter's call stack.
+func RegisterForeignStackDecoder(aName string, aDecoder ForeignStackDecoder) {
+	foreignMtx.Lock()
+	defer foreignMtx.Unlock()
+
+	decoders := make([]tForeignDecoder, 0, len(foreignDecoders)+1)
+	for _, dec := range foreignDecoders {
+		if dec.name != aName {
+			decoders = append(decoders, dec)
+		}
+	}
+	if nil != aDecoder {
+		decoders = append(decoders, tForeignDecoder{aName, aDecoder})
+		sort.Slice(decoders, func(i, j int) bool {
+			return decoders[i].name < decoders[j].name
+		})
+	}
+	foreignDecoders = decoders
+} // RegisterForeignStackDecoder()
+
+// `foreignStacks()` returns the call stacks all registered decoders
+// extract from the given error.
+//
+// Parameters:
+// - `aErr`: The error to decode.
+//
+// Returns:
+// - `[]ForeignStack`: The decoded call stacks (if any).
+func foreignStacks(aErr error) []ForeignStack {
+	if nil == aErr {
+		return nil
+	}
+
+	foreignMtx.RLock()
+	decoders := foreignDecoders
+	foreignMtx.RUnlock()
+
+	var result []ForeignStack
+	for _, dec := range decoders {
+		if frames := dec.fn(aErr); 0 < len(frames) {
+			result = append(result, ForeignStack{
+				Name:   dec.name,
+				Frames: frames,
+			})
+		}
+	}
+
+	return result
+} // foreignStacks()
+
+// `String()` implements the `Stringer` interface and returns the
+// foreign stack in the format used by `debug.Stack()`; recursion
+// cycles are folded.
+//
+// Returns:
+// - `string`: The stack's string representation.
+func (fs ForeignStack) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fs.Name + ":\n")
+	writeFrames(&sb, fs.Frames, func(aFrame Frame) string {
+		return aFrame.Function + "\n\t" + aFrame.String() + "\n"
+	})
+
+	return sb.String()
+} // String()
+
+/* _EoF_ */
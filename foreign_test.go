@@ -0,0 +1,85 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `tScriptError` simulates an error raised by an embedded interpreter.
+type tScriptError struct {
+	frames []Frame
+}
+
+func (se tScriptError) Error() string {
+	return "script failed"
+}
+
+func TestRegisterForeignStackDecoder(t *testing.T) {
+	decoder := func(aErr error) []Frame {
+		var se tScriptError
+		if errors.As(aErr, &se) {
+			return se.frames
+		}
+		return nil
+	}
+	RegisterForeignStackDecoder("lua", decoder)
+	RegisterForeignStackDecoder("js", decoder)
+	defer func() {
+		RegisterForeignStackDecoder("lua", nil)
+		RegisterForeignStackDecoder("js", nil)
+	}()
+
+	e1 := tScriptError{[]Frame{
+		{"init.lua", "main", 3, 0},
+		{"util.lua", "helper", 12, 0},
+	}}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantLen  int
+		wantText string
+	}{
+		{"0", nil, 0, ""},
+		{"1", errors.New("some first error"), 0, ""},
+		{"2", e1, 2, "Foreign stack js:\nmain\n\tinit.lua:3\nhelper\n\tutil.lua:12\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			se := Wrap(tt.err, 0).(*ErrSource)
+			if got := len(se.Foreign); got != tt.wantLen {
+				t.Errorf("%q: len(Foreign) = %d, want %d",
+					tt.name, got, tt.wantLen)
+				return
+			}
+			if 0 == tt.wantLen {
+				return
+			}
+			if "js" != se.Foreign[0].Name {
+				t.Errorf("%q: Foreign[0].Name = %q, want %q",
+					tt.name, se.Foreign[0].Name, "js")
+			}
+			if got := se.String(); !strings.Contains(got, tt.wantText) {
+				t.Errorf("%q: String() =\n%s\nwant\n%s",
+					tt.name, got, tt.wantText)
+			}
+		})
+	}
+
+	RegisterForeignStackDecoder("lua", nil)
+	if 1 != len(foreignDecoders) {
+		t.Errorf("RegisterForeignStackDecoder(nil) didn't remove decoder")
+	}
+} // TestRegisterForeignStackDecoder()
+
+/* _EoF_ */
@@ -0,0 +1,55 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Format()` implements the `fmt.Formatter` interface:
+// - `%s`, `%v`: the wrapped error's text (see `Error()`);
+// - `%q`: the wrapped error's text, double-quoted;
+// - `%+s`: the error's location followed by the wrapped error's text,
+// e.g. "repo.go:88: connection refused", for compact log lines;
+// - `%+v`: the error's full description including the call stack
+// (see `Detail()`).
+//
+// NOTE: `%v` doesn't include the location since `fmt.Errorf()` formats
+// the errors wrapped by `%w` like `%v`; otherwise the location would
+// become part of the wrapping errors' messages.
+//
+// Parameters:
+// - `aState`: The formatter's state.
+// - `aVerb`: The formatting verb.
+func (se ErrSource) Format(aState fmt.State, aVerb rune) {
+	switch aVerb {
+	case 'v':
+		if aState.Flag('+') {
+			_, _ = io.WriteString(aState, se.Detail())
+			return
+		}
+		_, _ = io.WriteString(aState, se.Error())
+
+	case 's':
+		if aState.Flag('+') && ("" != se.File) {
+			_, _ = io.WriteString(aState, se.Location().String()+": ")
+		}
+		_, _ = io.WriteString(aState, se.Error())
+
+	case 'q':
+		_, _ = io.WriteString(aState, strconv.Quote(se.Error()))
+
+	default:
+		fmt.Fprintf(aState, "%%!%c(%T=%s)", aVerb, se, se.Error())
+	}
+} // Format()
+
+/* _EoF_ */
@@ -0,0 +1,174 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+/*
+Package format renders errors of the `sourceerror` package for
+comparisons in (table driven) tests.
+*/
+package format
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `Option` configures the rendering of `TB()`.
+	Option func(*tOptions)
+
+	// `tOptions` are the settings of a rendering.
+	tOptions struct {
+		keepVolatile bool // whether to keep IDs, durations etc.
+		withStack    bool // whether to render the call stacks
+	}
+)
+
+var (
+	// Regular expression matching memory addresses, e.g. "0xc000012345".
+	reAddress = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+
+	// Regular expression matching timestamps, e.g. RFC 3339 or the
+	// format of the `log` package.
+	reTimestamp = regexp.MustCompile(
+		`\d{4}[-/]\d{2}[-/]\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?`)
+)
+
+// `KeepVolatile()` keeps the parts of the rendering which differ
+// between runs (IDs, sequence numbers, durations, memory addresses,
+// and timestamps) instead of eliding them.
+//
+// Returns:
+// - `Option`: The option for `TB()`.
+func KeepVolatile() Option {
+	return func(aOptions *tOptions) {
+		aOptions.keepVolatile = true
+	}
+} // KeepVolatile()
+
+// `WithStack()` adds the frames of the errors' call stacks to the
+// rendering.
+//
+// Returns:
+// - `Option`: The option for `TB()`.
+func WithStack() Option {
+	return func(aOptions *tOptions) {
+		aOptions.withStack = true
+	}
+} // WithStack()
+
+// --------------------------------------------------------------------------
+
+// `stable()` elides the volatile parts of the given text unless they
+// should be kept.
+//
+// Parameters:
+// - `aText`: The text to process.
+// - `aOptions`: The rendering's settings.
+//
+// Returns:
+// - `string`: The processed text.
+func stable(aText string, aOptions *tOptions) string {
+	if aOptions.keepVolatile {
+		return aText
+	}
+	aText = reTimestamp.ReplaceAllString(aText, "<time>")
+
+	return reAddress.ReplaceAllString(aText, "0x?")
+} // stable()
+
+// `TB()` returns a deterministic, line oriented rendering of the given
+// error's chain for comparisons in tests, e.g. with `go-cmp`:
+//
+//	if diff := cmp.Diff(tt.want, format.TB(t, err)); "" != diff {
+//		t.Errorf("%q: Load() mismatch (-want +got):\n%s", tt.name, diff)
+//	}
+//
+// Each error of the chain is rendered as an indented block with its
+// type, message, and (for `ErrSource` layers) location data; source
+// files are rendered by their base name. Volatile parts are elided
+// unless the `KeepVolatile()` option is given.
+//
+// Parameters:
+// - `aTest`: The current test.
+// - `aErr`: The error to render.
+// - `aOptions`: Options configuring the rendering.
+//
+// Returns:
+// - `string`: The error's rendering ("<nil>" for `nil`).
+func TB(aTest testing.TB, aErr error, aOptions ...Option) string {
+	aTest.Helper()
+	if nil == aErr {
+		return "<nil>\n"
+	}
+
+	options := &tOptions{}
+	for _, option := range aOptions {
+		option(options)
+	}
+
+	var sb strings.Builder
+	for idx, link := range sourceerror.Chain(aErr) {
+		fmt.Fprintf(&sb, "[%d] %T\n", idx, link)
+		line := func(aKey, aValue string) {
+			if "" != aValue {
+				fmt.Fprintf(&sb, "    %s: %s\n", aKey, stable(aValue, options))
+			}
+		}
+		line("message", link.Error())
+
+		var se *sourceerror.ErrSource
+		switch typed := link.(type) {
+		case *sourceerror.ErrSource:
+			se = typed
+		case sourceerror.ErrSource:
+			se = &typed
+		}
+		if nil == se {
+			continue
+		}
+		if "" != se.File {
+			line("location", fmt.Sprintf("%s:%d", filepath.Base(se.File), se.Line))
+			line("function", se.Function)
+		}
+		if nil != se.External {
+			line("external", fmt.Sprintf("%s:%d",
+				filepath.Base(se.External.File), se.External.Line))
+		}
+		if nil != se.Input {
+			line("input", se.Input.String())
+		}
+		line("phase", se.Phase)
+		line("test", se.Test)
+		line("stack omitted", se.OmitReason)
+		if options.keepVolatile {
+			line("id", se.ID)
+			if 0 < se.Seq {
+				line("seq", fmt.Sprint(se.Seq))
+			}
+			if 0 < se.Elapsed {
+				line("elapsed", se.Elapsed.String())
+			}
+		}
+		if options.withStack {
+			for frame := range se.All() {
+				line("frame", fmt.Sprintf("%s %s:%d",
+					frame.Function, filepath.Base(frame.File), frame.Line))
+			}
+		}
+	}
+
+	return sb.String()
+} // TB()
+
+/* _EoF_ */
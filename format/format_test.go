@@ -0,0 +1,92 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_stable(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		keep bool
+		want string
+	}{
+		{"0", "plain", false, "plain"},
+		{"1", "nil pointer at 0xc000012345", false, "nil pointer at 0x?"},
+		{"2", "expired at 2024-05-01T12:00:00.123Z", false, "expired at <time>"},
+		{"3", "logged 2024/05/01 12:00:00 ok", false, "logged <time> ok"},
+		{"4", "at 0xc000012345", true, "at 0xc000012345"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stable(tt.text, &tOptions{keepVolatile: tt.keep}); got != tt.want {
+				t.Errorf("%q: stable() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_stable()
+
+func TestTB(t *testing.T) {
+	e := errors.New("timeout at 0xc000012345")
+	cl1 := sourceerror.WithDuration(sourceerror.Wrap(e, 0), time.Second)
+	se, _ := sourceerror.AsSource(cl1)
+	cl2 := fmt.Errorf("outer: %w", cl1)
+	loc := fmt.Sprintf("format_test.go:%d", se.Line)
+
+	tests := []struct {
+		name    string
+		err     error
+		options []Option
+		want    string
+	}{
+		{"0", nil, nil, "<nil>\n"},
+		{"1", e, nil, "[0] *errors.errorString\n    message: timeout at 0x?\n"},
+		{"2", cl2, nil, "[0] *fmt.wrapError\n" +
+			"    message: outer: timeout at 0x?\n" +
+			"[1] *sourceerror.ErrSource\n" +
+			"    message: timeout at 0x?\n" +
+			"    location: " + loc + "\n" +
+			"    function: github.com/mwat56/sourceerror/format.TestTB\n" +
+			"    test: TestTB\n" +
+			"[2] *errors.errorString\n" +
+			"    message: timeout at 0x?\n"},
+		{"3", cl1, []Option{KeepVolatile()}, "[0] *sourceerror.ErrSource\n" +
+			"    message: timeout at 0xc000012345\n" +
+			"    location: " + loc + "\n" +
+			"    function: github.com/mwat56/sourceerror/format.TestTB\n" +
+			"    test: TestTB\n" +
+			"    id: " + se.ID + "\n" +
+			"    elapsed: 1s\n" +
+			"[1] *errors.errorString\n" +
+			"    message: timeout at 0xc000012345\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TB(t, tt.err, tt.options...); got != tt.want {
+				t.Errorf("%q: TB() =\n%s\nwant\n%s", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	if got := TB(t, cl1, WithStack()); !strings.Contains(got, "    frame: github.com/mwat56/sourceerror/format.TestTB "+loc) {
+		t.Errorf("TB(WithStack()) =\n%s\nwant the test's frame", got)
+	}
+} // TestTB()
+
+/* _EoF_ */
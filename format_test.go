@@ -0,0 +1,54 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestErrSource_Format(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	se, _ := AsSource(cl1)
+	loc := se.Location().String()
+
+	tests := []struct {
+		name   string
+		format string
+		err    error
+		want   string
+	}{
+		{"0", "%s", cl1, "some first error"},
+		{"1", "%v", cl1, "some first error"},
+		{"2", "%q", cl1, `"some first error"`},
+		{"3", "%+s", cl1, loc + ": some first error"},
+		{"4", "%+s", &ErrSource{err: e}, "some first error"},
+		{"5", "wrapped: %w", cl1, "wrapped: some first error"},
+		{"6", "%d", cl1, "%!d(sourceerror.ErrSource=some first error)"},
+		{"7", "%v", ErrSource{}, StringSourceLocation},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Errorf(tt.format, tt.err).Error(); got != tt.want {
+				t.Errorf("%q: Format() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	if got := fmt.Sprintf("%+v", cl1); (got != se.Detail()) ||
+		!strings.Contains(got, "Stack: goroutine") {
+		t.Errorf("Format() = %q, want %q", got, se.Detail())
+	}
+} // TestErrSource_Format()
+
+/* _EoF_ */
@@ -0,0 +1,499 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"iter"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `Frame` describes a single entry of a call stack.
+	//
+	// The fields are as follows:
+	// - `File`: The source file of the frame.
+	// - `Function`: The (fully qualified) function name of the frame.
+	// - `Line`: The code line within the `File`.
+	// - `PC`: The frame's program counter (only available for frames
+	// captured by the current process); it's not serialised since it
+	// is meaningless to other processes and reveals the memory layout.
+	Frame struct {
+		File     string  `json:"file,omitempty"`
+		Function string  `json:"function,omitempty"`
+		Line     int     `json:"line,omitempty"`
+		PC       uintptr `json:"-"`
+	}
+
+	// `FrameStrategy` selects the frame that is to become the
+	// location (i.e. `File`, `Function`, and `Line`) of an `ErrSource`.
+	//
+	// The given frames start with the immediate caller of the wrapping
+	// function and continue up the call stack.
+	// The function returns the index of the selected frame; if the
+	// returned index is out of range the immediate caller is used.
+	FrameStrategy func(aFrames []Frame) int
+)
+
+const (
+	// The max. number of frames to investigate by a `FrameStrategy`.
+	maxStrategyFrames = 32
+)
+
+var (
+	// `Raw` selects the immediate caller of the wrapping function
+	// (which is the default).
+	Raw FrameStrategy = func([]Frame) int {
+		return 0
+	}
+
+	// `FirstNonInternal` selects the first frame that belongs neither
+	// to this package, nor to the Go standard library, nor to an
+	// `internal` package.
+	FirstNonInternal FrameStrategy = func(aFrames []Frame) int {
+		for idx, frame := range aFrames {
+			if !isInternalFrame(frame) {
+				return idx
+			}
+		}
+
+		return 0
+	}
+
+	// `DeferAdjust` selects the immediate caller of the wrapping
+	// function, or the caller's parent if the error is wrapped in a
+	// deferred function (see `WithDeferAdjust()`).
+	DeferAdjust = WithDeferAdjust(Raw)
+
+	// `TopFrame` is the strategy used by `Wrap()` to select the frame
+	// that becomes the location of the error, unless another strategy
+	// is set by `Config.SetTopFrame()` or `UseProfile()`.
+	// A `nil` value is treated like `Raw`.
+	//
+	// Deprecated: Changing the strategy at runtime races with the
+	// wrapping functions; use `DefaultConfig().SetTopFrame()` instead.
+	TopFrame = Raw
+
+	// The import path of this very package.
+	thisPackage = func() string {
+		pc, _, _, _ := runtime.Caller(0)
+
+		return funcPackage(runtime.FuncForPC(pc).Name())
+	}()
+)
+
+// `FirstInModule()` returns a strategy that selects the first frame
+// belonging to a package of the module with the given path.
+//
+// Parameters:
+// - `aModulePath`: The module's path, e.g. "github.com/user/project".
+//
+// Returns:
+// - `FrameStrategy`: The frame selecting strategy.
+func FirstInModule(aModulePath string) FrameStrategy {
+	aModulePath = strings.TrimSuffix(aModulePath, "/")
+
+	return func(aFrames []Frame) int {
+		for idx, frame := range aFrames {
+			if inModule(frame.Function, aModulePath) {
+				return idx
+			}
+		}
+
+		return 0
+	}
+} // FirstInModule()
+
+// `WithDeferAdjust()` returns a strategy that skips a deferred
+// function (a closure or the compiler's wrapper of a `defer` statement)
+// wrapping an error, so that the function which declared the `defer`
+// is reported instead of e.g. "pkg.Save.func1" or "pkg.Save.deferwrap1".
+// All other frames are selected by the given strategy.
+//
+// NOTE: The reported line of the declaring function is the one where
+// it returned (i.e. where the deferred function was run).
+//
+// Parameters:
+// - `aStrategy`: The strategy to select the frame after the adjustment;
+// `nil` is treated like `Raw`.
+//
+// Returns:
+// - `FrameStrategy`: The frame selecting strategy.
+func WithDeferAdjust(aStrategy FrameStrategy) FrameStrategy {
+	if nil == aStrategy {
+		aStrategy = Raw
+	}
+
+	return func(aFrames []Frame) int {
+		if (2 > len(aFrames)) ||
+			!isClosureOf(aFrames[0].Function, aFrames[1].Function) {
+			return aStrategy(aFrames)
+		}
+
+		return aStrategy(aFrames[1:]) + 1
+	}
+} // WithDeferAdjust()
+
+// `callerFrames()` returns the frames of the current goroutine's call
+// stack.
+//
+// Parameters:
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `callerFrames()`.
+//
+// Returns:
+// - `[]Frame`: The list of caller frames.
+func callerFrames(aSkip int) []Frame {
+	pcs := make([]uintptr, maxStrategyFrames)
+	// skip `runtime.Callers()` and `callerFrames()`
+	num := runtime.Callers(aSkip+2, pcs)
+	if 0 == num {
+		return nil
+	}
+
+	return SymbolizePCs(pcs[:num])
+} // callerFrames()
+
+// `callerPCs()` returns the program counters of the current goroutine's
+// call stack.
+//
+// Parameters:
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `callerPCs()`.
+//
+// Returns:
+// - `[]uintptr`: The program counters, innermost first.
+func callerPCs(aSkip int) []uintptr {
+	pcs := make([]uintptr, maxStrategyFrames)
+	for {
+		// skip `runtime.Callers()` and `callerPCs()`
+		num := runtime.Callers(aSkip+2, pcs)
+		if num < len(pcs) {
+			return slices.Clip(pcs[:num])
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+} // callerPCs()
+
+// `funcPackage()` returns the package path of the given fully qualified
+// function name.
+//
+// Parameters:
+// - `aFunction`: The function name, e.g. "github.com/a/b.(*T).M".
+//
+// Returns:
+// - `string`: The package path, e.g. "github.com/a/b".
+func funcPackage(aFunction string) string {
+	slash := strings.LastIndexByte(aFunction, '/')
+	if dot := strings.IndexByte(aFunction[slash+1:], '.'); 0 <= dot {
+		return aFunction[:slash+1+dot]
+	}
+
+	return aFunction
+} // funcPackage()
+
+// `inModule()` reports whether the given function belongs to a package
+// of the given module.
+//
+// Parameters:
+// - `aFunction`: The (fully qualified) function name.
+// - `aModulePath`: The module's path (without trailing slash).
+//
+// Returns:
+// - `bool`: `true` if the function belongs to the module.
+func inModule(aFunction, aModulePath string) bool {
+	pkg := funcPackage(aFunction)
+
+	return (pkg == aModulePath) || strings.HasPrefix(pkg, aModulePath+"/")
+} // inModule()
+
+// `isClosureOf()` checks whether the first function is a closure (or
+// a `defer` wrapper) declared within the second function.
+//
+// Parameters:
+// - `aInner`: The (fully qualified) name of the possible closure.
+// - `aOuter`: The (fully qualified) name of the declaring function.
+//
+// Returns:
+// - `bool`: `true` if `aInner` is declared within `aOuter`.
+func isClosureOf(aInner, aOuter string) bool {
+	rest, ok := strings.CutPrefix(aInner, aOuter+".")
+	if !ok {
+		return false
+	}
+	if tail, ok := strings.CutPrefix(rest, "func"); ok {
+		rest = tail
+	} else if tail, ok := strings.CutPrefix(rest, "deferwrap"); ok {
+		rest = tail
+	}
+
+	// e.g. "func1", "deferwrap1", or "1" (a closure within a closure)
+	return ("" != rest) && ("" == strings.Trim(rest, "0123456789"))
+} // isClosureOf()
+
+// `isRaw()` reports whether the given strategy is the `Raw` one.
+//
+// Parameters:
+// - `aStrategy`: The strategy to check.
+//
+// Returns:
+// - `bool`: `true` if `aStrategy` is `Raw`, `false` otherwise.
+func isRaw(aStrategy FrameStrategy) bool {
+	return reflect.ValueOf(aStrategy).Pointer() ==
+		reflect.ValueOf(Raw).Pointer()
+} // isRaw()
+
+// `isInternalFrame()` reports whether the given frame belongs to this
+// package (apart from its tests), to the Go standard library, or to an
+// `internal` package.
+//
+// Parameters:
+// - `aFrame`: The frame to check.
+//
+// Returns:
+// - `bool`: `true` if the frame is an internal one, `false` otherwise.
+func isInternalFrame(aFrame Frame) bool {
+	pkg := funcPackage(aFrame.Function)
+	if "" == pkg {
+		return true
+	}
+	if pkg == thisPackage {
+		return !strings.HasSuffix(aFrame.File, "_test.go")
+	}
+	if ("internal" == pkg) || strings.HasPrefix(pkg, "internal/") ||
+		strings.Contains(pkg, "/internal/") ||
+		strings.HasSuffix(pkg, "/internal") {
+		return true
+	}
+
+	// Standard library packages have no dot in their first path
+	// element (the `main` package being the exception).
+	first, _, _ := strings.Cut(pkg, "/")
+
+	return ("main" != pkg) && !strings.Contains(first, ".")
+} // isInternalFrame()
+
+// `pcFrameSeq()` returns an iterator over the frames of the given
+// program counters, starting with the given function.
+//
+// Parameters:
+// - `aPCs`: The program counters, innermost first.
+// - `aFunction`: The function of the first frame to yield; if it's
+// empty or not found, all frames are yielded.
+//
+// Returns:
+// - `iter.Seq[Frame]`: The iterator over the frames.
+func pcFrameSeq(aPCs []uintptr, aFunction string) iter.Seq[Frame] {
+	walk := func(aSkip bool, aYield func(Frame) bool) (rFound, rStopped bool) {
+		for _, pc := range aPCs {
+			for _, frame := range symbolize(pc) {
+				frame.Function = demangle(frame.Function)
+				if aSkip {
+					if frame.Function != aFunction {
+						continue
+					}
+					aSkip, rFound = false, true
+				}
+				if !aYield(frame) {
+					return rFound, true
+				}
+			}
+		}
+		return rFound, false
+	}
+
+	return func(yield func(Frame) bool) {
+		if "" != aFunction {
+			if found, stopped := walk(true, yield); found || stopped {
+				return
+			}
+		}
+		_, _ = walk(false, yield)
+	}
+} // pcFrameSeq()
+
+// `sameFunction()` reports whether the given function line of a call
+// stack (e.g. "main.(*T).M(0xc0000, ...)") names the given function.
+//
+// Parameters:
+// - `aLine`: The function line of a call stack.
+// - `aFunction`: The (demangled) function name to compare with.
+//
+// Returns:
+// - `bool`: `true` if the line names the function, `false` otherwise.
+func sameFunction(aLine []byte, aFunction string) bool {
+	name := bytes.TrimSpace(aLine)
+	if bytes.HasSuffix(name, []byte(")")) {
+		if idx := bytes.LastIndexByte(name, '('); 0 < idx {
+			name = name[:idx]
+		}
+	}
+	if bytes.Contains(name, []byte(shapePrefix)) {
+		return demangle(string(name)) == aFunction
+	}
+
+	return string(name) == aFunction
+} // sameFunction()
+
+// `textFrameSeq()` returns an iterator over the frames of the given
+// call stack in the format used by `debug.Stack()`, starting with the
+// given function.
+//
+// Only the yielded frames are converted into strings, i.e. skipped
+// frames and all other lines don't allocate.
+//
+// Parameters:
+// - `aStack`: The call stack to parse.
+// - `aFunction`: The function of the first frame to yield; if it's
+// empty or not found, all frames are yielded.
+//
+// Returns:
+// - `iter.Seq[Frame]`: The iterator over the frames.
+func textFrameSeq(aStack []byte, aFunction string) iter.Seq[Frame] {
+	walk := func(aSkip bool, aYield func(Frame) bool) (rFound, rStopped bool) {
+		walkStackText(aStack, func(aFuncLine, aFileLine []byte) bool {
+			if aSkip {
+				if !sameFunction(aFuncLine, aFunction) {
+					return true
+				}
+				aSkip, rFound = false, true
+			}
+			file, line, ok := parseFileLine(string(aFileLine))
+			if !ok {
+				return true
+			}
+			rStopped = !aYield(Frame{
+				File:     file,
+				Function: parseFunction(string(aFuncLine)),
+				Line:     line,
+			})
+			return !rStopped
+		})
+		return rFound, rStopped
+	}
+
+	return func(yield func(Frame) bool) {
+		if "" != aFunction {
+			if found, stopped := walk(true, yield); found || stopped {
+				return
+			}
+		}
+		_, _ = walk(false, yield)
+	}
+} // textFrameSeq()
+
+// `walkStackText()` calls the given function for each frame of the
+// given call stack in the format used by `debug.Stack()` until the
+// function returns `false`.
+//
+// The "created by" entries of the goroutines are skipped.
+//
+// Parameters:
+// - `aStack`: The call stack to walk.
+// - `aFunc`: The function to call with the frame's function line and
+// its file line.
+func walkStackText(aStack []byte, aFunc func(aFuncLine, aFileLine []byte) bool) {
+	var (
+		function    []byte
+		inGoroutine bool
+	)
+	for 0 < len(aStack) {
+		var line []byte
+		if idx := bytes.IndexByte(aStack, '\n'); 0 <= idx {
+			line, aStack = aStack[:idx], aStack[idx+1:]
+		} else {
+			line, aStack = aStack, nil
+		}
+		line = bytes.TrimRight(line, "\r")
+
+		if bytes.HasPrefix(line, []byte("goroutine ")) &&
+			reGoroutineHeader.Match(line) {
+			function, inGoroutine = nil, true
+			continue
+		}
+		if !inGoroutine {
+			continue
+		}
+		if 0 == len(bytes.TrimSpace(line)) {
+			function, inGoroutine = nil, false
+			continue
+		}
+
+		if bytes.HasPrefix(line, []byte("\t")) {
+			if (nil != function) && !bytes.HasPrefix(function, []byte("created by ")) {
+				if !aFunc(function, line) {
+					return
+				}
+			}
+			function = nil
+			continue
+		}
+		if bytes.HasPrefix(line, []byte("...")) {
+			// e.g. "...additional frames elided..."
+			continue
+		}
+		function = line
+	}
+} // walkStackText()
+
+// `All()` returns an iterator over the frames of the error's call
+// stack, starting with the error's location (see `Function`) and
+// continuing up the call stack like `Frames()`:
+//
+//	for frame := range se.All() {
+//		if strings.HasPrefix(frame.Function, "example.com/app/") {
+//			return frame
+//		}
+//	}
+//
+// The frames are resolved lazily, i.e. only as far as the caller
+// ranges over them: for errors created by the current process from
+// the captured program counters (see `SymbolizePCs()`), otherwise
+// (e.g. for decoded errors) by parsing the `Stack` field.
+//
+// Returns:
+// - `iter.Seq[Frame]`: The iterator over the call stack's frames.
+func (se ErrSource) All() iter.Seq[Frame] {
+	switch {
+	case nil != se.lazy:
+		return func(yield func(Frame) bool) {
+			se.lazy.resolve(&se)
+			for _, frame := range se.lazy.frames {
+				if !yield(frame) {
+					return
+				}
+			}
+		}
+
+	case 0 < len(se.pcs):
+		return pcFrameSeq(se.pcs, se.Function)
+	}
+
+	return textFrameSeq(se.Stack, se.Function)
+} // All()
+
+// `Frames()` returns the frames of the error's call stack, starting
+// with the error's location (see `Function`) and continuing up the
+// call stack.
+//
+// For errors created by the current process the frames are resolved
+// from the captured program counters (see `SymbolizePCs()`), hence
+// their `PC` field is set; otherwise (e.g. for decoded errors) they
+// are parsed from the `Stack` field.
+//
+// Returns:
+// - `[]Frame`: The call stack's frames, or `nil` if there is no stack.
+func (se ErrSource) Frames() []Frame {
+	return slices.Collect(se.All())
+} // Frames()
+
+/* _EoF_ */
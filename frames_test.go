@@ -0,0 +1,291 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// frames used by several strategy tests
+	testStrategyFrames = []Frame{
+		{"/x/sourceerror/sourceerror.go", thisPackage + ".Wrap", 10, 0},
+		{"/x/go/src/fmt/errors.go", "fmt.Errorf", 20, 0},
+		{"/x/app/internal/help/help.go", "example.com/app/internal/help.Must[...]", 30, 0},
+		{"/x/app/users/users.go", "example.com/app/users.(*Repo).Load", 40, 0},
+		{"/x/app/main.go", "main.main", 50, 0},
+	}
+)
+
+func testDeferClosure(aStrategy FrameStrategy) (rErr error) {
+	defer func() {
+		rErr = WrapWith(errors.New("deferred error"), 0, aStrategy)
+	}()
+
+	return nil
+} // testDeferClosure()
+
+func testDeferNested(aStrategy FrameStrategy) (rErr error) {
+	func() {
+		defer func() {
+			rErr = WrapWith(errors.New("deferred error"), 0, aStrategy)
+		}()
+	}()
+
+	return
+} // testDeferNested()
+
+func TestErrSource_All(t *testing.T) {
+	stack := []byte("goroutine 7 [running]:\n" +
+		"main.inner(0x1)\n\t/src/main.go:12 +0x1d\n" +
+		"...additional frames elided...\n" +
+		"main.outer()\n\t/src/main.go:20 +0x2e\n" +
+		"created by main.main in goroutine 1\n\t/src/main.go:30 +0x3f\n")
+	want := []Frame{
+		{"/src/main.go", "main.inner", 12, 0},
+		{"/src/main.go", "main.outer", 20, 0},
+	}
+
+	tests := []struct {
+		name  string
+		se    ErrSource
+		limit int
+		want  []Frame
+	}{
+		{"0", ErrSource{}, 5, nil},
+		{"1", ErrSource{Stack: stack}, 5, want},
+		{"2", ErrSource{Stack: stack}, 1, want[:1]},
+		{"3", ErrSource{Stack: []byte("main.f()\n\t/src/main.go:1\n")}, 5, nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Frame
+			for frame := range tt.se.All() {
+				got = append(got, frame)
+				if len(got) == tt.limit {
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: ErrSource.All() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+
+	se := Wrap(errors.New("some first error"), 0).(*ErrSource)
+	if got := se.Frames(); 0 == len(got) {
+		t.Error("ErrSource.Frames() = no frames, want some")
+	}
+} // TestErrSource_All()
+
+func TestErrSource_Frames(t *testing.T) {
+	se := Wrap(errors.New("some first error"), 0).(*ErrSource)
+	line := se.Line
+	stack := []byte("goroutine 7 [running]:\n" +
+		"main.wrap(0x1)\n\t/src/main.go:5 +0x1d\n" +
+		"main.inner(0x1)\n\t/src/main.go:12 +0x1d\n" +
+		"main.outer()\n\t/src/main.go:20 +0x2e\n")
+
+	tests := []struct {
+		name string
+		se   ErrSource
+		want []Frame
+	}{
+		{"0", ErrSource{}, nil},
+		{"1", ErrSource{Stack: stack}, []Frame{
+			{"/src/main.go", "main.wrap", 5, 0},
+			{"/src/main.go", "main.inner", 12, 0},
+			{"/src/main.go", "main.outer", 20, 0},
+		}},
+		{"2", ErrSource{Function: "main.inner", Stack: stack}, []Frame{
+			{"/src/main.go", "main.inner", 12, 0},
+			{"/src/main.go", "main.outer", 20, 0},
+		}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.se.Frames(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: ErrSource.Frames() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+
+	got := se.Frames()
+	if (0 == len(got)) || (got[0].Function != se.Function) ||
+		(got[0].Line != line) || (0 == got[0].PC) {
+		t.Fatalf("ErrSource.Frames() = %v, want %s:%d with PC first",
+			got, se.Function, line)
+	}
+	// the captured text starts at the location as well
+	for frame := range textFrameSeq(se.Stack, se.Function) {
+		if (frame.Function != got[0].Function) || (frame.Line != got[0].Line) {
+			t.Errorf("ErrSource.Stack starts with %v, want %v", frame, got[0])
+		}
+		break
+	}
+} // TestErrSource_Frames()
+
+func TestDeferAdjust(t *testing.T) {
+	e := errors.New("some first error")
+	tests := []struct {
+		name     string
+		err      error
+		wantFunc string
+	}{
+		{"0", WrapWith(e, 0, DeferAdjust), thisPackage + ".TestDeferAdjust"},
+		{"1", testDeferClosure(Raw), thisPackage + ".testDeferClosure.func1"},
+		{"2", testDeferClosure(DeferAdjust), thisPackage + ".testDeferClosure"},
+		{"3", testDeferClosure(WithDeferAdjust(nil)), thisPackage + ".testDeferClosure"},
+		{"4", testDeferNested(DeferAdjust), thisPackage + ".testDeferNested.func1"},
+		{"5", testDeferClosure(WithDeferAdjust(func([]Frame) int { return 1 })),
+			thisPackage + ".TestDeferAdjust"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			se := tt.err.(*ErrSource)
+			if se.Function != tt.wantFunc {
+				t.Errorf("%q: DeferAdjust function = %q, want %q",
+					tt.name, se.Function, tt.wantFunc)
+			}
+		})
+	}
+} // TestDeferAdjust()
+
+func TestFirstInModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		module string
+		want   int
+	}{
+		{"1", "example.com/app", 2},
+		{"2", "example.com/app/users/", 3},
+		{"3", "example.com/ap", 0},
+		{"4", "fmt", 1},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FirstInModule(tt.module)(testStrategyFrames); got != tt.want {
+				t.Errorf("%q: FirstInModule() = %d, want %d",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestFirstInModule()
+
+func TestFirstNonInternal(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []Frame
+		want   int
+	}{
+		{"0", nil, 0},
+		{"1", testStrategyFrames, 3},
+		{"2", testStrategyFrames[4:], 0},
+		{"3", testStrategyFrames[:3], 0},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FirstNonInternal(tt.frames); got != tt.want {
+				t.Errorf("%q: FirstNonInternal() = %d, want %d",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestFirstNonInternal()
+
+func Test_funcPackage(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		want     string
+	}{
+		{"0", "", ""},
+		{"1", "main.main", "main"},
+		{"2", "net/http.(*Server).Serve", "net/http"},
+		{"3", "github.com/a/b.(*T).M", "github.com/a/b"},
+		{"4", "gopkg.in/yaml.v3.Unmarshal", "gopkg.in/yaml"},
+		{"5", "github.com/a/b.F.func1", "github.com/a/b"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := funcPackage(tt.function); got != tt.want {
+				t.Errorf("%q: funcPackage() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_funcPackage()
+
+func Test_isClosureOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		inner string
+		outer string
+		want  bool
+	}{
+		{"0", "pkg.Save.func1", "pkg.Save", true},
+		{"1", "pkg.Save.func1.2", "pkg.Save.func1", true},
+		{"2", "pkg.(*T).Save.deferwrap1", "pkg.(*T).Save", true},
+		{"3", "pkg.Save", "pkg.Save", false},
+		{"4", "pkg.SaveAll.func1", "pkg.Save", false},
+		{"5", "pkg.Save.funcX", "pkg.Save", false},
+		{"6", "pkg.Save.func", "pkg.Save", false},
+		{"7", "pkg.Save.func1.2", "pkg.Save", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isClosureOf(tt.inner, tt.outer); got != tt.want {
+				t.Errorf("%q: isClosureOf() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_isClosureOf()
+
+func TestWrapWith(t *testing.T) {
+	e := errors.New("some first error")
+	helper := func(aErr error) error {
+		// skip the helper itself
+		return WrapWith(aErr, 0, func([]Frame) int { return 1 })
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantFunc string
+	}{
+		{"1", WrapWith(e, 0, nil), thisPackage + ".TestWrapWith"},
+		{"2", WrapWith(e, 0, Raw), thisPackage + ".TestWrapWith"},
+		{"3", helper(e), thisPackage + ".TestWrapWith"},
+		{"4", WrapWith(e, 0, func([]Frame) int { return 1 }), "testing.tRunner"},
+		{"5", WrapWith(e, 0, func([]Frame) int { return -1 }), thisPackage + ".TestWrapWith"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			se := tt.err.(*ErrSource)
+			if se.Function != tt.wantFunc {
+				t.Errorf("%q: WrapWith() function = %q, want %q",
+					tt.name, se.Function, tt.wantFunc)
+			}
+		})
+	}
+} // TestWrapWith()
+
+/* _EoF_ */
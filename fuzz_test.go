@@ -0,0 +1,265 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tWireFormat` is a serialised form of errors checked by
+	// `fuzzRoundTrip()`.
+	tWireFormat struct {
+		name   string
+		encode func(*ErrSource) ([]byte, error)
+		decode func([]byte) (*ErrSource, error)
+	}
+)
+
+var (
+	// The (public) key used to check the signed form.
+	fuzzSignKey = []byte("sourceerror fuzz key")
+
+	// The key pair used to check the encrypted form.
+	fuzzPrivateKey = func() *ecdh.PrivateKey {
+		seed := sha256.Sum256(fuzzSignKey)
+		key, _ := ecdh.X25519().NewPrivateKey(seed[:])
+		return key
+	}()
+
+	// The serialised forms checked by `fuzzRoundTrip()`.
+	wireFormats = []tWireFormat{
+		{"json", func(aSource *ErrSource) ([]byte, error) {
+			return json.Marshal(newRecord(aSource))
+		}, func(aData []byte) (*ErrSource, error) {
+			var record tRecord
+			if err := json.Unmarshal(aData, &record); nil != err {
+				return nil, err
+			}
+			return record.source(), nil
+		}},
+		{"signed", func(aSource *ErrSource) ([]byte, error) {
+			return SignedEncode(aSource, fuzzSignKey)
+		}, func(aData []byte) (*ErrSource, error) {
+			return VerifyDecode(aData, fuzzSignKey)
+		}},
+		{"encrypted", func(aSource *ErrSource) ([]byte, error) {
+			return EncryptedEncode(aSource, fuzzPrivateKey.PublicKey())
+		}, func(aData []byte) (*ErrSource, error) {
+			return DecryptDecode(aData, fuzzPrivateKey)
+		}},
+	}
+)
+
+// `canonical()` returns the canonical serialisation of the given error
+// used to compare errors semantically.
+func canonical(aSource *ErrSource) ([]byte, error) {
+	return json.Marshal(newRecord(aSource))
+} // canonical()
+
+// `fuzzCorpus()` returns serialised errors (in the JSON form used by
+// e.g. `Store.Persist()`) covering all fields.
+func fuzzCorpus() [][]byte {
+	base := newSource(errors.New("some first error"), 0, 0, Raw)
+	full := *base
+	full.External = &Location{File: "page.tmpl", Line: 7}
+	full.Foreign = []ForeignStack{{
+		Name:   "lua",
+		Frames: []Frame{{File: "script.lua", Function: "main", Line: 3}},
+	}}
+	full.Elapsed = 1500 * time.Millisecond
+	full.Phase = PhaseShutdown
+	full.Seq = 42
+	full.Test = "TestFuzz"
+	full.Input = &Position{Record: 3, Column: 7, Offset: 99}
+	omitted := ErrSource{
+		err:          errors.New("ünïcödé\n\"quoted\"\t<tag>"),
+		ID:           "x",
+		StackOmitted: true,
+		OmitReason:   ReasonNoStack,
+	}
+
+	sources := []*ErrSource{
+		base,
+		&full,
+		&omitted,
+		{},
+		newSource(base, 1, 0, Raw),
+	}
+	result := make([][]byte, 0, len(sources)+1)
+	for _, se := range sources {
+		if data, err := canonical(se); nil == err {
+			result = append(result, data)
+		}
+	}
+
+	return append(result, []byte(`{"v":1,"chain":[{"line":1},{"repeated":2}]}`))
+} // fuzzCorpus()
+
+// `textFields()` returns a copy of the given error reduced to the
+// fields restored from its text form (see `parseTextForm()`), or
+// `nil` if the text form is ambiguous (e.g. for a message spanning
+// several lines).
+func textFields(aSource *ErrSource) *ErrSource {
+	message := aSource.message()
+	if strings.ContainsAny(aSource.ID, " \t\n\f\r") ||
+		strings.Contains(message+aSource.Phase+aSource.Test, "\n") ||
+		("<nil>" == message) {
+		return nil
+	}
+	stack := demangleStack(foldStack(aSource.StackTrace()))
+	for _, trailer := range textTrailers {
+		if bytes.Contains(stack, []byte(trailer)) {
+			return nil
+		}
+	}
+
+	result := &ErrSource{
+		ID:       aSource.ID,
+		File:     aSource.File,
+		Line:     aSource.Line,
+		Function: aSource.Function,
+		Stack:    aSource.Stack,
+		Phase:    aSource.Phase,
+		Seq:      aSource.Seq,
+		Test:     aSource.Test,
+	}
+	if "" != message {
+		result.err = errors.New(message)
+	}
+	if 0 < aSource.Elapsed {
+		result.Elapsed = aSource.Elapsed
+	}
+	if nil != aSource.Input {
+		input := Position{
+			Record: max(0, aSource.Input.Record),
+			Column: max(0, aSource.Input.Column),
+			Offset: max(0, aSource.Input.Offset),
+		}
+		if (Position{} != input) {
+			result.Input = &input
+		}
+	}
+
+	return result
+} // textFields()
+
+// `fuzzRoundTrip()` checks that the error serialised by the given data
+// (in the JSON form used by e.g. `Store.Persist()`) survives all of the
+// package's wire formats (plain JSON, signed, encrypted, and text)
+// unchanged.
+//
+// Data that doesn't decode to an error is ignored. Errors are compared
+// by their canonical serialisation; the chain's inner layers aren't
+// compared since the decoders don't restore them. The text form is
+// only compared by the fields it keeps (see `textFields()`).
+func fuzzRoundTrip(aData []byte) error {
+	var record tRecord
+	if err := json.Unmarshal(aData, &record); nil != err {
+		return nil
+	}
+	source := record.source()
+	want, err := canonical(source)
+	if nil != err {
+		return fmt.Errorf("canonical form: %w", err)
+	}
+
+	for _, format := range wireFormats {
+		data, err := format.encode(source)
+		if nil != err {
+			return fmt.Errorf("%s: encoding: %w", format.name, err)
+		}
+		decoded, err := format.decode(data)
+		if nil != err {
+			return fmt.Errorf("%s: decoding: %w", format.name, err)
+		}
+		got, err := canonical(decoded)
+		if nil != err {
+			return fmt.Errorf("%s: canonical form: %w", format.name, err)
+		}
+		if !bytes.Equal(got, want) {
+			return fmt.Errorf("%s: round trip changed the error:\n%s\nwant\n%s",
+				format.name, got, want)
+		}
+	}
+
+	reduced := textFields(source)
+	if nil == reduced {
+		return nil
+	}
+	decoded, err := Decode([]byte(reduced.String()))
+	if nil != err {
+		return fmt.Errorf("text: decoding: %w", err)
+	}
+	// the text form holds the folded and demangled call stack
+	reduced.Stack = demangleStack(foldStack(reduced.Stack))
+	if want, err = canonical(reduced); nil != err {
+		return fmt.Errorf("text: canonical form: %w", err)
+	}
+	got, err := canonical(decoded)
+	if nil != err {
+		return fmt.Errorf("text: canonical form: %w", err)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("text: round trip changed the error:\n%s\nwant\n%s",
+			got, want)
+	}
+
+	return nil
+} // fuzzRoundTrip()
+
+func TestFuzzRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"0", ""},
+		{"1", "not json"},
+		{"2", "{}"},
+		{"3", `{"v":1,"message":"x","foreign":[],"external":{}}`},
+		{"4", `{"v":-7,"id":"\u0000","line":-1,"elapsed_ns":-5,"stack_omitted":"r"}`},
+		{"5", `{"foreign":[{"name":"","frames":null}]}`},
+		{"6", `{"id":"a b","message":"x\ny","phase":"p\nq"}`},
+		{"7", `{"message":"x","file":"a\"b.go","line":7,"function":"f\n","stack":"s","input":{"record":-1,"column":2}}`},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := fuzzRoundTrip([]byte(tt.data)); nil != err {
+				t.Errorf("%q: fuzzRoundTrip() = %v", tt.name, err)
+			}
+		})
+	}
+	for idx, seed := range fuzzCorpus() {
+		if err := fuzzRoundTrip(seed); nil != err {
+			t.Errorf("fuzzCorpus()[%d]: fuzzRoundTrip() = %v", idx, err)
+		}
+	}
+} // TestFuzzRoundTrip()
+
+func FuzzErrors(f *testing.F) {
+	for _, seed := range fuzzCorpus() {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, aData []byte) {
+		if err := fuzzRoundTrip(aData); nil != err {
+			t.Error(err)
+		}
+	})
+} // FuzzErrors()
+
+/* _EoF_ */
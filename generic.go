@@ -0,0 +1,58 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Wrap1()` passes the results of a function returning a value and an
+// error, wrapping a non-nil error like `Wrap()` does:
+//
+//	user, err := sourceerror.Wrap1(repo.Load(id))
+//
+// A `nil` error is returned as `nil` so that the function can be used
+// for every call's results. The function is small enough to be inlined
+// by the compiler, hence the `nil` case costs no more than returning
+// the results directly.
+//
+// Parameters:
+// - `aValue`: The value to pass.
+// - `aErr`: The error to wrap (if any).
+//
+// Returns:
+// - `T`: The given value.
+// - `error`: The wrapped error or `nil`.
+func Wrap1[T any](aValue T, aErr error) (T, error) {
+	if nil == aErr {
+		return aValue, nil
+	}
+
+	return aValue, newSource(aErr, 0, 1, defaultConfig.TopFrame())
+} // Wrap1()
+
+// `Wrap2()` works like `Wrap1()` for functions returning two values
+// and an error:
+//
+//	key, value, err := sourceerror.Wrap2(cache.Next())
+//
+// Parameters:
+// - `aValue1`: The first value to pass.
+// - `aValue2`: The second value to pass.
+// - `aErr`: The error to wrap (if any).
+//
+// Returns:
+// - `T`: The given first value.
+// - `U`: The given second value.
+// - `error`: The wrapped error or `nil`.
+func Wrap2[T, U any](aValue1 T, aValue2 U, aErr error) (T, U, error) {
+	if nil == aErr {
+		return aValue1, aValue2, nil
+	}
+
+	return aValue1, aValue2, newSource(aErr, 0, 1, defaultConfig.TopFrame())
+} // Wrap2()
+
+/* _EoF_ */
@@ -0,0 +1,125 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `atoi()` is a function returning a value and an error.
+func atoi(aText string) (int, error) {
+	return strconv.Atoi(aText)
+} // atoi()
+
+// `split()` is a function returning two values and an error.
+func split(aText string) (string, int, error) {
+	n, err := strconv.Atoi(aText[1:])
+	return aText[:1], n, err
+} // split()
+
+func TestWrap1(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    int
+		wantErr bool
+	}{
+		{"0", "42", 42, false},
+		{"1", "x", 0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, line, _ := runtime.Caller(0)
+			got, err := Wrap1(atoi(tt.text))
+			if got != tt.want {
+				t.Errorf("%q: Wrap1() = %d, want %d", tt.name, got, tt.want)
+			}
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: Wrap1() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if nil == err {
+				return
+			}
+			var se *ErrSource
+			if !errors.As(err, &se) || (line+1 != se.Line) {
+				t.Errorf("%q: Wrap1() location = %v, want line %d",
+					tt.name, se, line+1)
+			}
+		})
+	}
+} // TestWrap1()
+
+func TestWrap2(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want1   string
+		want2   int
+		wantErr bool
+	}{
+		{"0", "a42", "a", 42, false},
+		{"1", "bx", "b", 0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, line, _ := runtime.Caller(0)
+			got1, got2, err := Wrap2(split(tt.text))
+			if (got1 != tt.want1) || (got2 != tt.want2) {
+				t.Errorf("%q: Wrap2() = %q, %d, want %q, %d",
+					tt.name, got1, got2, tt.want1, tt.want2)
+			}
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: Wrap2() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if nil == err {
+				return
+			}
+			var se *ErrSource
+			if !errors.As(err, &se) || (line+1 != se.Line) {
+				t.Errorf("%q: Wrap2() location = %v, want line %d",
+					tt.name, se, line+1)
+			}
+		})
+	}
+} // TestWrap2()
+
+func BenchmarkDirect(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := atoi("42"); nil != err {
+			b.Fatal(err)
+		}
+	}
+} // BenchmarkDirect()
+
+func BenchmarkWrap1(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := Wrap1(atoi("42")); nil != err {
+			b.Fatal(err)
+		}
+	}
+} // BenchmarkWrap1()
+
+func BenchmarkWrap2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, _, err := Wrap2(split("a42")); nil != err {
+			b.Fatal(err)
+		}
+	}
+} // BenchmarkWrap2()
+
+/* _EoF_ */
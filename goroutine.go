@@ -0,0 +1,58 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The name of the foreign stack added by `GoWrap()`.
+	createdByName = "created by"
+)
+
+// `GoWrap()` returns a function running the given function and
+// annotating its error with the call stack of the code calling
+// `GoWrap()`, i.e. usually the code starting the goroutine:
+//
+//	group.Go(sourceerror.GoWrap(func() error {
+//		return fetch(ctx, url)
+//	}))
+//
+// A goroutine's call stack ends with the goroutine's function, so an
+// error wrapped within the goroutine loses the context the goroutine
+// was started from. The annotated error carries that context as a
+// foreign stack named "created by" (see `ErrSource.Foreign`) which is
+// rendered beneath the error's own call stack.
+//
+// If the error isn't an `ErrSource`, it gets wrapped with the location
+// of the code calling the returned function.
+//
+// NOTE: If locations are disabled (see `Config.SetEnabled()`), the
+// given function is returned unchanged.
+//
+// Parameters:
+// - `aFn`: The function to run within the goroutine.
+//
+// Returns:
+// - `func() error`: The function annotating `aFn`'s error.
+func GoWrap(aFn func() error) func() error {
+	if defaultConfig.noDebug() {
+		return aFn
+	}
+	created := callerFrames(1)
+
+	return func() error {
+		return annotate(aFn(), 1, func(aSource *ErrSource) {
+			aSource.Foreign = append(aSource.Foreign[:len(aSource.Foreign):len(aSource.Foreign)],
+				ForeignStack{
+					Name:   createdByName,
+					Frames: created,
+				})
+		})
+	}
+} // GoWrap()
+
+/* _EoF_ */
@@ -0,0 +1,65 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestGoWrap(t *testing.T) {
+	e := errors.New("some first error")
+
+	tests := []struct {
+		name    string
+		fn      func() error
+		wantErr bool
+	}{
+		{"0", func() error { return nil }, false},
+		{"1", func() error { return e }, true},
+		{"2", func() error { return Wrap(e, 0) }, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := GoWrap(tt.fn)
+			done := make(chan error)
+			go func() {
+				done <- fn()
+			}()
+			err := <-done
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("%q: GoWrap() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			if !errors.Is(err, e) {
+				t.Errorf("%q: GoWrap() = %v, want %v", tt.name, err, e)
+			}
+			se, _ := AsSource(err)
+			if (nil == se) || (1 != len(se.Foreign)) {
+				t.Fatalf("%q: GoWrap() = %#v, want one foreign stack",
+					tt.name, se)
+			}
+			created := se.Foreign[0]
+			if (createdByName != created.Name) || (0 == len(created.Frames)) ||
+				!strings.Contains(created.Frames[0].Function, ".TestGoWrap.") {
+				t.Errorf("%q: GoWrap() created by = %v", tt.name, created)
+			}
+			if got := se.Detail(); !strings.Contains(got, createdByName+":\n") {
+				t.Errorf("%q: Detail() =\n%s\nwant %q stack", tt.name, got, createdByName)
+			}
+		})
+	}
+} // TestGoWrap()
+
+/* _EoF_ */
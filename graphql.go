@@ -0,0 +1,111 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"net/http"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `GraphQLError` is an entry of a GraphQL response's "errors" list
+// (shaped like `gqlerror.Error` of gqlgen and `graphql.FormattedError`
+// of graphql-go).
+//
+// The fields are as follows:
+// - `Message`: The sanitised error message (see `Public()`).
+// - `Path`: The path of the response field that failed (to be set by
+// the GraphQL server).
+// - `Extensions`: The error's additional data, i.e. "code" and "id",
+// and in the `Development` profile "location" and "function".
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+// - `string`: The error's message.
+func (ge GraphQLError) Error() string {
+	return ge.Message
+} // Error()
+
+// --------------------------------------------------------------------------
+
+// `graphQLCode()` returns the GraphQL error code for the given HTTP
+// status, e.g. "INTERNAL_SERVER_ERROR" for 500.
+//
+// Parameters:
+// - `aStatus`: The HTTP status code.
+//
+// Returns:
+// - `string`: The error code.
+func graphQLCode(aStatus int) string {
+	text := http.StatusText(aStatus)
+	if "" == text {
+		return "INTERNAL_SERVER_ERROR"
+	}
+
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+} // graphQLCode()
+
+// `ToGraphQLError()` converts the given error into an entry of a
+// GraphQL response's error list with consistent extensions, e.g. in
+// a gqlgen error presenter:
+//
+//	srv.SetErrorPresenter(func(ctx context.Context, err error) *gqlerror.Error {
+//		ge := sourceerror.ToGraphQLError(err)
+//		return &gqlerror.Error{
+//			Message:    ge.Message,
+//			Path:       graphql.GetPath(ctx),
+//			Extensions: ge.Extensions,
+//		}
+//	})
+//
+// The "code" extension is derived from the HTTP status the error maps
+// to (see `RespondError()`), e.g. "NOT_IMPLEMENTED" for errors wrapping
+// `ErrNotImplemented`; the "id" extension holds the error's instance
+// ID (if any). The location and function of the outermost `ErrSource`
+// are added only if debug output was enabled by `Config.SetDebugPage()`
+// and the `Development` profile is used.
+//
+// Parameters:
+// - `aErr`: The error to convert.
+//
+// Returns:
+// - `*GraphQLError`: The converted error or `nil` if `aErr` is `nil`.
+func ToGraphQLError(aErr error) *GraphQLError {
+	if nil == aErr {
+		return nil
+	}
+
+	public := Public(aErr).(PublicError)
+	result := &GraphQLError{
+		Message: public.Message,
+		Extensions: map[string]any{
+			"code": graphQLCode(statusOf(aErr)),
+		},
+	}
+	if "" != public.ID {
+		result.Extensions["id"] = public.ID
+	}
+	if !defaultConfig.DebugPage() ||
+		(Development.Name != CurrentProfile().Name) {
+		return result
+	}
+	if se, ok := asSource(aErr); ok && ("" != se.File) {
+		result.Extensions["location"] = se.Location().String()
+		result.Extensions["function"] = se.Function
+	}
+
+	return result
+} // ToGraphQLError()
+
+/* _EoF_ */
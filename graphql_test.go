@@ -0,0 +1,90 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_graphQLCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   string
+	}{
+		{"0", http.StatusInternalServerError, "INTERNAL_SERVER_ERROR"},
+		{"1", http.StatusNotImplemented, "NOT_IMPLEMENTED"},
+		{"2", http.StatusTeapot, "IM_A_TEAPOT"},
+		{"3", http.StatusNonAuthoritativeInfo, "NON_AUTHORITATIVE_INFORMATION"},
+		{"4", 999, "INTERNAL_SERVER_ERROR"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graphQLCode(tt.status); got != tt.want {
+				t.Errorf("%q: graphQLCode() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_graphQLCode()
+
+func TestToGraphQLError(t *testing.T) {
+	defer UseProfile(CurrentProfile())
+	defer DefaultConfig().SetDebugPage(false)
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	cl2 := Wrap(fmt.Errorf("%w: mutation", ErrNotImplemented), 0)
+
+	tests := []struct {
+		name      string
+		profile   Profile
+		debug     bool
+		err       error
+		wantNil   bool
+		wantJSON  string
+		wantExtra bool
+	}{
+		{"0", Development, true, nil, true, "", false},
+		{"1", Development, true, e, false, `{"message":"Internal Server Error","extensions":{"code":"INTERNAL_SERVER_ERROR"}}`, false},
+		{"2", Development, true, cl1, false, `"id":"` + ID(cl1) + `"`, true},
+		{"3", Development, true, cl2, false, `"code":"NOT_IMPLEMENTED"`, true},
+		{"4", Production, true, cl1, false, `{"message":"Internal Server Error","extensions":{"code":"INTERNAL_SERVER_ERROR","id":"` + ID(cl1) + `"}}`, false},
+		{"5", Development, false, cl1, false, `{"message":"Internal Server Error","extensions":{"code":"INTERNAL_SERVER_ERROR","id":"` + ID(cl1) + `"}}`, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			UseProfile(tt.profile)
+			DefaultConfig().SetDebugPage(tt.debug)
+			got := ToGraphQLError(tt.err)
+			if (nil == got) != tt.wantNil {
+				t.Fatalf("%q: ToGraphQLError() = %v, wantNil %v", tt.name, got, tt.wantNil)
+			}
+			if tt.wantNil {
+				return
+			}
+			data, _ := json.Marshal(got)
+			if !strings.Contains(string(data), tt.wantJSON) {
+				t.Errorf("%q: ToGraphQLError() = %s, want %s", tt.name, data, tt.wantJSON)
+			}
+			_, hasLoc := got.Extensions["location"]
+			if hasLoc != tt.wantExtra {
+				t.Errorf("%q: ToGraphQLError() = %s, want location %v",
+					tt.name, data, tt.wantExtra)
+			}
+		})
+	}
+} // TestToGraphQLError()
+
+/* _EoF_ */
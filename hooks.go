@@ -0,0 +1,151 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tWrapHook` is a function registered by `RegisterWrapHook()`.
+	tWrapHook struct {
+		fn func(*ErrSource)
+	}
+
+	// `tFatalHook` is a function registered by `RegisterFatalHook()`.
+	tFatalHook struct {
+		fn func(error)
+	}
+)
+
+var (
+	// The registered wrap hooks.
+	wrapHooks []*tWrapHook
+
+	// The registered fatal hooks.
+	fatalHooks []*tFatalHook
+
+	// Guard for `wrapHooks` and `fatalHooks`.
+	hookMtx sync.RWMutex
+
+	// The number of registered wrap hooks (checked without locking).
+	wrapHookCount atomic.Int32
+)
+
+// `notifyWrapHooks()` passes the given error to all registered wrap
+// hooks.
+//
+// Parameters:
+// - `aSource`: The newly wrapped error.
+func notifyWrapHooks(aSource *ErrSource) {
+	if (nil == aSource) || (0 == wrapHookCount.Load()) {
+		return
+	}
+
+	hookMtx.RLock()
+	list := append([]*tWrapHook(nil), wrapHooks...)
+	hookMtx.RUnlock()
+
+	for _, hook := range list {
+		hook.fn(aSource)
+	}
+} // notifyWrapHooks()
+
+// `notifyFatalHooks()` passes the given error to all registered fatal
+// hooks.
+//
+// Parameters:
+// - `aErr`: The error passed to `Fatal()`.
+func notifyFatalHooks(aErr error) {
+	hookMtx.RLock()
+	list := append([]*tFatalHook(nil), fatalHooks...)
+	hookMtx.RUnlock()
+
+	for _, hook := range list {
+		hook.fn(aErr)
+	}
+} // notifyFatalHooks()
+
+// `RegisterFatalHook()` registers a function to be called by `Fatal()`
+// before it reports the error and terminates the program, e.g. to fail
+// a test instead (see the `sourceerrortest` package).
+//
+// Parameters:
+// - `aHook`: The function to receive the fatal error.
+//
+// Returns:
+// - `func()`: A function removing the registered hook.
+func RegisterFatalHook(aHook func(error)) func() {
+	if nil == aHook {
+		return func() {}
+	}
+	hook := &tFatalHook{fn: aHook}
+
+	hookMtx.Lock()
+	fatalHooks = append(fatalHooks, hook)
+	hookMtx.Unlock()
+
+	return func() {
+		hookMtx.Lock()
+		defer hookMtx.Unlock()
+
+		for idx, h := range fatalHooks {
+			if h == hook {
+				fatalHooks = append(fatalHooks[:idx:idx], fatalHooks[idx+1:]...)
+				break
+			}
+		}
+	}
+} // RegisterFatalHook()
+
+// `RegisterWrapHook()` registers a function to be called with each
+// error wrapped by this package, e.g. to log errors which might get
+// swallowed later on:
+//
+//	defer sourceerror.RegisterWrapHook(func(aErr *sourceerror.ErrSource) {
+//		log.Println(aErr.Detail())
+//	})()
+//
+// The hooks are called synchronously by the goroutine wrapping the
+// error, so they should be fast and must not modify the error.
+// They receive the completed error which isn't modified afterwards,
+// hence they may pass it on to other goroutines.
+//
+// Parameters:
+// - `aHook`: The function to receive the wrapped errors.
+//
+// Returns:
+// - `func()`: A function removing the registered hook.
+func RegisterWrapHook(aHook func(*ErrSource)) func() {
+	if nil == aHook {
+		return func() {}
+	}
+	hook := &tWrapHook{fn: aHook}
+
+	hookMtx.Lock()
+	wrapHooks = append(wrapHooks, hook)
+	wrapHookCount.Store(int32(len(wrapHooks)))
+	hookMtx.Unlock()
+
+	return func() {
+		hookMtx.Lock()
+		defer hookMtx.Unlock()
+
+		for idx, h := range wrapHooks {
+			if h == hook {
+				wrapHooks = append(wrapHooks[:idx:idx], wrapHooks[idx+1:]...)
+				break
+			}
+		}
+		wrapHookCount.Store(int32(len(wrapHooks)))
+	}
+} // RegisterWrapHook()
+
+/* _EoF_ */
@@ -0,0 +1,87 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestRegisterWrapHook(t *testing.T) {
+	var got []*ErrSource
+	remove := RegisterWrapHook(func(aErr *ErrSource) {
+		got = append(got, aErr)
+	})
+	defer RegisterWrapHook(nil)()
+
+	cl1 := Wrap(errors.New("some first error"), 0)
+	cl2 := WrapWith(cl1, 0, Raw)
+	if (2 != len(got)) || (got[0] != cl1) || (got[1] != cl2) {
+		t.Errorf("RegisterWrapHook() got %v, want [%v %v]", got, cl1, cl2)
+	}
+
+	remove()
+	_ = Wrap(errors.New("some second error"), 0)
+	if 2 != len(got) {
+		t.Errorf("RegisterWrapHook() got %d errors after removal, want 2", len(got))
+	}
+} // TestRegisterWrapHook()
+
+func TestRegisterWrapHook_complete(t *testing.T) {
+	var got []ErrSource
+	defer RegisterWrapHook(func(aErr *ErrSource) {
+		got = append(got, *aErr) // the state seen by the hook
+	})()
+
+	want := []error{
+		WithPhase(errors.New("some first error"), "startup"),
+		WrapTemplate(errors.New(`template: page:7: unexpected "}"`), "page"),
+		WrapSkip(errors.New("some second error"), 0, 0),
+	}
+	if len(want) != len(got) {
+		t.Fatalf("RegisterWrapHook() got %d errors, want %d", len(got), len(want))
+	}
+	for idx, err := range want {
+		if se := err.(*ErrSource); !reflect.DeepEqual(*se, got[idx]) {
+			t.Errorf("%d: RegisterWrapHook() got %v, want %v",
+				idx, got[idx].Detail(), se.Detail())
+		}
+	}
+} // TestRegisterWrapHook_complete()
+
+func TestRegisterFatalHook(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		got error
+	)
+	fatalWriter, osExit = &buf, func(int) {}
+	defer func() {
+		fatalWriter, osExit = os.Stderr, os.Exit
+		shuttingDown.Store(false)
+	}()
+
+	e := errors.New("some fatal error")
+	remove := RegisterFatalHook(func(aErr error) { got = aErr })
+	Fatal(e)
+	if got != e {
+		t.Errorf("RegisterFatalHook() got %v, want %v", got, e)
+	}
+
+	remove()
+	got = nil
+	Fatal(e)
+	if nil != got {
+		t.Errorf("RegisterFatalHook() got %v after removal, want <nil>", got)
+	}
+} // TestRegisterFatalHook()
+
+/* _EoF_ */
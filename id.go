@@ -0,0 +1,74 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// The encoding used for the instance IDs (sortable, lowercase).
+	idEncoding = base32.NewEncoding("0123456789abcdefghijklmnopqrstuv").
+			WithPadding(base32.NoPadding)
+
+	// Random bytes identifying the current process.
+	idProcess = func() (rBytes [5]byte) {
+		_, _ = rand.Read(rBytes[:])
+
+		return
+	}()
+
+	// Counter distinguishing the IDs created within the same second.
+	idCounter atomic.Uint32
+)
+
+// `newID()` returns a new compact unique error instance ID.
+//
+// The 12 bytes of the ID consist of the current Unix time (4 bytes),
+// a random process identifier (5 bytes), and a counter (3 bytes);
+// they are encoded as a 20 characters string which sorts by its
+// creation time.
+//
+// Returns:
+// - `string`: The new ID.
+func newID() string {
+	var id [12]byte
+
+	binary.BigEndian.PutUint32(id[0:4], uint32(time.Now().Unix()))
+	copy(id[4:9], idProcess[:])
+	cnt := idCounter.Add(1)
+	id[9], id[10], id[11] = byte(cnt>>16), byte(cnt>>8), byte(cnt)
+
+	return idEncoding.EncodeToString(id[:])
+} // newID()
+
+// `ID()` returns the instance ID of the outermost `ErrSource` in the
+// given error's chain.
+//
+// The ID can be shown to users (e.g. on an error page) so that support
+// teams can correlate a reported ID with the internally logged error.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `string`: The error's ID or an empty string if there is none.
+func ID(aErr error) string {
+	if se, ok := asSource(aErr); ok {
+		return se.ID
+	}
+
+	return ""
+} // ID()
+
+/* _EoF_ */
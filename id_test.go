@@ -0,0 +1,71 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestID(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	cl2 := fmt.Errorf("outer: %w", cl1)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"0", nil, ""},
+		{"1", e, ""},
+		{"2", cl1, cl1.(*ErrSource).ID},
+		{"3", cl2, cl1.(*ErrSource).ID},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ID(tt.err); got != tt.want {
+				t.Errorf("%q: ID() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestID()
+
+func Test_newID(t *testing.T) {
+	seen := make(map[string]bool)
+	last := ""
+	for i := 0; i < 10000; i++ {
+		id := newID()
+		if 20 != len(id) {
+			t.Fatalf("newID() = %q, want 20 characters", id)
+		}
+		if seen[id] {
+			t.Fatalf("newID() = %q, duplicate ID", id)
+		}
+		if id <= last {
+			t.Fatalf("newID() = %q, not sorted after %q", id, last)
+		}
+		seen[id], last = true, id
+	}
+} // Test_newID()
+
+func TestWrap_ID(t *testing.T) {
+	NODEBUG = true
+	defer func() {
+		NODEBUG = false
+	}()
+
+	if "" == ID(Wrap(nil, 0)) {
+		t.Error("Wrap() with NODEBUG: want non-empty ID")
+	}
+} // TestWrap_ID()
+
+/* _EoF_ */
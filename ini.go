@@ -0,0 +1,159 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `parseStrategy()` returns the frame strategy with the given name.
+//
+// Valid names are "raw", "firstnoninternal", "deferadjust", and
+// "module:<path>".
+//
+// Parameters:
+// - `aName`: The (case-insensitive) name of the strategy.
+//
+// Returns:
+// - `FrameStrategy`: The requested strategy.
+// - `bool`: `true` if the name is valid, `false` otherwise.
+func parseStrategy(aName string) (FrameStrategy, bool) {
+	aName = strings.TrimSpace(aName)
+	if module, ok := cutPrefixFold(aName, "module:"); ok {
+		if module = strings.TrimSpace(module); "" != module {
+			return FirstInModule(module), true
+		}
+		return nil, false
+	}
+
+	switch strings.ToLower(aName) {
+	case "raw":
+		return Raw, true
+	case "firstnoninternal":
+		return FirstNonInternal, true
+	case "deferadjust":
+		return DeferAdjust, true
+	}
+
+	return nil, false
+} // parseStrategy()
+
+// `cutPrefixFold()` works like `strings.CutPrefix()` but ignores case.
+//
+// Parameters:
+// - `aText`: The text to inspect.
+// - `aPrefix`: The prefix to remove.
+//
+// Returns:
+// - `string`: The text without prefix.
+// - `bool`: `true` if the prefix was found, `false` otherwise.
+func cutPrefixFold(aText, aPrefix string) (string, bool) {
+	if (len(aText) >= len(aPrefix)) &&
+		strings.EqualFold(aText[:len(aPrefix)], aPrefix) {
+		return aText[len(aPrefix):], true
+	}
+
+	return aText, false
+} // cutPrefixFold()
+
+// `FromINISection()` configures the package from the key/value pairs
+// of an INI file's section (e.g. `[sourceerror]`) as provided by the
+// host application.
+//
+// The supported (case-insensitive) keys are:
+// - `profile`: The name of a profile (see `ProfileByName()`) which is
+// applied first so that the other keys can override its settings.
+// - `nodebug`: A boolean value disabling the locations (see
+// `Config.SetEnabled()`).
+// - `nostack`: A boolean value disabling the call stacks (see
+// `Config.SetCaptureStack()`).
+// - `topframe`: The top frame strategy (see `Config.SetTopFrame()`): "raw", "firstnoninternal",
+// "deferadjust", or "module:<module path>".
+//
+// Invalid values and unknown keys are reported by the returned error
+// (as joined `*ConfigError`s) while all valid settings are applied
+// nevertheless.
+//
+// Parameters:
+// - `aSection`: The INI section's key/value pairs.
+//
+// Returns:
+// - `error`: A possible error describing all problems found.
+func FromINISection(aSection map[string]string) error {
+	var (
+		errs   []error
+		result = CurrentProfile()
+		keys   = make([]string, 0, len(aSection))
+		values = make(map[string]string, len(aSection))
+	)
+	for key, value := range aSection {
+		key = strings.ToLower(strings.TrimSpace(key))
+		keys = append(keys, key)
+		values[key] = strings.TrimSpace(value)
+	}
+	sort.Strings(keys)
+
+	if name, ok := values["profile"]; ok {
+		if profile, ok := ProfileByName(name); ok {
+			result = profile
+		} else {
+			errs = append(errs, &ConfigError{
+				Setting: "profile", Value: name, Reason: "unknown profile",
+			})
+		}
+	}
+
+	// the profile's own settings are kept, i.e. the deprecated
+	// `NODEBUG` and `NOSTACK` flags are not folded into them
+	for _, key := range keys {
+		value := values[key]
+		switch key {
+		case "profile":
+			// handled above
+
+		case "nodebug", "nostack":
+			flag, err := strconv.ParseBool(value)
+			if nil != err {
+				errs = append(errs, &ConfigError{
+					Setting: key, Value: value, Reason: "invalid boolean",
+				})
+				continue
+			}
+			if "nodebug" == key {
+				result.NoDebug = flag
+			} else {
+				result.NoStack = flag
+			}
+
+		case "topframe":
+			strategy, ok := parseStrategy(value)
+			if !ok {
+				errs = append(errs, &ConfigError{
+					Setting: key, Value: value, Reason: "unknown strategy",
+				})
+				continue
+			}
+			result.TopFrame = strategy
+
+		default:
+			errs = append(errs, &ConfigError{
+				Setting: key, Reason: "unknown key",
+			})
+		}
+	}
+
+	UseProfile(result)
+
+	return errors.Join(errs...)
+} // FromINISection()
+
+/* _EoF_ */
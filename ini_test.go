@@ -0,0 +1,100 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestFromINISection(t *testing.T) {
+	defer UseProfile(Development)
+
+	tests := []struct {
+		name        string
+		section     map[string]string
+		wantNoDebug bool
+		wantNoStack bool
+		wantProfile string
+		wantErr     string
+	}{
+		{"0", nil, false, false, Development.Name, ""},
+		{"1", map[string]string{"Profile": "production"}, true, true, Production.Name, ""},
+		{"2", map[string]string{"profile": "production", "NoDebug": "false"}, false, true, Production.Name, ""},
+		{"3", map[string]string{"nostack": "yes"}, false, false, Development.Name, `nostack: invalid boolean "yes"`},
+		{"4", map[string]string{"profile": "testing", "nostack": "1"}, false, true, Development.Name, `unknown profile "testing"`},
+		{"5", map[string]string{"topframe": "module:example.com/app"}, false, false, Development.Name, ""},
+		{"6", map[string]string{"topframe": "module: "}, false, false, Development.Name, "unknown strategy"},
+		{"7", map[string]string{"sinks": "stderr"}, false, false, Development.Name, "sinks: unknown key"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			UseProfile(Development)
+			err := FromINISection(tt.section)
+			if "" == tt.wantErr {
+				if nil != err {
+					t.Errorf("%q: FromINISection() error = %v", tt.name, err)
+				}
+			} else if (nil == err) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%q: FromINISection() error = %v, want %q",
+					tt.name, err, tt.wantErr)
+			}
+			config := DefaultConfig()
+			if (config.Enabled() == tt.wantNoDebug) || (config.CaptureStack() == tt.wantNoStack) {
+				t.Errorf("%q: FromINISection() Enabled, CaptureStack = %v, %v, want %v, %v",
+					tt.name, config.Enabled(), config.CaptureStack(), !tt.wantNoDebug, !tt.wantNoStack)
+			}
+			if got := CurrentProfile().Name; got != tt.wantProfile {
+				t.Errorf("%q: FromINISection() profile = %q, want %q",
+					tt.name, got, tt.wantProfile)
+			}
+		})
+	}
+
+	// the deprecated flags aren't folded into the profile
+	NODEBUG, NOSTACK = true, true
+	UseProfile(Development)
+	err := FromINISection(map[string]string{"topframe": "raw"})
+	NODEBUG, NOSTACK = false, false
+	if got := CurrentProfile(); (nil != err) || got.NoDebug || got.NoStack {
+		t.Errorf("FromINISection() = %+v, %v, want %+v, <nil>",
+			got, err, Development)
+	}
+	if config := DefaultConfig(); !config.Enabled() || !config.CaptureStack() {
+		t.Errorf("FromINISection() Enabled, CaptureStack = %v, %v, want true, true",
+			config.Enabled(), config.CaptureStack())
+	}
+} // TestFromINISection()
+
+func Test_parseStrategy(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		wantOK bool
+	}{
+		{"0", "", false},
+		{"1", "Raw", true},
+		{"2", " FirstNonInternal ", true},
+		{"3", "MODULE:example.com/app", true},
+		{"4", "module:", false},
+		{"5", "first", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := parseStrategy(tt.value); got != tt.wantOK {
+				t.Errorf("%q: parseStrategy() = %v, want %v",
+					tt.name, got, tt.wantOK)
+			}
+		})
+	}
+} // Test_parseStrategy()
+
+/* _EoF_ */
@@ -0,0 +1,106 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tIntentional` is an error representing a deliberate abort (see
+	// `MarkIntentional()`).
+	tIntentional struct {
+		err error
+	}
+)
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+// - `string`: The wrapped error's text.
+func (ie tIntentional) Error() string {
+	return ie.err.Error()
+} // Error()
+
+// `Intentional()` marks the error as a deliberate abort.
+//
+// Returns:
+// - `bool`: Always `true`.
+func (ie tIntentional) Intentional() bool {
+	return true
+} // Intentional()
+
+// `Unwrap()` returns the wrapped error.
+//
+// Returns:
+// - `error`: The wrapped error.
+func (ie tIntentional) Unwrap() error {
+	return ie.err
+} // Unwrap()
+
+// --------------------------------------------------------------------------
+
+// `IsIntentional()` reports whether the given error represents a
+// deliberate abort, so that alerting hooks and aggregators can exclude
+// it from the incident metrics:
+//
+//	sourceerror.RegisterWrapHook(func(aErr *sourceerror.ErrSource) {
+//		if !sourceerror.IsIntentional(aErr) {
+//			incidents.Inc()
+//		}
+//	})
+//
+// The first error in the chain providing an `Intentional() bool`
+// method (e.g. added by `MarkIntentional()`) determines the result.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `bool`: `true` if the error is a deliberate abort, `false` otherwise.
+func IsIntentional(aErr error) bool {
+	if nil == aErr {
+		return false
+	}
+
+	var marker interface{ Intentional() bool }
+	if errors.As(aErr, &marker) {
+		return marker.Intentional()
+	}
+
+	return false
+} // IsIntentional()
+
+// `MarkIntentional()` marks the given error as a deliberate abort,
+// e.g. due to a disabled feature flag or the maintenance mode, rather
+// than a failure (see `IsIntentional()`):
+//
+//	if maintenance.Load() {
+//		return sourceerror.Wrap(sourceerror.MarkIntentional(ErrMaintenance), 0)
+//	}
+//
+// NOTE: To let the hooks registered by `RegisterWrapHook()` see the
+// mark, the error must be marked before it's wrapped.
+// The mark survives the serialisation by `ErrSource.MarshalJSON()`
+// and is logged as the "intentional" field by `Attr()`.
+//
+// Parameters:
+// - `aErr`: The error to mark.
+//
+// Returns:
+// - `error`: The marked error, or `nil` if `aErr` is `nil`.
+func MarkIntentional(aErr error) error {
+	if (nil == aErr) || IsIntentional(aErr) {
+		return aErr
+	}
+
+	return tIntentional{err: aErr}
+} // MarkIntentional()
+
+/* _EoF_ */
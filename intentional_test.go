@@ -0,0 +1,102 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `tAbort` is a custom error type marking itself as intentional.
+type tAbort struct{}
+
+func (tAbort) Error() string     { return "feature disabled" }
+func (tAbort) Intentional() bool { return true }
+
+func TestIsIntentional(t *testing.T) {
+	e1 := errors.New("some first error")
+	e2 := MarkIntentional(e1)
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"0", nil, false},
+		{"1", e1, false},
+		{"2", e2, true},
+		{"3", Wrap(e2, 0), true},
+		{"4", MarkIntentional(Wrap(e1, 0)), true},
+		{"5", fmt.Errorf("checking: %w", e2), true},
+		{"6", errors.Join(e1, e2), true},
+		{"7", Wrap(tAbort{}, 0), true},
+		{"8", MarkIntentional(nil), false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsIntentional(tt.err); got != tt.want {
+				t.Errorf("%q: IsIntentional() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	if !errors.Is(e2, e1) || (e2.Error() != e1.Error()) {
+		t.Errorf("MarkIntentional() = %q, want a wrapper of %q", e2, e1)
+	}
+	if again := MarkIntentional(e2); again != e2 {
+		t.Errorf("MarkIntentional() = %#v, want unchanged %#v", again, e2)
+	}
+} // TestIsIntentional()
+
+func TestMarkIntentional(t *testing.T) {
+	e1 := errors.New("some first error")
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"0", Wrap(e1, 0), false},
+		{"1", Wrap(MarkIntentional(e1), 0), true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the mark survives the serialisation
+			data, err := json.Marshal(tt.err)
+			if nil != err {
+				t.Fatalf("%q: json.Marshal() error = %v", tt.name, err)
+			}
+			got, err := Decode(data)
+			if nil != err {
+				t.Fatalf("%q: Decode() error = %v", tt.name, err)
+			}
+			if IsIntentional(got) != tt.want {
+				t.Errorf("%q: IsIntentional(Decode(%s)) = %v, want %v",
+					tt.name, data, !tt.want, tt.want)
+			}
+
+			// and it's logged
+			logged := false
+			for _, attr := range Attr(tt.err).Value.Group() {
+				if "intentional" == attr.Key {
+					logged = attr.Value.Bool()
+				}
+			}
+			if logged != tt.want {
+				t.Errorf("%q: Attr() intentional = %v, want %v", tt.name, logged, tt.want)
+			}
+		})
+	}
+} // TestMarkIntentional()
+
+/* _EoF_ */
@@ -0,0 +1,156 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `jsonOffset()` returns the byte offset of a JSON decoding error in
+// the given error's chain.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `int64`: The number of bytes read before the error occurred.
+// - `bool`: `true` if an offset was found, `false` otherwise.
+func jsonOffset(aErr error) (int64, bool) {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(aErr, &syntaxErr):
+		return syntaxErr.Offset, true
+	case errors.As(aErr, &typeErr):
+		return typeErr.Offset, true
+	}
+
+	return 0, false
+} // jsonOffset()
+
+// `offsetPosition()` converts the offset of a JSON decoding error into
+// a line and column position.
+//
+// Parameters:
+// - `aInput`: The input data up to (at least) the offset.
+// - `aOffset`: The number of bytes read before the error occurred.
+//
+// Returns:
+// - `Position`: The position of the last byte read, with the line as
+// `Record` and the column counted in characters.
+func offsetPosition(aInput []byte, aOffset int64) Position {
+	idx := int(aOffset) - 1
+	if idx > len(aInput) {
+		idx = len(aInput)
+	}
+	if 0 > idx {
+		idx = 0
+	}
+	head := aInput[:idx]
+	lineStart := bytes.LastIndexByte(head, '\n') + 1
+
+	return Position{
+		Record: 1 + bytes.Count(head, []byte{'\n'}),
+		Column: 1 + utf8.RuneCount(head[lineStart:]),
+		Offset: aOffset,
+	}
+} // offsetPosition()
+
+// `withJSONPosition()` annotates the given error with its position
+// within the given JSON input.
+//
+// Parameters:
+// - `aErr`: The JSON decoding error.
+// - `aName`: The name of the input (e.g. its file name).
+// - `aPosition`: The error's position within the input.
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `withJSONPosition()`.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func withJSONPosition(aErr error, aName string, aPosition *Position, aSkip int) error {
+	return annotate(aErr, aSkip+1, func(aSource *ErrSource) {
+		if nil == aPosition {
+			return
+		}
+		aSource.Input = aPosition
+		aSource.External = &Location{
+			File: aName,
+			Line: aPosition.Record,
+		}
+	})
+} // withJSONPosition()
+
+// `WrapJSON()` wraps a JSON decoding error like `Wrap()` does and
+// converts the byte offset of a `*json.SyntaxError` or
+// `*json.UnmarshalTypeError` in its chain to a line and column within
+// the given input:
+//
+//	if err := json.Unmarshal(data, &config); nil != err {
+//		return sourceerror.WrapJSON(err, "config.json", data)
+//	}
+//
+// The line is attached as the error's `External` location and the
+// full position (see `Position`) as its `Input`.
+//
+// Parameters:
+// - `aErr`: The JSON decoding error.
+// - `aName`: The name of the input (e.g. its file name).
+// - `aInput`: The input that was decoded.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func WrapJSON(aErr error, aName string, aInput []byte) error {
+	var position *Position
+	if offset, ok := jsonOffset(aErr); ok {
+		p := offsetPosition(aInput, offset)
+		position = &p
+	}
+
+	return withJSONPosition(aErr, aName, position, 1)
+} // WrapJSON()
+
+// `WrapJSONFrom()` works like `WrapJSON()` but reads the input up to
+// the error's offset from the given source, e.g. the file decoded by a
+// `json.Decoder`.
+//
+// The source's read position is restored afterwards; if the input
+// can't be read the error is wrapped without position.
+//
+// Parameters:
+// - `aErr`: The JSON decoding error.
+// - `aName`: The name of the input (e.g. its file name).
+// - `aInput`: The input that was decoded.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func WrapJSONFrom(aErr error, aName string, aInput io.ReadSeeker) error {
+	var position *Position
+	if offset, ok := jsonOffset(aErr); ok && (nil != aInput) {
+		if current, err := aInput.Seek(0, io.SeekCurrent); nil == err {
+			if _, err = aInput.Seek(0, io.SeekStart); nil == err {
+				if head, err := io.ReadAll(io.LimitReader(aInput, offset)); nil == err {
+					p := offsetPosition(head, offset)
+					position = &p
+				}
+			}
+			_, _ = aInput.Seek(current, io.SeekStart)
+		}
+	}
+
+	return withJSONPosition(aErr, aName, position, 1)
+} // WrapJSONFrom()
+
+/* _EoF_ */
@@ -0,0 +1,101 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_offsetPosition(t *testing.T) {
+	input := []byte("{\n  \"a\": 1,\n  \"ä\": x\n}")
+
+	tests := []struct {
+		name   string
+		offset int64
+		want   Position
+	}{
+		{"0", 0, Position{Record: 1, Column: 1}},
+		{"1", 1, Position{Record: 1, Column: 1, Offset: 1}},
+		{"2", 3, Position{Record: 2, Column: 1, Offset: 3}},
+		{"3", 20, Position{Record: 3, Column: 7, Offset: 20}},
+		{"4", 99, Position{Record: 4, Column: 2, Offset: 99}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := offsetPosition(input, tt.offset); got != tt.want {
+				t.Errorf("%q: offsetPosition() = %+v, want %+v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_offsetPosition()
+
+func TestWrapJSON(t *testing.T) {
+	var v struct{ N int }
+	bad := []byte("{\n  \"N\": 1,\n}")
+	syntaxErr := json.Unmarshal(bad, &v)
+	typed := []byte("{\n\n  \"N\": \"x\"}")
+	typeErr := json.Unmarshal(typed, &v)
+
+	tests := []struct {
+		name     string
+		err      error
+		input    []byte
+		wantLine int
+		wantCol  int
+	}{
+		{"0", syntaxErr, bad, 3, 1},
+		{"1", typeErr, typed, 3, 10},
+		{"2", errors.New("not JSON"), nil, 0, 0},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for idx, err := range []error{
+				WrapJSON(tt.err, "input.json", tt.input),
+				WrapJSONFrom(tt.err, "input.json", strings.NewReader(string(tt.input))),
+			} {
+				se := err.(*ErrSource)
+				if 0 == tt.wantLine {
+					if (nil != se.External) || (nil != se.Input) {
+						t.Errorf("%q/%d: WrapJSON() = %v, %v, want no position",
+							tt.name, idx, se.External, se.Input)
+					}
+					continue
+				}
+				if (nil == se.External) || (nil == se.Input) {
+					t.Errorf("%q/%d: WrapJSON() has no position", tt.name, idx)
+					continue
+				}
+				if ("input.json" != se.External.File) || (tt.wantLine != se.External.Line) {
+					t.Errorf("%q/%d: WrapJSON() external = %v, want input.json:%d",
+						tt.name, idx, se.External, tt.wantLine)
+				}
+				if tt.wantCol != se.Input.Column {
+					t.Errorf("%q/%d: WrapJSON() column = %d, want %d",
+						tt.name, idx, se.Input.Column, tt.wantCol)
+				}
+			}
+		})
+	}
+
+	r := strings.NewReader(string(bad))
+	_, _ = r.Seek(5, io.SeekStart)
+	_ = WrapJSONFrom(syntaxErr, "input.json", r)
+	if pos, _ := r.Seek(0, io.SeekCurrent); 5 != pos {
+		t.Errorf("WrapJSONFrom() read position = %d, want 5", pos)
+	}
+} // TestWrapJSON()
+
+/* _EoF_ */
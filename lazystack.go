@@ -0,0 +1,60 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"slices"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `tLazyStack` holds the call stack of an error captured with
+// `Config.SetLazyStack(true)` once it's resolved.
+//
+// It's shared by all copies of an error (see `WithPhase()` etc.), so
+// that the program counters are resolved at most once.
+type tLazyStack struct {
+	once   sync.Once
+	frames []Frame // the resolved frames, starting with the location
+	text   []byte  // the frames in the format of `debug.Stack()`
+}
+
+// `resolve()` symbolises the given error's program counters on the
+// first call.
+//
+// Parameters:
+// - `aSource`: The error owning the lazy stack.
+func (ls *tLazyStack) resolve(aSource *ErrSource) {
+	ls.once.Do(func() {
+		ls.frames = slices.Collect(pcFrameSeq(aSource.pcs, aSource.Function))
+		if 0 < len(ls.frames) {
+			ls.text = restoredStack(ls.frames)
+		}
+	})
+} // resolve()
+
+// `StackTrace()` returns the error's call stack in the format used by
+// `debug.Stack()`.
+//
+// For errors captured with `Config.SetLazyStack(true)` the call stack
+// is resolved from the program counters on the first call (of this
+// method or of `String()`, `Detail()`, `Frames()` etc.); otherwise it's
+// the `Stack` field.
+//
+// Returns:
+// - `[]byte`: The error's call stack, or `nil` if there is none.
+func (se ErrSource) StackTrace() []byte {
+	if (nil == se.lazy) || (0 < len(se.Stack)) {
+		return se.Stack
+	}
+	se.lazy.resolve(&se)
+
+	return se.lazy.text
+} // StackTrace()
+
+/* _EoF_ */
@@ -0,0 +1,92 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestErrSource_StackTrace(t *testing.T) {
+	lazy := &Config{}
+	lazy.SetLazyStack(true)
+	noStack := &Config{}
+	noStack.SetLazyStack(true)
+	noStack.SetCaptureStack(false)
+
+	tests := []struct {
+		name      string
+		config    *Config
+		wantLazy  bool
+		wantStack bool
+	}{
+		{"0", &Config{}, false, true},
+		{"1", lazy, true, true},
+		{"2", noStack, false, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			se := NewOpts(errors.New("some first error"),
+				WithConfig(tt.config)).(*ErrSource)
+			if got := (0 == len(se.Stack)) && (nil != se.lazy); got != tt.wantLazy {
+				t.Fatalf("%q: NewOpts() lazy = %v, want %v", tt.name, got, tt.wantLazy)
+			}
+
+			got := se.StackTrace()
+			if (0 < len(got)) != tt.wantStack {
+				t.Fatalf("%q: ErrSource.StackTrace() = %q, want a stack: %v",
+					tt.name, got, tt.wantStack)
+			}
+			if !tt.wantStack {
+				return
+			}
+			if !bytes.Contains(got, []byte(se.Function)) {
+				t.Errorf("%q: ErrSource.StackTrace() =\n%s\nwant %q", tt.name, got, se.Function)
+			}
+			if !strings.Contains(se.String(), string(got)) {
+				t.Errorf("%q: ErrSource.String() =\n%s\nwant the stack\n%s", tt.name, se, got)
+			}
+
+			frames := se.Frames()
+			if (0 == len(frames)) || (frames[0].Function != se.Function) ||
+				(frames[0].Line != se.Line) {
+				t.Fatalf("%q: ErrSource.Frames() = %v, want %s first",
+					tt.name, frames, se.Location())
+			}
+			if !tt.wantLazy {
+				return
+			}
+			// the copies share the resolved stack
+			annotated := WithPhase(se, "startup").(*ErrSource)
+			if again := annotated.StackTrace(); !bytes.Equal(again, got) {
+				t.Errorf("%q: ErrSource.StackTrace() =\n%s\nwant\n%s", tt.name, again, got)
+			}
+			if again := se.Frames(); !reflect.DeepEqual(again, frames) {
+				t.Errorf("%q: ErrSource.Frames() = %v, want %v", tt.name, again, frames)
+			}
+		})
+	}
+} // TestErrSource_StackTrace()
+
+func BenchmarkLazyStack(b *testing.B) {
+	lazy := &Config{}
+	lazy.SetLazyStack(true)
+	err := errors.New("some first error")
+	for i := 0; i < b.N; i++ {
+		if nil == NewOpts(err, WithConfig(lazy)) {
+			b.Fatal("NewOpts() = nil")
+		}
+	}
+} // BenchmarkLazyStack()
+
+/* _EoF_ */
@@ -0,0 +1,33 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestWrap_lineDirective(t *testing.T) {
+	se := lineDirectiveError().(*ErrSource)
+	if ("parser.y" != filepath.Base(se.File)) || (43 != se.Line) {
+		t.Errorf("Wrap() location = %s:%d, want %s:%d",
+			se.File, se.Line, "parser.y", 43)
+	}
+} // TestWrap_lineDirective()
+
+// NOTE: This function must remain the last one of this file since the
+// `//line` directive affects all following lines.
+func lineDirectiveError() error {
+//line parser.y:42
+	_ = 0
+	return Wrap(errors.New("generated code failed"), 0)
+} // lineDirectiveError()
+
+/* _EoF_ */
@@ -0,0 +1,124 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"fmt"
+	"runtime"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Location` is a snapshot of a source code position.
+//
+// The fields are as follows:
+// - `File`: The source file of the location.
+// - `Function`: The function wherein the location lies.
+// - `Line`: The code line within the `File`.
+type Location struct {
+	File     string `json:"file,omitempty"`
+	Function string `json:"function,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// `String()` implements the `Stringer` interface and returns the
+// location in the common "file:line" notation.
+//
+// Locations within a dependency module are rendered with the
+// dependency's version, e.g. "github.com/foo/bar@v1.2.3/x.go:10".
+//
+// Returns:
+// - `string`: The location's string representation.
+func (loc Location) String() string {
+	return fmt.Sprintf("%s:%d",
+		moduleFile(loc.File, loc.Function, dependencyVersions()), loc.Line)
+} // String()
+
+// --------------------------------------------------------------------------
+
+// `Caller()` returns the location of a function on the calling
+// goroutine's stack, e.g. for audit logs or metrics labels.
+//
+// The location is determined the same way as the location of errors
+// wrapped by `Wrap()` with the `Raw` strategy, i.e. inlined functions
+// and `//line` directives are taken into account.
+//
+// Parameters:
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `Caller()`.
+//
+// Returns:
+// - `Location`: The requested location or an empty location if
+// there's no such stack frame.
+func Caller(aSkip int) Location {
+	pc, file, line, ok := runtime.Caller(aSkip + 1)
+	if !ok {
+		return Location{}
+	}
+
+	return Location{
+		File:     file,
+		Function: demangle(runtime.FuncForPC(pc).Name()),
+		Line:     line,
+	}
+} // Caller()
+
+// `Precompute()` returns the location of its caller.
+//
+// The result is meant to be stored in a (package level) variable and
+// later used with `WithLocation()` so that extremely hot code paths
+// don't have to pay for the `runtime` investigation each time an error
+// has to be wrapped:
+//
+//	var loadFailed = sourceerror.Precompute()
+//
+//	// ...
+//	if nil != err {
+//		return sourceerror.WithLocation(err, loadFailed)
+//	}
+//
+// Returns:
+// - `Location`: The location of the code calling this function.
+func Precompute() Location {
+	return Caller(1)
+} // Precompute()
+
+// `WithLocation()` wraps the given error with the given location
+// without any `runtime` investigation; the call stack isn't captured.
+//
+// NOTE: If locations are disabled (see `Config.SetEnabled()`), this
+// function returns an instance with the given `aErr`, while file,
+// function, and line number fields remain empty.
+// Like the other wrapping functions it assigns the error's ID and
+// sequence number and passes the new instance to the hooks registered
+// by `RegisterWrapHook()`.
+//
+// Parameters:
+// - `aErr`: The error to be wrapped.
+// - `aLocation`: The location to use for the error.
+//
+// Returns:
+// - `error`: A new `ErrSource` instance that contains `aErr`, as well
+// as the given location.
+func WithLocation(aErr error, aLocation Location) error {
+	result := newInstance(aErr)
+	if defaultConfig.noDebug() {
+		result.omitStack(ReasonNoDebug)
+	} else {
+		result.File = aLocation.File
+		result.Function = aLocation.Function
+		result.Line = aLocation.Line
+		auditLocation(aLocation)
+		result.Foreign = foreignStacks(aErr)
+		result.omitStack(ReasonPrecomputed)
+	}
+	notifyWrapHooks(result)
+
+	return result
+} // WithLocation()
+
+/* _EoF_ */
@@ -0,0 +1,128 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// location precomputed during package initialisation
+	testPrecomputed = Precompute()
+)
+
+func TestCaller(t *testing.T) {
+	helper := func() Location {
+		return Caller(1)
+	}
+	tests := []struct {
+		name     string
+		loc      Location
+		wantFunc string
+	}{
+		{"0", Caller(0), thisPackage + ".TestCaller"},
+		{"1", helper(), thisPackage + ".TestCaller"},
+		{"2", Caller(1), "testing.tRunner"},
+		{"3", Caller(1000), ""},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.loc.Function != tt.wantFunc {
+				t.Errorf("%q: Caller() function = %q, want %q",
+					tt.name, tt.loc.Function, tt.wantFunc)
+			}
+			if ("" != tt.wantFunc) && (0 >= tt.loc.Line) {
+				t.Errorf("%q: Caller() line = %d, want > 0",
+					tt.name, tt.loc.Line)
+			}
+		})
+	}
+} // TestCaller()
+
+func TestPrecompute(t *testing.T) {
+	loc := Precompute()
+	tests := []struct {
+		name     string
+		loc      Location
+		wantFunc string
+	}{
+		{"1", testPrecomputed, thisPackage + ".init"},
+		{"2", loc, thisPackage + ".TestPrecompute"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.HasSuffix(tt.loc.File, "location_test.go") {
+				t.Errorf("%q: Precompute() file = %q, want %q",
+					tt.name, tt.loc.File, "location_test.go")
+			}
+			if 0 >= tt.loc.Line {
+				t.Errorf("%q: Precompute() line = %d, want > 0",
+					tt.name, tt.loc.Line)
+			}
+			if tt.loc.Function != tt.wantFunc {
+				t.Errorf("%q: Precompute() function = %q, want %q",
+					tt.name, tt.loc.Function, tt.wantFunc)
+			}
+		})
+	}
+} // TestPrecompute()
+
+func TestWithLocation(t *testing.T) {
+	e := errors.New("some first error")
+	loc := Location{"file.go", "pkg.Func", 42}
+
+	tests := []struct {
+		name    string
+		nodebug bool
+		want    Location
+	}{
+		{"1", false, loc},
+		{"2", true, Location{}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			NODEBUG = tt.nodebug
+			SetSequencing(true)
+			var hooked *ErrSource
+			remove := RegisterWrapHook(func(aErr *ErrSource) {
+				hooked = aErr
+			})
+			defer func() {
+				NODEBUG = false
+				SetSequencing(false)
+				remove()
+			}()
+
+			se := WithLocation(e, loc).(*ErrSource)
+			if (hooked != se) || (0 == se.Seq) {
+				t.Errorf("%q: WithLocation() hooked = %v, seq = %d, want %v",
+					tt.name, hooked, se.Seq, se)
+			}
+			got := Location{se.File, se.Function, se.Line}
+			if got != tt.want {
+				t.Errorf("%q: WithLocation() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if nil != se.Stack {
+				t.Errorf("%q: WithLocation() stack = %q, want <nil>",
+					tt.name, se.Stack)
+			}
+			if !errors.Is(se, e) {
+				t.Errorf("%q: WithLocation() lost wrapped error", tt.name)
+			}
+		})
+	}
+} // TestWithLocation()
+
+/* _EoF_ */
@@ -0,0 +1,60 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `MarshalLog()` implements the `logr.Marshaler` interface, so that
+// loggers of the `logr` ecosystem (e.g. in Kubernetes controllers) log
+// the error's fields instead of its message only:
+//
+//	logger.Error(err, "reconcile failed", "source", se)
+//
+// The fields are the same as those of the attribute returned by
+// `Attr()`, e.g. "msg", "id", "file", "line", and "function".
+//
+// Returns:
+// - `any`: The error's fields as a `map[string]any`.
+func (se ErrSource) MarshalLog() any {
+	attrs := Attr(&se).Value.Group()
+	result := make(map[string]any, len(attrs))
+	for _, attr := range attrs {
+		result[attr.Key] = attr.Value.Any()
+	}
+
+	return result
+} // MarshalLog()
+
+// --------------------------------------------------------------------------
+
+// `LogValuer()` returns a function that can be converted to a go-kit
+// `log.Valuer` to log the fields of the given error:
+//
+//	logger = log.With(logger, "error", log.Valuer(sourceerror.LogValuer(err)))
+//
+// The function returns the fields of the outermost `ErrSource` in the
+// error's chain (see `ErrSource.MarshalLog()`) or, if there's none,
+// the error's message.
+//
+// Parameters:
+// - `aErr`: The error to log.
+//
+// Returns:
+// - `func() any`: The function providing the error's fields.
+func LogValuer(aErr error) func() any {
+	return func() any {
+		if nil == aErr {
+			return nil
+		}
+		if se, ok := asSource(aErr); ok {
+			return se.MarshalLog()
+		}
+		return aErr.Error()
+	}
+} // LogValuer()
+
+/* _EoF_ */
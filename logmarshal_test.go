@@ -0,0 +1,76 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestErrSource_MarshalLog(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0).(*ErrSource)
+	cl2 := &ErrSource{err: e, Elapsed: time.Second}
+
+	tests := []struct {
+		name string
+		se   *ErrSource
+		want map[string]any
+	}{
+		{"0", cl1, map[string]any{
+			"msg": "some first error", "id": cl1.ID, "file": cl1.File,
+			"line": int64(cl1.Line), "function": cl1.Function,
+		}},
+		{"1", cl2, map[string]any{
+			"msg": "some first error", "elapsed": time.Second,
+		}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The interface of `logr.Marshaler`.
+			marshaler, ok := any(tt.se).(interface{ MarshalLog() any })
+			if !ok {
+				t.Fatalf("%q: *ErrSource doesn't implement MarshalLog()", tt.name)
+			}
+			if got := marshaler.MarshalLog(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: ErrSource.MarshalLog() =\n%v\nwant\n%v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestErrSource_MarshalLog()
+
+func TestLogValuer(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0).(*ErrSource)
+
+	tests := []struct {
+		name string
+		err  error
+		want any
+	}{
+		{"0", nil, nil},
+		{"1", e, "some first error"},
+		{"2", fmt.Errorf("outer: %w", cl1), cl1.MarshalLog()},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LogValuer(tt.err)(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: LogValuer() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestLogValuer()
+
+/* _EoF_ */
@@ -0,0 +1,73 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The goroutine header of call stacks restored from their frames.
+	restoredHeader = "goroutine 0 [unknown]:\n"
+)
+
+type (
+	// `tJSON` is the JSON representation of an `ErrSource`, i.e. its
+	// serialisable record with the call stack as a list of frames.
+	tJSON struct {
+		*tRecord
+		Stack []Frame `json:"stack,omitempty"`
+	}
+)
+
+// `MarshalJSON()` implements the `json.Marshaler` interface.
+//
+// The resulting object holds the fields "message", "file", "line",
+// "function", and "id", the call stack as a list of frames ("stack",
+// without the frames' program counters) as well as the error's other
+// metadata (if any), e.g.
+//
+//	{"v":1,"id":"…","message":"connection refused","file":"/src/repo.go",
+//	 "line":88,"function":"example.com/app.(*Repo).Save",
+//	 "stack":[{"file":"/src/repo.go","function":"…","line":88}, …]}
+//
+// Returns:
+// - `[]byte`: The error's JSON representation.
+// - `error`: A possible marshalling error.
+func (se ErrSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tJSON{
+		tRecord: newMetaRecord(&se),
+		Stack:   se.Frames(),
+	})
+} // MarshalJSON()
+
+// `UnmarshalJSON()` implements the `json.Unmarshaler` interface and
+// restores an error marshalled by `MarshalJSON()` (or any other JSON
+// form accepted by `Decode()`).
+//
+// NOTE: The wrapped error is restored as a plain error with the
+// original error's message only, and the call stack is restored in
+// the format of `debug.Stack()` without the functions' arguments.
+//
+// Parameters:
+// - `aData`: The JSON representation to restore.
+//
+// Returns:
+// - `error`: A possible unmarshalling error.
+func (se *ErrSource) UnmarshalJSON(aData []byte) error {
+	record, err := upgradeJSON(aData)
+	if nil != err {
+		return err
+	}
+	*se = *record.source()
+
+	return nil
+} // UnmarshalJSON()
+
+/* _EoF_ */
@@ -0,0 +1,78 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestErrSource_MarshalJSON(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0).(*ErrSource)
+	cl2 := WithPhase(cl1, PhaseShutdown).(*ErrSource)
+
+	tests := []struct {
+		name     string
+		err      *ErrSource
+		wantJSON string
+	}{
+		{"0", cl1, `"message":"some first error"`},
+		{"1", cl1, `"stack":[{"file":"`},
+		{"2", cl2, `"phase":"shutdown"`},
+		{"3", &ErrSource{err: e}, `{"v":1,"message":"some first error"}`},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.err)
+			if nil != err {
+				t.Fatalf("%q: MarshalJSON() error = %v", tt.name, err)
+			}
+			if !strings.Contains(string(data), tt.wantJSON) {
+				t.Errorf("%q: MarshalJSON() = %s, want %s", tt.name, data, tt.wantJSON)
+			}
+			if strings.Contains(string(data), `"pc":`) {
+				t.Errorf("%q: MarshalJSON() = %s, want no program counters",
+					tt.name, data)
+			}
+
+			var got ErrSource
+			if err := json.Unmarshal(data, &got); nil != err {
+				t.Fatalf("%q: UnmarshalJSON() error = %v", tt.name, err)
+			}
+			if (got.Error() != tt.err.Error()) || (got.ID != tt.err.ID) ||
+				(got.Location() != tt.err.Location()) || (got.Phase != tt.err.Phase) {
+				t.Errorf("%q: UnmarshalJSON() =\n%s\nwant\n%s",
+					tt.name, got.Detail(), tt.err.Detail())
+			}
+			// the restored frames lack the program counters
+			locations := func(aFrames []Frame) (rLocs []Location) {
+				for _, frame := range aFrames {
+					rLocs = append(rLocs, frame.Location())
+				}
+				return
+			}
+			if want := locations(tt.err.Frames()); !reflect.DeepEqual(locations(got.Frames()), want) {
+				t.Errorf("%q: UnmarshalJSON() frames = %v, want %v",
+					tt.name, got.Frames(), want)
+			}
+		})
+	}
+
+	var got ErrSource
+	if err := json.Unmarshal([]byte(`{"stack":42}`), &got); nil == err {
+		t.Errorf("UnmarshalJSON() = %v, want error", got)
+	}
+} // TestErrSource_MarshalJSON()
+
+/* _EoF_ */
@@ -0,0 +1,108 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"slices"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Merge()` combines two errors into one, e.g. when an operation fails
+// and its cleanup fails as well.
+//
+// The result wraps `errors.Join(aPrimary, aSecondary)` so that both
+// errors can be found by `errors.Is()` and `errors.As()`. Its location
+// is the one of the primary error's outermost `ErrSource` (or the
+// caller's location if there is none) while the call stacks of both
+// errors are kept, the primary one first. All other data of the primary
+// error (e.g. its phase, elapsed time, input position and test) is
+// kept as well, while the merged error gets a new ID and sequence
+// number. Like all new errors it's passed to the hooks registered by
+// `RegisterWrapHook()`.
+//
+// Parameters:
+// - `aPrimary`: The main error.
+// - `aSecondary`: The additional error.
+//
+// Returns:
+// - `error`: The merged error, or the other error if one of the two
+// is `nil`.
+func Merge(aPrimary, aSecondary error) error {
+	return merge(aPrimary, aSecondary, 1)
+} // Merge()
+
+// `merge()` implements `Merge()`.
+//
+// Parameters:
+// - `aPrimary`: The main error.
+// - `aSecondary`: The additional error.
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `merge()`.
+//
+// Returns:
+// - `error`: The merged error.
+func merge(aPrimary, aSecondary error, aSkip int) error {
+	if nil == aSecondary {
+		return aPrimary
+	}
+	if nil == aPrimary {
+		return aSecondary
+	}
+
+	joined := errors.Join(aPrimary, aSecondary)
+	primary, ok := asSource(aPrimary)
+	if !ok || ("" == primary.File) {
+		primary = buildSource(joined, 0, aSkip+1, defaultConfig.TopFrame())
+		mergeSecondary(primary, aSecondary)
+		notifyWrapHooks(primary)
+
+		return primary
+	}
+
+	// a copy keeping all of the primary error's data
+	result := *primary
+	result.err, result.ID, result.Seq = joined, newID(), nextSeq()
+	if ("" == result.Phase) && ShuttingDown() {
+		result.Phase = PhaseShutdown
+	}
+	countCreated()
+	mergeSecondary(&result, aSecondary)
+	notifyWrapHooks(&result)
+
+	return &result
+} // merge()
+
+// `mergeSecondary()` adds the call stacks of the given secondary error
+// to the given (not yet published) primary error.
+//
+// Parameters:
+// - `aPrimary`: The error to complete.
+// - `aSecondary`: The additional error.
+func mergeSecondary(aPrimary *ErrSource, aSecondary error) {
+	secondary, ok := asSource(aSecondary)
+	if !ok {
+		return
+	}
+
+	if secondaryStack := secondary.StackTrace(); 0 < len(secondaryStack) {
+		primaryStack := aPrimary.StackTrace()
+		stack := make([]byte, 0, len(primaryStack)+1+len(secondaryStack))
+		if 0 < len(primaryStack) {
+			stack = append(append(stack, primaryStack...), '\n')
+		}
+		aPrimary.Stack = append(stack, secondaryStack...)
+		aPrimary.StackOmitted, aPrimary.OmitReason = false, ""
+		// the program counters cover the primary stack only
+		aPrimary.pcs, aPrimary.lazy = nil, nil
+	}
+	aPrimary.Foreign = append(slices.Clip(aPrimary.Foreign),
+		secondary.Foreign...)
+} // mergeSecondary()
+
+/* _EoF_ */
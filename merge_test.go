@@ -0,0 +1,95 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestMerge(t *testing.T) {
+	e1 := errors.New("write failed")
+	e2 := errors.New("close failed")
+	cl1 := Wrap(e1, 0).(*ErrSource)
+	cl2 := Wrap(e2, 0).(*ErrSource)
+
+	tests := []struct {
+		name       string
+		primary    error
+		secondary  error
+		wantSame   error
+		wantLine   int
+		wantStacks int
+	}{
+		{"0", nil, nil, nil, 0, 0},
+		{"1", e1, nil, e1, 0, 0},
+		{"2", nil, e2, e2, 0, 0},
+		{"3", cl1, cl2, nil, cl1.Line, 2},
+		{"4", cl1, e2, nil, cl1.Line, 1},
+		{"5", e1, cl2, nil, -1, 2},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Merge(tt.primary, tt.secondary)
+			if (nil != tt.wantSame) || ((nil == tt.primary) && (nil == tt.secondary)) {
+				if got != tt.wantSame {
+					t.Errorf("%q: Merge() = %v, want %v", tt.name, got, tt.wantSame)
+				}
+				return
+			}
+			se := got.(*ErrSource)
+			if !errors.Is(se, e1) || !errors.Is(se, e2) {
+				t.Errorf("%q: Merge() lost one of the errors", tt.name)
+			}
+			if (0 <= tt.wantLine) && (se.Line != tt.wantLine) {
+				t.Errorf("%q: Merge() line = %d, want %d",
+					tt.name, se.Line, tt.wantLine)
+			}
+			if !strings.HasSuffix(se.Function, "TestMerge") &&
+				!strings.HasSuffix(se.Function, "TestMerge.func1") {
+				t.Errorf("%q: Merge() function = %q", tt.name, se.Function)
+			}
+			if got := len(regexp.MustCompile(`(?m)^goroutine \d+ \[`).FindAllIndex(se.Stack, -1)); got != tt.wantStacks {
+				t.Errorf("%q: Merge() stacks = %d, want %d",
+					tt.name, got, tt.wantStacks)
+			}
+			if se.ID == cl1.ID {
+				t.Errorf("%q: Merge() didn't assign a new ID", tt.name)
+			}
+		})
+	}
+
+	// the primary error's data is kept and the hooks see the result
+	rich := *cl1
+	rich.Phase, rich.Elapsed, rich.Test = "startup", time.Second, "TestMerge"
+	rich.Input = &Position{Record: 3, Column: 7}
+	SetSequencing(true)
+	defer SetSequencing(false)
+	var hooked []*ErrSource
+	defer RegisterWrapHook(func(aErr *ErrSource) {
+		hooked = append(hooked, aErr)
+	})()
+	se := Merge(MarkIntentional(&rich), cl2).(*ErrSource)
+	if (se.Phase != rich.Phase) || (se.Elapsed != rich.Elapsed) ||
+		(se.Test != rich.Test) || (se.Input != rich.Input) {
+		t.Errorf("Merge() =\n%s\nwant the data of\n%s", se.Detail(), rich.Detail())
+	}
+	if !IsIntentional(se) || (0 == se.Seq) {
+		t.Errorf("Merge() intentional = %v, seq = %d", IsIntentional(se), se.Seq)
+	}
+	if (1 != len(hooked)) || (hooked[0] != se) {
+		t.Errorf("Merge() hooks got %v, want [%v]", hooked, se)
+	}
+} // TestMerge()
+
+/* _EoF_ */
@@ -0,0 +1,112 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// The dependency modules' versions indexed by module path.
+	depVersions map[string]string
+
+	// Guard to read the build info only once.
+	depVersionsOnce sync.Once
+)
+
+// `dependencyVersions()` returns the versions of the program's
+// dependency modules (as recorded in the binary's build info).
+//
+// Returns:
+// - `map[string]string`: The module versions indexed by module path.
+func dependencyVersions() map[string]string {
+	depVersionsOnce.Do(func() {
+		depVersions = make(map[string]string)
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, dep := range info.Deps {
+			if nil != dep.Replace {
+				dep = dep.Replace
+			}
+			if "" != dep.Version {
+				depVersions[dep.Path] = dep.Version
+			}
+		}
+	})
+
+	return depVersions
+} // dependencyVersions()
+
+// `moduleFile()` returns the given source file in the notation
+// "module@version/dir/file.go" if the given function belongs to one
+// of the given dependency modules.
+//
+// Parameters:
+// - `aFile`: The source file's path.
+// - `aFunction`: The (fully qualified) function name.
+// - `aModules`: The dependency modules' versions indexed by module path.
+//
+// Returns:
+// - `string`: The annotated file name or `aFile` if the function
+// doesn't belong to a dependency.
+func moduleFile(aFile, aFunction string, aModules map[string]string) string {
+	if (0 == len(aModules)) || ("" == aFunction) {
+		return aFile
+	}
+
+	pkg := funcPackage(aFunction)
+	module, version := "", ""
+	for mPath, mVersion := range aModules {
+		if (len(mPath) > len(module)) &&
+			((pkg == mPath) || strings.HasPrefix(pkg, mPath+"/")) {
+			module, version = mPath, mVersion
+		}
+	}
+	if "" == module {
+		return aFile
+	}
+
+	return path.Join(module+"@"+version,
+		strings.TrimPrefix(pkg, module),
+		filepath.Base(filepath.ToSlash(aFile)))
+} // moduleFile()
+
+// `String()` implements the `Stringer` interface and returns the frame
+// in the common "file:line" notation.
+//
+// Frames belonging to a dependency module are rendered with the
+// dependency's version, e.g. "github.com/foo/bar@v1.2.3/x.go:10".
+//
+// Returns:
+// - `string`: The frame's string representation.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s:%d",
+		moduleFile(f.File, f.Function, dependencyVersions()), f.Line)
+} // String()
+
+// `Location()` returns the frame's source code position.
+//
+// Returns:
+// - `Location`: The frame's location.
+func (f Frame) Location() Location {
+	return Location{
+		File:     f.File,
+		Function: f.Function,
+		Line:     f.Line,
+	}
+} // Location()
+
+/* _EoF_ */
@@ -0,0 +1,60 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_moduleFile(t *testing.T) {
+	modules := map[string]string{
+		"github.com/foo/bar":     "v1.2.3",
+		"github.com/foo/bar/sub": "v0.1.0",
+		"golang.org/x/text":      "v0.14.0",
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		function string
+		modules  map[string]string
+		want     string
+	}{
+		{"0", "/src/app/main.go", "main.main", nil, "/src/app/main.go"},
+		{"1", "/src/app/main.go", "main.main", modules, "/src/app/main.go"},
+		{"2", "/mod/github.com/foo/bar@v1.2.3/x.go", "github.com/foo/bar.X",
+			modules, "github.com/foo/bar@v1.2.3/x.go"},
+		{"3", "/vendor/github.com/foo/bar/pkg/y.go", "github.com/foo/bar/pkg.(*T).Y",
+			modules, "github.com/foo/bar@v1.2.3/pkg/y.go"},
+		{"4", "/mod/github.com/foo/bar/sub@v0.1.0/z.go", "github.com/foo/bar/sub.Z",
+			modules, "github.com/foo/bar/sub@v0.1.0/z.go"},
+		{"5", "/go/src/fmt/print.go", "fmt.Fprintf", modules, "/go/src/fmt/print.go"},
+		{"6", "template", "", modules, "template"},
+		{"7", "/mod/github.com/foo/barbaz/b.go", "github.com/foo/barbaz.B",
+			modules, "/mod/github.com/foo/barbaz/b.go"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moduleFile(tt.file, tt.function, tt.modules); got != tt.want {
+				t.Errorf("%q: moduleFile() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_moduleFile()
+
+func TestFrame_String(t *testing.T) {
+	f := Frame{"/src/app/main.go", "main.main", 12, 0}
+	if got, want := f.String(), "/src/app/main.go:12"; got != want {
+		t.Errorf("Frame.String() = %q, want %q", got, want)
+	}
+} // TestFrame_String()
+
+/* _EoF_ */
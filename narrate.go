@@ -0,0 +1,137 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"strings"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `tClause` is a part of an error's narration.
+type tClause struct {
+	text    string        // the text the error adds to its chain
+	loc     Location      // where the error was encountered
+	elapsed time.Duration // the duration of the failed operation
+}
+
+// `String()` implements the `Stringer` interface.
+//
+// Returns:
+// - `string`: The clause with its location and duration (if any).
+func (c tClause) String() string {
+	result := c.text
+	if 0 < c.elapsed {
+		result += " after " + c.elapsed.String()
+	}
+	if "" != c.loc.File {
+		result += " at " + c.loc.String()
+	}
+
+	return result
+} // String()
+
+// --------------------------------------------------------------------------
+
+// `failed()` appends "failed" to the given text unless it already
+// tells so.
+//
+// Parameters:
+// - `aText`: The text describing an operation.
+//
+// Returns:
+// - `string`: The text describing the operation's failure.
+func failed(aText string) string {
+	lower := strings.ToLower(aText)
+	if strings.Contains(lower, "fail") || strings.Contains(lower, "error") {
+		return aText
+	}
+
+	return aText + " failed"
+} // failed()
+
+// `Narrate()` returns a prose-like summary of the given error's chain
+// for incident tickets or administration pages, e.g.
+//
+//	saving user failed because db connection failed after 5s at
+//	repo.go:88, caused by dial tcp: i/o timeout
+//
+// Each error of the chain contributes the text it adds to the message
+// of the error it wraps, followed by the duration (see `WithDuration()`)
+// and location of an `ErrSource` layer wrapping it. The chain's root
+// error is named as the cause.
+//
+// Parameters:
+// - `aErr`: The error to narrate.
+//
+// Returns:
+// - `string`: The error's narration or an empty string for `nil`.
+func Narrate(aErr error) string {
+	if nil == aErr {
+		return ""
+	}
+
+	var (
+		clauses []tClause
+		cause   *tClause
+		pending tClause // the annotations of the `ErrSource` layers
+	)
+	_ = walkChain(aErr, func(aLink error) bool {
+		var se *ErrSource
+		switch link := aLink.(type) {
+		case *ErrSource:
+			se = link
+		case ErrSource:
+			se = &link
+		}
+		if nil != se {
+			if "" != se.File {
+				pending.loc = se.Location()
+			}
+			if (0 < se.Elapsed) && (0 == pending.elapsed) {
+				pending.elapsed = se.Elapsed
+			}
+			return true
+		}
+
+		text, inner := aLink.Error(), unwrap(aLink)
+		if nil == inner {
+			pending.text = text
+			cause = &pending
+			return false
+		}
+		if innerText := inner.Error(); strings.HasSuffix(text, innerText) {
+			text = strings.TrimRight(text[:len(text)-len(innerText)], ": -\t\n")
+		}
+		if "" == text {
+			// a mere container adding no text
+			return true
+		}
+		pending.text = failed(text)
+		clauses = append(clauses, pending)
+		pending = tClause{}
+
+		return true
+	})
+
+	parts := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		parts = append(parts, clause.String())
+	}
+	result := strings.Join(parts, " because ")
+	if nil != cause {
+		if "" == result {
+			return cause.String()
+		}
+		result += ", caused by " + cause.String()
+	}
+
+	return result
+} // Narrate()
+
+/* _EoF_ */
@@ -0,0 +1,73 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_failed(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"0", "saving user", "saving user failed"},
+		{"1", "failed to save user", "failed to save user"},
+		{"2", "DB Error", "DB Error"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := failed(tt.text); got != tt.want {
+				t.Errorf("%q: failed() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_failed()
+
+func TestNarrate(t *testing.T) {
+	root := errors.New("dial tcp: i/o timeout")
+	se := &ErrSource{
+		err:      fmt.Errorf("db connection: %w", root),
+		File:     "repo.go",
+		Function: "repo.Save",
+		Line:     88,
+		Elapsed:  5 * time.Second,
+	}
+	inner := &ErrSource{err: root, File: "db.go", Line: 12}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"0", nil, ""},
+		{"1", root, "dial tcp: i/o timeout"},
+		{"2", fmt.Errorf("saving user: %w", se),
+			"saving user failed because db connection failed after 5s at repo.go:88, caused by dial tcp: i/o timeout"},
+		{"3", fmt.Errorf("saving user: %w", inner),
+			"saving user failed, caused by dial tcp: i/o timeout at db.go:12"},
+		{"4", fmt.Errorf("failed to load: %w", root),
+			"failed to load, caused by dial tcp: i/o timeout"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Narrate(tt.err); got != tt.want {
+				t.Errorf("%q: Narrate() = %q,\nwant %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestNarrate()
+
+/* _EoF_ */
@@ -0,0 +1,268 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `Option` configures an error created by `NewOpts()`.
+	Option func(*tOptions)
+
+	// `tOptions` are the settings of an error created by `NewOpts()`.
+	tOptions struct {
+		lines   int     // number of lines to subtract
+		skip    int     // number of frames to skip
+		noStack bool    // whether to omit the call stack
+		message string  // the text to prefix the error with
+		config  *Config // the configuration to use
+
+		problems []*ConfigError // the misused options
+	}
+)
+
+const (
+	// The max. number of distinct option problems kept for
+	// `ValidateConfig()`.
+	maxOptionProblems = 16
+)
+
+var (
+	// The distinct option problems found by `NewOpts()` so far.
+	optionProblems []*ConfigError
+
+	// Guard for `optionProblems`.
+	optionMtx sync.Mutex
+)
+
+// `problem()` records a misused option.
+//
+// Parameters:
+// - `aSetting`: The name of the misused option, e.g. "WithSkip".
+// - `aValue`: The offending value (if any).
+// - `aReason`: Why the option is invalid.
+func (o *tOptions) problem(aSetting, aValue, aReason string) {
+	o.problems = append(o.problems, &ConfigError{
+		Setting: aSetting,
+		Value:   aValue,
+		Reason:  aReason,
+	})
+} // problem()
+
+// `WithConfig()` uses the given configuration instead of
+// `DefaultConfig()`, e.g. to capture the call stacks of a certain
+// subsystem's errors only.
+//
+// Parameters:
+// - `aConfig`: The configuration to capture the error's data with.
+//
+// Returns:
+// - `Option`: The option to pass to `NewOpts()`.
+func WithConfig(aConfig *Config) Option {
+	return func(aOpts *tOptions) {
+		if nil == aConfig {
+			aOpts.problem("WithConfig", "", "missing configuration")
+		}
+		aOpts.config = aConfig
+	}
+} // WithConfig()
+
+// `WithLineOffset()` subtracts the given number of lines from the
+// error's line number (see `Wrap()`).
+//
+// Parameters:
+// - `aLines`: The number of lines to subtract; a negative number is
+// reported as `*ConfigError` (see `Diagnostics()`) and ignored.
+//
+// Returns:
+// - `Option`: The option to pass to `NewOpts()`.
+func WithLineOffset(aLines int) Option {
+	return func(aOpts *tOptions) {
+		if 0 > aLines {
+			aOpts.problem("WithLineOffset", strconv.Itoa(aLines), "negative line offset")
+			aOpts.lines = 0
+			return
+		}
+		aOpts.lines = aLines
+	}
+} // WithLineOffset()
+
+// `WithMessage()` prefixes the wrapped error's text with the given
+// message, e.g. "loading config: no such file"; if `NewOpts()` is
+// called without an error, the message becomes the new error's text.
+//
+// Parameters:
+// - `aMessage`: The message to add.
+//
+// Returns:
+// - `Option`: The option to pass to `NewOpts()`.
+func WithMessage(aMessage string) Option {
+	return func(aOpts *tOptions) {
+		aOpts.message = aMessage
+	}
+} // WithMessage()
+
+// `WithNoStack()` omits the error's call stack (like
+// `Config.SetCaptureStack(false)` does for all errors), e.g. for
+// expected errors on a hot path.
+//
+// Returns:
+// - `Option`: The option to pass to `NewOpts()`.
+func WithNoStack() Option {
+	return func(aOpts *tOptions) {
+		aOpts.noStack = true
+	}
+} // WithNoStack()
+
+// `WithSkip()` skips the given number of frames both when selecting
+// the error's location and when storing the call stack (see
+// `WrapSkip()`).
+//
+// Parameters:
+// - `aSkip`: The number of frames to skip with `0` identifying the
+// caller of `NewOpts()`; a negative number is reported as
+// `*ConfigError` (see `Diagnostics()`) and ignored.
+//
+// Returns:
+// - `Option`: The option to pass to `NewOpts()`.
+func WithSkip(aSkip int) Option {
+	return func(aOpts *tOptions) {
+		if 0 > aSkip {
+			aOpts.problem("WithSkip", strconv.Itoa(aSkip), "negative frame count")
+			aOpts.skip = 0
+			return
+		}
+		aOpts.skip = aSkip
+	}
+} // WithSkip()
+
+// --------------------------------------------------------------------------
+
+// `optionProblemList()` returns the distinct option problems found by
+// `NewOpts()` so far.
+//
+// Returns:
+// - `[]error`: The `*ConfigError`s describing the misused options.
+func optionProblemList() []error {
+	optionMtx.Lock()
+	defer optionMtx.Unlock()
+
+	result := make([]error, 0, len(optionProblems))
+	for _, problem := range optionProblems {
+		result = append(result, problem)
+	}
+
+	return result
+} // optionProblemList()
+
+// `recordOptionProblems()` keeps the given option problems for
+// `ValidateConfig()`, ignoring those already known.
+//
+// Parameters:
+// - `aProblems`: The problems found by `NewOpts()`.
+func recordOptionProblems(aProblems []*ConfigError) {
+	if 0 == len(aProblems) {
+		return
+	}
+	optionMtx.Lock()
+	defer optionMtx.Unlock()
+
+	for _, problem := range aProblems {
+		if maxOptionProblems <= len(optionProblems) {
+			return
+		}
+		if !slices.ContainsFunc(optionProblems, func(aKnown *ConfigError) bool {
+			return *aKnown == *problem
+		}) {
+			optionProblems = append(optionProblems, problem)
+		}
+	}
+} // recordOptionProblems()
+
+// `NewOpts()` works like `Wrap()` but is configured by the given
+// options instead of positional arguments:
+//
+//	if err := load(name); nil != err {
+//		return sourceerror.NewOpts(err,
+//			sourceerror.WithMessage("loading "+name),
+//			sourceerror.WithSkip(1))
+//	}
+//
+// Like with `WrapSkip()` the call stack of the returned error starts
+// with the frame reported as the error's location.
+//
+// Misused options (e.g. a negative skip) don't fail the call, since
+// the error must be reported anyway; they're ignored instead and
+// reported as `*ConfigError`s by `Diagnostics()` and `ValidateConfig()`.
+//
+// Parameters:
+// - `aErr`: The error to be wrapped (may be `nil` if a message is given).
+// - `aOpts`: The options configuring the new error.
+//
+// Returns:
+// - `error`: A new `ErrSource` instance, or `nil` if neither an error
+// nor a message is given.
+func NewOpts(aErr error, aOpts ...Option) error {
+	var opts tOptions
+	for idx, opt := range aOpts {
+		if nil == opt {
+			opts.problem("NewOpts", strconv.Itoa(idx+1), "missing option")
+			continue
+		}
+		opt(&opts)
+	}
+	if "" != opts.message {
+		if nil == aErr {
+			aErr = errors.New(opts.message)
+		} else {
+			aErr = fmt.Errorf("%s: %w", opts.message, aErr)
+		}
+	}
+	if nil == aErr {
+		return nil
+	}
+
+	config := opts.config
+	if nil == config {
+		config = &defaultConfig
+	}
+	if opts.noStack && config.CaptureStack() {
+		derived := &Config{}
+		derived.SetEnabled(config.Enabled())
+		derived.SetCaptureStack(false)
+		derived.SetLazyStack(config.LazyStack())
+		derived.SetTopFrame(config.TopFrame())
+		config = derived
+	}
+
+	recordOptionProblems(opts.problems)
+
+	result := captureSource(aErr, opts.lines, opts.skip+1, config.TopFrame(), config)
+	if 0 < len(result.Stack) {
+		// skip `debug.Stack()`, `captureSource()`, and `NewOpts()`
+		result.Stack = trimStack(result.Stack, opts.skip+3)
+	}
+	if 0 < len(opts.problems) {
+		problems := make([]error, 0, len(opts.problems))
+		for _, problem := range opts.problems {
+			problems = append(problems, problem)
+		}
+		result.diagnostics = errors.Join(problems...)
+	}
+	notifyWrapHooks(result)
+
+	return result
+} // NewOpts()
+
+/* _EoF_ */
@@ -0,0 +1,92 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `testLoad()` is a helper wrapping errors at its caller's location.
+func testLoad(aErr error) error {
+	return NewOpts(aErr, WithMessage("loading"), WithSkip(1))
+} // testLoad()
+
+func TestNewOpts(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	e1 := errors.New("some first error")
+
+	tests := []struct {
+		name        string
+		err         error
+		opts        []Option
+		wantMsg     string
+		wantLine    int
+		wantOmitted bool
+	}{
+		{"0", nil, nil, "", 0, false},
+		{"1", e1, nil, "some first error", line + 23, false},
+		{"2", e1, []Option{WithLineOffset(2), nil}, "some first error", line + 21, false},
+		{"3", e1, []Option{WithLineOffset(-2)}, "some first error", line + 23, false},
+		{"4", e1, []Option{WithMessage("reading")}, "reading: some first error", line + 23, false},
+		{"5", nil, []Option{WithMessage("some second error")}, "some second error", line + 23, false},
+		{"6", e1, []Option{WithNoStack()}, "some first error", line + 23, true},
+		{"7", e1, []Option{WithSkip(-1)}, "some first error", line + 23, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewOpts(tt.err, tt.opts...)
+			if nil == err {
+				if "" != tt.wantMsg {
+					t.Errorf("%q: NewOpts() = <nil>, want %q", tt.name, tt.wantMsg)
+				}
+				return
+			}
+			se := err.(*ErrSource)
+			if (se.Error() != tt.wantMsg) || (se.Line != tt.wantLine) {
+				t.Errorf("%q: NewOpts() = %q at line %d, want %q at line %d",
+					tt.name, se.Error(), se.Line, tt.wantMsg, tt.wantLine)
+			}
+			if (se.StackOmitted != tt.wantOmitted) || ((0 == len(se.Stack)) != tt.wantOmitted) {
+				t.Errorf("%q: NewOpts() StackOmitted = %v, want %v",
+					tt.name, se.StackOmitted, tt.wantOmitted)
+			}
+			if tt.wantOmitted {
+				return
+			}
+			if got := se.Frames(); (0 == len(got)) || (got[0].Function != se.Function) {
+				t.Errorf("%q: NewOpts() stack = %v, want it to start with %q",
+					tt.name, got, se.Function)
+			}
+		})
+	}
+
+	var disabled Config
+	disabled.SetEnabled(false)
+	if se := NewOpts(e1, WithConfig(&disabled)).(*ErrSource); ("" != se.File) || (ReasonNoDebug != se.OmitReason) {
+		t.Errorf("NewOpts() = %s (%s), want no location", se.Location(), se.OmitReason)
+	}
+
+	_, _, line, _ = runtime.Caller(0)
+	se := testLoad(e1).(*ErrSource)
+	if (se.Line != line+1) || (se.Function != thisPackage+".TestNewOpts") {
+		t.Errorf("NewOpts() = %s:%d, want %s:%d",
+			se.Function, se.Line, thisPackage+".TestNewOpts", line+1)
+	}
+	if !errors.Is(se, e1) || ("loading: some first error" != se.Error()) {
+		t.Errorf("NewOpts() = %q, want a wrapper of %q", se, e1)
+	}
+	if got := se.Frames(); (0 == len(got)) || (got[0].Function != se.Function) {
+		t.Errorf("NewOpts() stack = %v, want it to start with %q", got, se.Function)
+	}
+} // TestNewOpts()
+
+/* _EoF_ */
@@ -0,0 +1,203 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tOwnerRule` is a single line of a CODEOWNERS-like file.
+	tOwnerRule struct {
+		pattern []string // the pattern's path components
+		dirOnly bool     // whether the pattern ends with a slash
+		owners  string   // the owners, separated by blanks
+	}
+)
+
+var (
+	// The rules loaded by `LoadOwners()`.
+	ownerRules []tOwnerRule
+
+	// Guard for concurrent access to `ownerRules`.
+	ownerMtx sync.RWMutex
+)
+
+// `match()` checks whether the rule matches the given path.
+//
+// Since the repository's root directory isn't known at runtime all
+// patterns (even those starting with a slash) may match at any
+// directory level of the given path.
+//
+// Parameters:
+// - `aPath`: The slash separated path components of a source file.
+//
+// Returns:
+// - `bool`: `true` if the rule matches, `false` otherwise.
+func (r tOwnerRule) match(aPath []string) bool {
+	pLen := len(r.pattern)
+	last := r.pattern[pLen-1]
+	for start := 0; start+pLen <= len(aPath); start++ {
+		matched := true
+		for idx, pat := range r.pattern {
+			if ok, _ := path.Match(pat, aPath[start+idx]); !ok {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if start+pLen == len(aPath) {
+			// the pattern matched the file itself
+			if !r.dirOnly {
+				return true
+			}
+		} else if (1 == pLen) || !strings.Contains(last, "*") {
+			// the pattern matched a directory containing the file
+			return true
+		}
+	}
+
+	return false
+} // match()
+
+// --------------------------------------------------------------------------
+
+// `LoadOwners()` reads CODEOWNERS-like rules from the given reader,
+// replacing any previously loaded rules.
+//
+// Each line consists of a path pattern followed by one or more owners
+// (e.g. teams or mail addresses); empty lines and lines starting with
+// "#" are ignored. As with CODEOWNERS the last matching rule wins:
+//
+//	# the default owners first
+//	*                 @org/core
+//	*.sql             @org/dba
+//	internal/billing/ @org/billing
+//
+// Parameters:
+// - `aReader`: The source to read the rules from.
+//
+// Returns:
+// - `error`: A possible error during reading or parsing.
+func LoadOwners(aReader io.Reader) error {
+	var (
+		errs  []error
+		rules []tOwnerRule
+	)
+
+	scanner := bufio.NewScanner(aReader)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if ("" == line) || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if 2 > len(fields) {
+			errs = append(errs, fmt.Errorf("line %d: no owner for pattern %q",
+				lineNo, fields[0]))
+			continue
+		}
+		pattern := strings.Trim(fields[0], "/")
+		if "" == pattern {
+			pattern = "*"
+		}
+		if _, err := path.Match(pattern, ""); nil != err {
+			errs = append(errs, fmt.Errorf("line %d: pattern %q: %w",
+				lineNo, fields[0], err))
+			continue
+		}
+		rules = append(rules, tOwnerRule{
+			pattern: strings.Split(pattern, "/"),
+			dirOnly: strings.HasSuffix(fields[0], "/"),
+			owners:  strings.Join(fields[1:], " "),
+		})
+	}
+	if err := scanner.Err(); nil != err {
+		errs = append(errs, err)
+	}
+	if 0 < len(errs) {
+		return errors.Join(errs...)
+	}
+
+	ownerMtx.Lock()
+	ownerRules = rules
+	ownerMtx.Unlock()
+
+	return nil
+} // LoadOwners()
+
+// `Owner()` returns the owners of the code where the given error was
+// encountered, according to the rules loaded by `LoadOwners()`.
+//
+// The error's location is checked first; if no rule matches it, the
+// frames of the error's call stack are checked from the top, so that
+// errors wrapped in shared code are attributed to the code's caller.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `string`: The (blank separated) owners, or an empty string if
+// no rule matches.
+func Owner(aErr error) string {
+	se, ok := asSource(aErr)
+	if !ok {
+		return ""
+	}
+
+	ownerMtx.RLock()
+	defer ownerMtx.RUnlock()
+	if 0 == len(ownerRules) {
+		return ""
+	}
+
+	if "" != se.File {
+		if owners := ownerOf(se.File); "" != owners {
+			return owners
+		}
+	}
+	for _, frame := range se.Frames() {
+		if owners := ownerOf(frame.File); "" != owners {
+			return owners
+		}
+	}
+
+	return ""
+} // Owner()
+
+// `ownerOf()` returns the owners of the given source file.
+//
+// NOTE: The caller must hold the `ownerMtx` lock.
+//
+// Parameters:
+// - `aFile`: The source file's path.
+//
+// Returns:
+// - `string`: The owners of the last matching rule or an empty string.
+func ownerOf(aFile string) string {
+	comps := strings.Split(strings.Trim(filepath.ToSlash(aFile), "/"), "/")
+	for idx := len(ownerRules) - 1; 0 <= idx; idx-- {
+		if ownerRules[idx].match(comps) {
+			return ownerRules[idx].owners
+		}
+	}
+
+	return ""
+} // ownerOf()
+
+/* _EoF_ */
@@ -0,0 +1,80 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestLoadOwners(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr bool
+	}{
+		{"0", "", false},
+		{"1", "# comment\n\n* @core\n", false},
+		{"2", "*.go\n", true},
+		{"3", "[ @core\n", true},
+		{"4", "/internal/ @a @b\ndocs/* x@example.com\n", false},
+		// TODO: Add test cases.
+	}
+	defer LoadOwners(strings.NewReader(""))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoadOwners(strings.NewReader(tt.rules))
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: LoadOwners() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+		})
+	}
+} // TestLoadOwners()
+
+func TestOwner(t *testing.T) {
+	const rules = `
+*                  @core
+*.sql              @dba
+internal/billing/  @billing
+/docs/*            @writers
+owners_test.go     @tests @qa
+`
+	if err := LoadOwners(strings.NewReader(rules)); nil != err {
+		t.Fatalf("LoadOwners() = %v", err)
+	}
+	defer LoadOwners(strings.NewReader(""))
+
+	e := errors.New("some first error")
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"0", nil, ""},
+		{"1", e, ""},
+		{"2", Wrap(e, 0), "@tests @qa"},
+		{"3", WithLocation(e, Location{"/src/app/q.sql", "app.Query", 1}), "@dba"},
+		{"4", WithLocation(e, Location{"/src/internal/billing/x/a.go", "b.A", 1}), "@billing"},
+		{"5", WithLocation(e, Location{"/src/docs/a.md", "d.A", 1}), "@writers"},
+		{"6", WithLocation(e, Location{"/src/docs/x/a.md", "d.A", 1}), "@core"},
+		{"7", WithLocation(e, Location{"/src/billing/a.go", "b.A", 1}), "@core"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Owner(tt.err); got != tt.want {
+				t.Errorf("%q: Owner() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestOwner()
+
+/* _EoF_ */
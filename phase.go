@@ -0,0 +1,140 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The phase of errors occurring while the program shuts down.
+	PhaseShutdown = "shutdown"
+)
+
+var (
+	// Whether `RunShutdownHooks()` was called.
+	shuttingDown atomic.Bool
+
+	// The registered shutdown controllers.
+	shutdownControllers []*tShutdownController
+
+	// Guard for `shutdownControllers`.
+	controllerMtx sync.RWMutex
+)
+
+type (
+	// `tShutdownController` is a function registered by
+	// `RegisterShutdownController()`.
+	tShutdownController struct {
+		stopping func() bool
+	}
+)
+
+// `RegisterShutdownController()` registers a function telling whether
+// the program is stopping, e.g. because the application's signal
+// handler started a graceful shutdown:
+//
+//	var stopping atomic.Bool
+//	defer sourceerror.RegisterShutdownController(stopping.Load)()
+//	// ...
+//	stopping.Store(true)
+//	_ = server.Shutdown(ctx)
+//
+// While any controller reports `true`, all new errors are stamped with
+// the `PhaseShutdown` phase, so that error triage can discount errors
+// that are expected side effects of the shutdown.
+//
+// Parameters:
+// - `aStopping`: The function telling whether the program is stopping.
+//
+// Returns:
+// - `func()`: A function removing the registered controller.
+func RegisterShutdownController(aStopping func() bool) func() {
+	if nil == aStopping {
+		return func() {}
+	}
+	sc := &tShutdownController{
+		stopping: aStopping,
+	}
+
+	controllerMtx.Lock()
+	shutdownControllers = append(shutdownControllers, sc)
+	controllerMtx.Unlock()
+
+	return func() {
+		controllerMtx.Lock()
+		defer controllerMtx.Unlock()
+
+		for idx, c := range shutdownControllers {
+			if c == sc {
+				shutdownControllers = append(shutdownControllers[:idx:idx], shutdownControllers[idx+1:]...)
+				break
+			}
+		}
+	}
+} // RegisterShutdownController()
+
+// `ShuttingDown()` tells whether the program is shutting down, i.e.
+// whether `RunShutdownHooks()` was called or a controller registered by
+// `RegisterShutdownController()` reports that the program is stopping.
+//
+// Returns:
+// - `bool`: `true` if the program is shutting down.
+func ShuttingDown() bool {
+	if shuttingDown.Load() {
+		return true
+	}
+
+	controllerMtx.RLock()
+	defer controllerMtx.RUnlock()
+	for _, sc := range shutdownControllers {
+		if sc.stopping() {
+			return true
+		}
+	}
+
+	return false
+} // ShuttingDown()
+
+// `WithPhase()` returns the given error annotated with the given phase
+// of the program (e.g. "startup", "migration", or `PhaseShutdown`).
+//
+// If `aErr` is an `ErrSource` a copy of it is annotated; otherwise
+// `aErr` is wrapped like by `Wrap()` first.
+//
+// Parameters:
+// - `aErr`: The error to annotate.
+// - `aPhase`: The program's phase the error occurred in.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func WithPhase(aErr error, aPhase string) error {
+	return annotate(aErr, 1, func(aSource *ErrSource) {
+		aSource.Phase = aPhase
+	})
+} // WithPhase()
+
+// `Phase()` returns the phase of the outermost `ErrSource` in the
+// given error's chain.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `string`: The error's phase or an empty string.
+func Phase(aErr error) string {
+	if se, ok := asSource(aErr); ok {
+		return se.Phase
+	}
+
+	return ""
+} // Phase()
+
+/* _EoF_ */
@@ -0,0 +1,85 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestWithPhase(t *testing.T) {
+	e := errors.New("some first error")
+	se := Wrap(e, 0).(*ErrSource)
+
+	tests := []struct {
+		name  string
+		err   error
+		phase string
+		want  string
+	}{
+		{"0", nil, "startup", ""},
+		{"1", e, "startup", "startup"},
+		{"2", se, PhaseShutdown, PhaseShutdown},
+		{"3", *se, "migration", "migration"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WithPhase(tt.err, tt.phase)
+			if nil == tt.err {
+				if nil != got {
+					t.Errorf("%q: WithPhase() = %v, want <nil>", tt.name, got)
+				}
+				return
+			}
+			if phase := Phase(got); phase != tt.want {
+				t.Errorf("%q: Phase() = %q, want %q", tt.name, phase, tt.want)
+			}
+			if !errors.Is(got, e) {
+				t.Errorf("%q: WithPhase() lost the wrapped error", tt.name)
+			}
+			if !strings.Contains(got.(*ErrSource).Detail(), "\nPhase: "+tt.want) {
+				t.Errorf("%q: Detail() lacks the phase", tt.name)
+			}
+		})
+	}
+	if "" != se.Phase {
+		t.Errorf("WithPhase() modified the original error: %q", se.Phase)
+	}
+} // TestWithPhase()
+
+func TestRegisterShutdownController(t *testing.T) {
+	var stopping atomic.Bool
+	remove := RegisterShutdownController(stopping.Load)
+	defer RegisterShutdownController(nil)()
+
+	if ShuttingDown() {
+		t.Error("ShuttingDown() = true, want false")
+	}
+	if got := Phase(Wrap(errors.New("running"), 0)); "" != got {
+		t.Errorf("Phase() = %q, want empty", got)
+	}
+
+	stopping.Store(true)
+	if !ShuttingDown() {
+		t.Error("ShuttingDown() = false, want true")
+	}
+	if got := Phase(Wrap(errors.New("stopping"), 0)); PhaseShutdown != got {
+		t.Errorf("Phase() = %q, want %q", got, PhaseShutdown)
+	}
+
+	remove()
+	if ShuttingDown() {
+		t.Error("ShuttingDown() = true after removal, want false")
+	}
+} // TestRegisterShutdownController()
+
+/* _EoF_ */
@@ -0,0 +1,116 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Position` describes where in the processed input data (as opposed
+// to the source code) an error occurred.
+//
+// The fields are as follows:
+// - `Record`: The record (or line) number, starting with `1`.
+// - `Column`: The column (or field) number, starting with `1`.
+// - `Offset`: The byte offset within the input, starting with `1`.
+//
+// Unknown values are zero.
+type Position struct {
+	Record int   `json:"record,omitempty"`
+	Column int   `json:"column,omitempty"`
+	Offset int64 `json:"offset,omitempty"`
+}
+
+// `String()` implements the `Stringer` interface.
+//
+// Returns:
+// - `string`: The position, e.g. "record 3, column 7".
+func (p Position) String() string {
+	var parts []string
+	if 0 < p.Record {
+		parts = append(parts, fmt.Sprintf("record %d", p.Record))
+	}
+	if 0 < p.Column {
+		parts = append(parts, fmt.Sprintf("column %d", p.Column))
+	}
+	if 0 < p.Offset {
+		parts = append(parts, fmt.Sprintf("offset %d", p.Offset))
+	}
+	if 0 == len(parts) {
+		return "unknown position"
+	}
+
+	return strings.Join(parts, ", ")
+} // String()
+
+// --------------------------------------------------------------------------
+
+// `parsePosition()` extracts the input position from the parse errors
+// of the standard library in the given error's chain.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `Position`: The extracted position.
+// - `bool`: `true` if a position was found, `false` otherwise.
+func parsePosition(aErr error) (Position, bool) {
+	var csvErr *csv.ParseError
+	if errors.As(aErr, &csvErr) {
+		return Position{Record: csvErr.Line, Column: csvErr.Column}, true
+	}
+	if offset, ok := jsonOffset(aErr); ok {
+		return Position{Offset: offset}, true
+	}
+
+	return Position{}, false
+} // parsePosition()
+
+// `WrapParse()` returns the given error annotated with the position
+// in the input data where it occurred, in addition to the location in
+// the code as by `Wrap()`:
+//
+//	for record := 1; scanner.Scan(); record++ {
+//		if err := process(scanner.Text()); nil != err {
+//			return sourceerror.WrapParse(err, record, 0)
+//		}
+//	}
+//
+// If neither record nor column are given, the position is taken from
+// a `*csv.ParseError`, `*json.SyntaxError`, or `*json.UnmarshalTypeError`
+// in the error's chain (if any); see `WrapJSON()` for converting JSON
+// offsets to lines and columns.
+//
+// If `aErr` is an `ErrSource` a copy of it is annotated; otherwise
+// `aErr` is wrapped like by `Wrap()` first.
+//
+// Parameters:
+// - `aErr`: The error to annotate.
+// - `aRecord`: The record (or line) number, starting with `1`.
+// - `aColumn`: The column (or field) number, starting with `1`.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func WrapParse(aErr error, aRecord, aColumn int) error {
+	position := Position{Record: aRecord, Column: aColumn}
+	if (0 >= aRecord) && (0 >= aColumn) {
+		position, _ = parsePosition(aErr)
+	}
+
+	return annotate(aErr, 1, func(aSource *ErrSource) {
+		if (Position{}) != position {
+			aSource.Input = &position
+		}
+	})
+} // WrapParse()
+
+/* _EoF_ */
@@ -0,0 +1,92 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestPosition_String(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  Position
+		want string
+	}{
+		{"0", Position{}, "unknown position"},
+		{"1", Position{Record: 3}, "record 3"},
+		{"2", Position{Record: 3, Column: 7}, "record 3, column 7"},
+		{"3", Position{Offset: 42}, "offset 42"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pos.String(); got != tt.want {
+				t.Errorf("%q: Position.String() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestPosition_String()
+
+func TestWrapParse(t *testing.T) {
+	_, csvErr := csv.NewReader(strings.NewReader("a,b\nc,\"d\n")).ReadAll()
+	var v struct{ N int }
+	syntaxErr := json.Unmarshal([]byte(`{"N": 1,}`), &v)
+	typeErr := json.Unmarshal([]byte(`{"N": "x"}`), &v)
+	plain := errors.New("some first error")
+
+	tests := []struct {
+		name    string
+		err     error
+		record  int
+		column  int
+		want    Position
+		wantPos bool
+	}{
+		{"0", plain, 3, 0, Position{Record: 3}, true},
+		{"1", plain, 0, 0, Position{}, false},
+		{"2", csvErr, 0, 0, Position{Record: 2, Column: 6}, true},
+		{"3", fmt.Errorf("import: %w", syntaxErr), 0, 0, Position{Offset: 9}, true},
+		{"4", typeErr, 0, 0, Position{Offset: 9}, true},
+		{"5", csvErr, 5, 1, Position{Record: 5, Column: 1}, true},
+		{"6", Wrap(plain, 0), 2, 2, Position{Record: 2, Column: 2}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WrapParse(tt.err, tt.record, tt.column)
+			var got Position
+			if ok := As(err, &got); ok != tt.wantPos {
+				t.Errorf("%q: As(*Position) = %v, want %v", tt.name, ok, tt.wantPos)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("%q: WrapParse() position = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			var loc Location
+			if !As(err, &loc) {
+				t.Errorf("%q: WrapParse() lacks the code location", tt.name)
+			}
+			if tt.wantPos && !strings.Contains(err.(*ErrSource).Detail(), "\nInput: "+tt.want.String()) {
+				t.Errorf("%q: Detail() lacks the input position", tt.name)
+			}
+		})
+	}
+	if nil != WrapParse(nil, 1, 1) {
+		t.Error("WrapParse(nil) != nil")
+	}
+} // TestWrapParse()
+
+/* _EoF_ */
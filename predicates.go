@@ -0,0 +1,175 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"regexp"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `FrameMatcher` is a predicate checking the frame at the given index
+// of a call stack (innermost frame first).
+//
+// The matchers are composable (see `And()`, `Or()`, `Not()`) and can
+// be used as frame strategy (see `Strategy()`), to filter call stacks
+// (see `FilterFrames()`), and in test assertions (see
+// `Expectation.HasFrame()`).
+type FrameMatcher func(aFrames []Frame, aIdx int) bool
+
+// `Strategy()` returns a frame strategy selecting the first frame
+// matched by the matcher (or the immediate caller if none matches).
+//
+// Returns:
+// - `FrameStrategy`: The frame selecting strategy.
+func (m FrameMatcher) Strategy() FrameStrategy {
+	return func(aFrames []Frame) int {
+		for idx := range aFrames {
+			if m(aFrames, idx) {
+				return idx
+			}
+		}
+
+		return 0
+	}
+} // Strategy()
+
+// --------------------------------------------------------------------------
+
+// `And()` returns a matcher matching frames matched by all the given
+// matchers.
+//
+// Parameters:
+// - `aMatchers`: The matchers to combine.
+//
+// Returns:
+// - `FrameMatcher`: The combined matcher.
+func And(aMatchers ...FrameMatcher) FrameMatcher {
+	return func(aFrames []Frame, aIdx int) bool {
+		for _, m := range aMatchers {
+			if !m(aFrames, aIdx) {
+				return false
+			}
+		}
+
+		return true
+	}
+} // And()
+
+// `Below()` returns a matcher matching all frames below (i.e. the
+// callers of) the first frame matched by the given matcher.
+//
+// Parameters:
+// - `aMatcher`: The matcher selecting the reference frame.
+//
+// Returns:
+// - `FrameMatcher`: The frame matcher.
+func Below(aMatcher FrameMatcher) FrameMatcher {
+	return func(aFrames []Frame, aIdx int) bool {
+		for idx := 0; idx < aIdx; idx++ {
+			if aMatcher(aFrames, idx) {
+				return true
+			}
+		}
+
+		return false
+	}
+} // Below()
+
+// `FilterFrames()` returns the frames matched by the given matcher.
+//
+// Parameters:
+// - `aFrames`: The call stack to filter.
+// - `aMatcher`: The matcher selecting the frames to keep.
+//
+// Returns:
+// - `[]Frame`: The matched frames.
+func FilterFrames(aFrames []Frame, aMatcher FrameMatcher) []Frame {
+	var result []Frame
+	for idx, frame := range aFrames {
+		if aMatcher(aFrames, idx) {
+			result = append(result, frame)
+		}
+	}
+
+	return result
+} // FilterFrames()
+
+// `FuncMatches()` returns a matcher matching frames whose (fully
+// qualified) function name matches the given glob pattern, e.g.
+// "example.com/app/*.(*Repo).*".
+//
+// In the pattern "*" matches any sequence of characters (including
+// slashes) and "?" matches a single character; all other characters
+// match themselves.
+//
+// Parameters:
+// - `aGlob`: The pattern to match the function names against.
+//
+// Returns:
+// - `FrameMatcher`: The frame matcher.
+func FuncMatches(aGlob string) FrameMatcher {
+	pattern := regexp.QuoteMeta(aGlob)
+	pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+	pattern = strings.ReplaceAll(pattern, `\?`, ".")
+	re := regexp.MustCompile("^" + pattern + "$")
+
+	return func(aFrames []Frame, aIdx int) bool {
+		return re.MatchString(aFrames[aIdx].Function)
+	}
+} // FuncMatches()
+
+// `InPackage()` returns a matcher matching frames whose function
+// belongs to the given package.
+//
+// Parameters:
+// - `aPackage`: The package's import path, e.g. "example.com/app/users".
+//
+// Returns:
+// - `FrameMatcher`: The frame matcher.
+func InPackage(aPackage string) FrameMatcher {
+	return func(aFrames []Frame, aIdx int) bool {
+		return funcPackage(aFrames[aIdx].Function) == aPackage
+	}
+} // InPackage()
+
+// `Not()` returns a matcher matching frames not matched by the given
+// matcher.
+//
+// Parameters:
+// - `aMatcher`: The matcher to negate.
+//
+// Returns:
+// - `FrameMatcher`: The negated matcher.
+func Not(aMatcher FrameMatcher) FrameMatcher {
+	return func(aFrames []Frame, aIdx int) bool {
+		return !aMatcher(aFrames, aIdx)
+	}
+} // Not()
+
+// `Or()` returns a matcher matching frames matched by any of the given
+// matchers.
+//
+// Parameters:
+// - `aMatchers`: The matchers to combine.
+//
+// Returns:
+// - `FrameMatcher`: The combined matcher.
+func Or(aMatchers ...FrameMatcher) FrameMatcher {
+	return func(aFrames []Frame, aIdx int) bool {
+		for _, m := range aMatchers {
+			if m(aFrames, aIdx) {
+				return true
+			}
+		}
+
+		return false
+	}
+} // Or()
+
+/* _EoF_ */
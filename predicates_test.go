@@ -0,0 +1,98 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestFilterFrames(t *testing.T) {
+	lines := func(aFrames []Frame) string {
+		result := make([]int, len(aFrames))
+		for idx, frame := range aFrames {
+			result[idx] = frame.Line
+		}
+		return fmt.Sprint(result)
+	}
+
+	tests := []struct {
+		name    string
+		matcher FrameMatcher
+		want    string
+	}{
+		{"0", InPackage("fmt"), "[20]"},
+		{"1", InPackage("example.com/app"), "[]"},
+		{"2", FuncMatches("example.com/app/*"), "[30 40]"},
+		{"3", FuncMatches("*.(*Repo).*"), "[40]"},
+		{"4", Below(InPackage("fmt")), "[30 40 50]"},
+		{"5", Not(Below(InPackage("fmt"))), "[10 20]"},
+		{"6", And(Below(InPackage("fmt")), FuncMatches("example.com/*")), "[30 40]"},
+		{"7", Or(InPackage("main"), InPackage("fmt")), "[20 50]"},
+		{"8", And(), "[10 20 30 40 50]"},
+		{"9", Or(), "[]"},
+		{"10", FuncMatches("*.Must[...]"), "[30]"},
+		{"11", FuncMatches("main.mai?"), "[50]"},
+		{"12", FuncMatches("example.com/app/users.*"), "[40]"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lines(FilterFrames(testStrategyFrames, tt.matcher)); got != tt.want {
+				t.Errorf("%q: FilterFrames() = %s, want %s", tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestFilterFrames()
+
+func TestFrameMatcher_Strategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher FrameMatcher
+		want    int
+	}{
+		{"0", InPackage("main"), 4},
+		{"1", FuncMatches("example.com/*"), 2},
+		{"2", InPackage("unknown"), 0},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Strategy()(testStrategyFrames); got != tt.want {
+				t.Errorf("%q: FrameMatcher.Strategy() = %d, want %d",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestFrameMatcher_Strategy()
+
+func TestExpectation_HasFrame(t *testing.T) {
+	cl1 := Wrap(errors.New("some first error"), 0)
+	tests := []struct {
+		name    string
+		matcher FrameMatcher
+		wantErr bool
+	}{
+		{"0", InPackage("testing"), false},
+		{"1", FuncMatches(thisPackage + ".TestExpectation_*"), false},
+		{"2", InPackage("example.com/unknown"), true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Expect(cl1).HasFrame(tt.matcher).Err()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: HasFrame() = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+} // TestExpectation_HasFrame()
+
+/* _EoF_ */
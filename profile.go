@@ -0,0 +1,127 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Profile` bundles the package's settings suitable for a certain
+// build or deployment environment.
+//
+// The fields are as follows:
+// - `Name`: The profile's name (e.g. "production").
+// - `NoDebug`: Whether to disable the locations (see `Config.SetEnabled()`).
+// - `NoStack`: Whether to disable the call stacks (see
+// `Config.SetCaptureStack()`).
+// - `TopFrame`: The strategy to select the errors' locations (see
+// `Config.SetTopFrame()`).
+type Profile struct {
+	Name     string
+	NoDebug  bool
+	NoStack  bool
+	TopFrame FrameStrategy
+}
+
+const (
+	// Name of the environment variable to select a profile by name
+	// when the package is initialised.
+	ProfileEnvVar = "SOURCEERROR_PROFILE"
+)
+
+var (
+	// `Development` collects locations and call stacks.
+	Development = Profile{
+		Name:     "development",
+		TopFrame: Raw,
+	}
+
+	// `Staging` collects locations but no call stacks.
+	Staging = Profile{
+		Name:     "staging",
+		NoStack:  true,
+		TopFrame: Raw,
+	}
+
+	// `Production` skips all location and call stack investigations.
+	Production = Profile{
+		Name:     "production",
+		NoDebug:  true,
+		NoStack:  true,
+		TopFrame: Raw,
+	}
+
+	// The profile set last by `UseProfile()`.
+	currentProfile = Development
+
+	// Guard for `currentProfile`.
+	profileMtx sync.RWMutex
+)
+
+// `init()` selects the profile named by the `SOURCEERROR_PROFILE`
+// environment variable (if any).
+func init() {
+	if p, ok := ProfileByName(os.Getenv(ProfileEnvVar)); ok {
+		UseProfile(p)
+	}
+} // init()
+
+// `CurrentProfile()` returns the profile set last by `UseProfile()`.
+//
+// NOTE: Changing any of the global settings directly is not reflected
+// by the returned profile.
+//
+// Returns:
+// - `Profile`: The currently used profile.
+func CurrentProfile() Profile {
+	profileMtx.RLock()
+	defer profileMtx.RUnlock()
+
+	return currentProfile
+} // CurrentProfile()
+
+// `ProfileByName()` returns the predefined profile with the given name.
+//
+// Parameters:
+// - `aName`: The (case-insensitive) name of the profile.
+//
+// Returns:
+// - `Profile`: The requested profile.
+// - `bool`: `true` if a profile with the given name exists.
+func ProfileByName(aName string) (Profile, bool) {
+	switch strings.ToLower(strings.TrimSpace(aName)) {
+	case "dev", Development.Name:
+		return Development, true
+	case Staging.Name:
+		return Staging, true
+	case "prod", Production.Name:
+		return Production, true
+	}
+
+	return Profile{}, false
+} // ProfileByName()
+
+// `UseProfile()` applies the given profile's settings to the package's
+// global settings.
+//
+// Parameters:
+// - `aProfile`: The profile to apply.
+func UseProfile(aProfile Profile) {
+	profileMtx.Lock()
+	defer profileMtx.Unlock()
+
+	defaultConfig.SetEnabled(!aProfile.NoDebug)
+	defaultConfig.SetCaptureStack(!aProfile.NoStack)
+	defaultConfig.SetTopFrame(aProfile.TopFrame)
+	currentProfile = aProfile
+} // UseProfile()
+
+/* _EoF_ */
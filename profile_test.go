@@ -0,0 +1,76 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestProfileByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		want    string
+		wantOK  bool
+	}{
+		{"0", "", "", false},
+		{"1", "Development", Development.Name, true},
+		{"2", " staging ", Staging.Name, true},
+		{"3", "PROD", Production.Name, true},
+		{"4", "testing", "", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ProfileByName(tt.profile)
+			if (got.Name != tt.want) || (ok != tt.wantOK) {
+				t.Errorf("%q: ProfileByName() = %q, %v, want %q, %v",
+					tt.name, got.Name, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+} // TestProfileByName()
+
+func TestUseProfile(t *testing.T) {
+	defer UseProfile(Development)
+	e := errors.New("some first error")
+
+	tests := []struct {
+		name      string
+		profile   Profile
+		wantFile  bool
+		wantStack bool
+	}{
+		{"1", Development, true, true},
+		{"2", Staging, true, false},
+		{"3", Production, false, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			UseProfile(tt.profile)
+			if got := CurrentProfile().Name; got != tt.profile.Name {
+				t.Errorf("%q: CurrentProfile() = %q, want %q",
+					tt.name, got, tt.profile.Name)
+			}
+			se := Wrap(e, 0).(*ErrSource)
+			if got := ("" != se.File); got != tt.wantFile {
+				t.Errorf("%q: UseProfile() file = %v, want %v",
+					tt.name, got, tt.wantFile)
+			}
+			if got := (nil != se.Stack); got != tt.wantStack {
+				t.Errorf("%q: UseProfile() stack = %v, want %v",
+					tt.name, got, tt.wantStack)
+			}
+		})
+	}
+} // TestUseProfile()
+
+/* _EoF_ */
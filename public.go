@@ -0,0 +1,148 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tPublicMessage` is an error carrying a message meant for
+	// external clients (see `WithPublicMessage()`).
+	tPublicMessage struct {
+		err     error
+		message string
+	}
+)
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+// - `string`: The wrapped error's text.
+func (pm tPublicMessage) Error() string {
+	return pm.err.Error()
+} // Error()
+
+// `PublicMessage()` returns the message meant for external clients.
+//
+// Returns:
+// - `string`: The error's public message.
+func (pm tPublicMessage) PublicMessage() string {
+	return pm.message
+} // PublicMessage()
+
+// `Unwrap()` returns the wrapped error.
+//
+// Returns:
+// - `error`: The wrapped error.
+func (pm tPublicMessage) Unwrap() error {
+	return pm.err
+} // Unwrap()
+
+// `PublicError` is the sanitised external view of an error: it holds
+// neither source locations nor call stacks and is suitable to be sent
+// to external clients.
+//
+// The fields are as follows:
+// - `Message`: The user facing error message.
+// - `ID`: The instance ID of the error (if any).
+type PublicError struct {
+	Message string `json:"message"`
+	ID      string `json:"id,omitempty"`
+}
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+// - `string`: The error's message, followed by its ID (if any).
+func (pe PublicError) Error() string {
+	if "" == pe.ID {
+		return pe.Message
+	}
+
+	return fmt.Sprintf("%s (error ID %s)", pe.Message, pe.ID)
+} // Error()
+
+// `PublicMessage()` returns the error's message, so that a sanitised
+// error keeps its message if it's sanitised again.
+//
+// Returns:
+// - `string`: The error's public message.
+func (pe PublicError) PublicMessage() string {
+	return pe.Message
+} // PublicMessage()
+
+// --------------------------------------------------------------------------
+
+// `Public()` returns a sanitised copy of the given error suitable to be
+// serialised to external clients.
+//
+// The text of the given error is never used, since it may contain file
+// paths, SQL statements, host names etc.; instead the result's message
+// is the one of the first error in the chain providing a
+// `PublicMessage() string` method (e.g. added by `WithPublicMessage()`)
+// or, if there's none, the generic text of the HTTP status the error
+// maps to (see `RespondError()`), e.g. "Internal Server Error".
+// The result's ID is the one of the outermost `ErrSource` and allows
+// to look up the full error internally (see `Store`).
+//
+// Parameters:
+// - `aErr`: The error to sanitise.
+//
+// Returns:
+// - `error`: The sanitised `PublicError` or `nil` if `aErr` is `nil`.
+func Public(aErr error) error {
+	if nil == aErr {
+		return nil
+	}
+	if result, ok := aErr.(PublicError); ok {
+		// already sanitised
+		return result
+	}
+
+	var result PublicError
+	if se, ok := asSource(aErr); ok {
+		result.ID = se.ID
+	}
+	var public interface{ PublicMessage() string }
+	if errors.As(aErr, &public) {
+		result.Message = public.PublicMessage()
+	}
+	if "" == result.Message {
+		result.Message = http.StatusText(statusOf(aErr))
+	}
+
+	return result
+} // Public()
+
+// `WithPublicMessage()` attaches the given message meant for external
+// clients to the given error (see `Public()`):
+//
+//	if errors.Is(err, sql.ErrNoRows) {
+//		return sourceerror.WithPublicMessage(sourceerror.Wrap(err, 0),
+//			"user not found")
+//	}
+//
+// Parameters:
+// - `aErr`: The error to annotate.
+// - `aMessage`: The message safe to show to external clients.
+//
+// Returns:
+// - `error`: The annotated error, or `nil` if `aErr` is `nil`.
+func WithPublicMessage(aErr error, aMessage string) error {
+	if nil == aErr {
+		return nil
+	}
+
+	return tPublicMessage{err: aErr, message: aMessage}
+} // WithPublicMessage()
+
+/* _EoF_ */
@@ -0,0 +1,65 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestPublic(t *testing.T) {
+	e := errors.New("select * from users: no rows")
+	cl1 := Wrap(e, 0)
+	cl2 := Wrap(fmt.Errorf("loading: %w", WithPublicMessage(cl1, "user not found")), 0)
+	cl3 := fmt.Errorf("handler: %w", cl2)
+	cl4 := NotImplemented("export")
+
+	tests := []struct {
+		name     string
+		err      error
+		wantJSON string
+	}{
+		{"1", e, `{"message":"Internal Server Error"}`},
+		{"2", cl1, `{"message":"Internal Server Error","id":"` + ID(cl1) + `"}`},
+		{"3", cl2, `{"message":"user not found","id":"` + ID(cl2) + `"}`},
+		{"4", cl3, `{"message":"user not found","id":"` + ID(cl2) + `"}`},
+		{"5", &ErrSource{}, `{"message":"Internal Server Error"}`},
+		{"6", cl4, `{"message":"Not Implemented","id":"` + ID(cl4) + `"}`},
+		{"7", Public(cl3), `{"message":"user not found","id":"` + ID(cl2) + `"}`},
+		{"8", WithPublicMessage(e, ""), `{"message":"Internal Server Error"}`},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(Public(tt.err))
+			if nil != err {
+				t.Errorf("%q: json.Marshal() error = %v", tt.name, err)
+				return
+			}
+			if string(got) != tt.wantJSON {
+				t.Errorf("%q: Public() =\n%s\nwant\n%s",
+					tt.name, got, tt.wantJSON)
+			}
+		})
+	}
+
+	if nil != Public(nil) {
+		t.Error("Public(nil) != nil")
+	}
+	if nil != WithPublicMessage(nil, "user not found") {
+		t.Error("WithPublicMessage(nil) != nil")
+	}
+	if got, want := Public(cl2).Error(), "user not found (error ID "+ID(cl2)+")"; got != want {
+		t.Errorf("PublicError.Error() = %q, want %q", got, want)
+	}
+} // TestPublic()
+
+/* _EoF_ */
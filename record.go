@@ -0,0 +1,189 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The current version of the serialised error representation.
+	recordVersion = 1
+)
+
+var (
+	// If set `true`, directly nested `ErrSource` layers of the same
+	// function are serialised as a single chain layer with a repeat
+	// count, keeping the payloads of deeply wrapped errors small.
+	CompactChains bool
+)
+
+// `tChainLayer` is a serialised layer of an error's chain.
+type tChainLayer struct {
+	Location
+	Repeated int `json:"repeated,omitempty"` // number of merged layers
+}
+
+// `tRecord` is the serialisable representation of an `ErrSource`.
+type tRecord struct {
+	Version  int            `json:"v"`
+	ID       string         `json:"id,omitempty"`
+	Message  string         `json:"message"`
+	File     string         `json:"file,omitempty"`
+	Line     int            `json:"line,omitempty"`
+	Function string         `json:"function,omitempty"`
+	Stack    string         `json:"stack,omitempty"`
+	External *Location      `json:"external,omitempty"`
+	Foreign  []ForeignStack `json:"foreign,omitempty"`
+	Omitted  string         `json:"stack_omitted,omitempty"`
+	Chain    []tChainLayer  `json:"chain,omitempty"`
+	Elapsed  time.Duration  `json:"elapsed_ns,omitempty"`
+	Phase    string         `json:"phase,omitempty"`
+	Seq      uint64         `json:"seq,omitempty"`
+	Input    *Position      `json:"input,omitempty"`
+	Test     string         `json:"test,omitempty"`
+
+	Intentional bool `json:"intentional,omitempty"` // see `MarkIntentional()`
+}
+
+// `newRecord()` returns the serialisable representation of the
+// given error.
+//
+// Parameters:
+// - `aSource`: The error to convert.
+//
+// Returns:
+// - `*tRecord`: The error's serialisable representation.
+func newRecord(aSource *ErrSource) *tRecord {
+	result := newMetaRecord(aSource)
+	result.Stack = string(aSource.StackTrace())
+
+	return result
+} // newRecord()
+
+// `newMetaRecord()` returns the serialisable representation of the
+// given error without its call stack.
+//
+// Parameters:
+// - `aSource`: The error to convert.
+//
+// Returns:
+// - `*tRecord`: The error's serialisable representation.
+func newMetaRecord(aSource *ErrSource) *tRecord {
+	result := &tRecord{
+		Version:  recordVersion,
+		ID:       aSource.ID,
+		Message:  aSource.message(),
+		File:     aSource.File,
+		Line:     aSource.Line,
+		Function: aSource.Function,
+		External: aSource.External,
+		Foreign:  aSource.Foreign,
+		Omitted:  aSource.OmitReason,
+		Elapsed:  aSource.Elapsed,
+		Phase:    aSource.Phase,
+		Seq:      aSource.Seq,
+		Input:    aSource.Input,
+		Test:     aSource.Test,
+
+		Intentional: IsIntentional(aSource),
+	}
+	if layers := chainLayers(aSource); (1 < len(layers)) || (0 < layers[0].Repeated) {
+		result.Chain = layers
+	}
+
+	return result
+} // newMetaRecord()
+
+// `chainLayers()` returns the serialisable layers of the given error's
+// chain, merging the layers that add no information if the global
+// `CompactChains` flag is set.
+//
+// A layer adds no information if it's wrapped directly (i.e. without
+// an intermediate error adding text) by a layer of the same function.
+//
+// Parameters:
+// - `aSource`: The outermost layer of the chain.
+//
+// Returns:
+// - `[]tChainLayer`: The chain's layers, outermost first.
+func chainLayers(aSource *ErrSource) []tChainLayer {
+	var (
+		result []tChainLayer
+		direct bool // whether the current link is wrapped by a layer
+	)
+	_ = walkChain(aSource, func(aLink error) bool {
+		var loc Location
+		switch se := aLink.(type) {
+		case *ErrSource:
+			if nil == se {
+				return false
+			}
+			loc = se.Location()
+		case ErrSource:
+			loc = se.Location()
+		default:
+			direct = false
+			return true
+		}
+
+		last := len(result) - 1
+		if CompactChains && direct &&
+			(result[last].File == loc.File) &&
+			(result[last].Function == loc.Function) {
+			result[last].Repeated++
+		} else {
+			result = append(result, tChainLayer{Location: loc})
+		}
+		direct = true
+		return true
+	})
+
+	return result
+} // chainLayers()
+
+// `source()` returns the `ErrSource` represented by the record.
+//
+// NOTE: The wrapped error is restored as a plain error with the
+// original error's message only; the chain's inner layers are not
+// restored.
+//
+// Returns:
+// - `*ErrSource`: The restored error.
+func (r *tRecord) source() *ErrSource {
+	result := &ErrSource{
+		ID:           r.ID,
+		File:         r.File,
+		Line:         r.Line,
+		Function:     r.Function,
+		External:     r.External,
+		Foreign:      r.Foreign,
+		StackOmitted: "" != r.Omitted,
+		OmitReason:   r.Omitted,
+		Elapsed:      r.Elapsed,
+		Phase:        r.Phase,
+		Seq:          r.Seq,
+		Input:        r.Input,
+		Test:         r.Test,
+	}
+	if "" != r.Message {
+		result.err = errors.New(r.Message)
+		if r.Intentional {
+			result.err = MarkIntentional(result.err)
+		}
+	}
+	if "" != r.Stack {
+		result.Stack = []byte(r.Stack)
+	}
+
+	return result
+} // source()
+
+/* _EoF_ */
@@ -0,0 +1,67 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func testWrapTwice(aErr error) error {
+	return Wrap(Wrap(aErr, 0), 0)
+} // testWrapTwice()
+
+func Test_chainLayers(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0).(*ErrSource)
+	cl2 := testWrapTwice(cl1).(*ErrSource)
+	cl3 := Wrap(fmt.Errorf("outer: %w", cl2), 0).(*ErrSource)
+	cl4 := Wrap(fmt.Errorf("outer: %w", testWrapTwice(e)), 0).(*ErrSource)
+
+	tests := []struct {
+		name         string
+		se           *ErrSource
+		compact      bool
+		wantLen      int
+		wantRepeated []int
+	}{
+		{"0", cl1, true, 1, []int{0}},
+		{"1", cl2, false, 3, []int{0, 0, 0}},
+		{"2", cl2, true, 2, []int{1, 0}},
+		{"3", cl3, true, 3, []int{0, 1, 0}},
+		{"4", cl4, true, 2, []int{0, 1}},
+		// TODO: Add test cases.
+	}
+	defer func() {
+		CompactChains = false
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			CompactChains = tt.compact
+			got := chainLayers(tt.se)
+			if len(got) != tt.wantLen {
+				t.Errorf("%q: chainLayers() = %v, want %d layers",
+					tt.name, got, tt.wantLen)
+				return
+			}
+			for idx, layer := range got {
+				if layer.Repeated != tt.wantRepeated[idx] {
+					t.Errorf("%q: chainLayers()[%d].Repeated = %d, want %d",
+						tt.name, idx, layer.Repeated, tt.wantRepeated[idx])
+				}
+			}
+			if rec := newRecord(tt.se); (1 < tt.wantLen) != (nil != rec.Chain) {
+				t.Errorf("%q: newRecord().Chain = %v", tt.name, rec.Chain)
+			}
+		})
+	}
+} // Test_chainLayers()
+
+/* _EoF_ */
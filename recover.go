@@ -0,0 +1,91 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `panicFrames()` returns the number of innermost frames of the given
+// call stack that precede the frame raising the current panic, i.e.
+// the frames of the deferred function recovering the panic, the
+// runtime's `panic` frame, and the runtime's helpers raising e.g.
+// index panics.
+//
+// Parameters:
+// - `aStack`: The call stack as returned by `debug.Stack()`.
+//
+// Returns:
+// - `int`: The number of frames preceding the panic site, or `0` if
+// there's no `panic` frame.
+func panicFrames(aStack []byte) int {
+	lines := bytes.Split(aStack, []byte("\n"))
+
+	// The first line is the goroutine header, followed by pairs of
+	// function and location lines.
+	for idx := 1; idx < len(lines); idx += 2 {
+		if !bytes.HasPrefix(lines[idx], []byte("panic(")) {
+			continue
+		}
+		for idx += 2; idx < len(lines); idx += 2 {
+			if !bytes.HasPrefix(lines[idx], []byte("runtime.")) {
+				break
+			}
+		}
+		return (idx - 1) / 2
+	}
+
+	return 0
+} // panicFrames()
+
+// `Recover()` recovers a panic of the calling function and stores it
+// as an error in the given variable, usually the function's named
+// error result:
+//
+//	func Parse(aText string) (rResult *Tree, rErr error) {
+//		defer sourceerror.Recover(&rErr)
+//		// ...
+//	}
+//
+// The error's location is the code that raised the panic rather than
+// the deferred call, and its call stack starts with the panic site,
+// i.e. without the frames of the recovery.
+// If the panic's value is an error, it's wrapped by the new error so
+// that it can be found by `errors.Is()` and `errors.As()`.
+//
+// NOTE: `Recover()` must be called directly by `defer`, otherwise it
+// can't recover the panic.
+//
+// Parameters:
+// - `aErr`: The variable to store the error in; if it's `nil`, the
+// panic is raised again.
+func Recover(aErr *error) {
+	r := recover()
+	if nil == r {
+		return
+	}
+	if nil == aErr {
+		panic(r)
+	}
+
+	var err error
+	if pErr, ok := r.(error); ok {
+		err = fmt.Errorf("panic: %w", pErr)
+	} else {
+		err = fmt.Errorf("panic: %v", r)
+	}
+	result := buildSource(err, 0, 1, panicSite)
+	result.Stack = trimStack(result.Stack, panicFrames(result.Stack))
+	notifyWrapHooks(result)
+
+	*aErr = result
+} // Recover()
+
+/* _EoF_ */
@@ -0,0 +1,106 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `recoverer()` runs the given function recovering its panic.
+func recoverer(aFn func()) (rErr error) {
+	defer Recover(&rErr)
+	aFn()
+
+	return nil
+} // recoverer()
+
+func Test_panicFrames(t *testing.T) {
+	tests := []struct {
+		name  string
+		stack string
+		want  int
+	}{
+		{"0", "", 0},
+		{"1", testTestStack, 0},
+		{"2", `goroutine 1 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:26 +0x5e
+example.com/app.Recover(0xc000012345)
+	/src/app/recover.go:12 +0x25
+panic({0x4b1e40?, 0xc000012360?})
+	/usr/local/go/src/runtime/panic.go:792 +0x132
+runtime.panicIndex(...)
+	/usr/local/go/src/runtime/panic.go:115 +0x33
+example.com/app.parse()
+	/src/app/parse.go:7 +0x1d
+`, 4},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := panicFrames([]byte(tt.stack)); got != tt.want {
+				t.Errorf("%q: panicFrames() = %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_panicFrames()
+
+func TestRecover(t *testing.T) {
+	var list []int
+	_, _, line, _ := runtime.Caller(0)
+
+	tests := []struct {
+		name     string
+		fn       func()
+		wantLine int
+		wantText string
+	}{
+		{"0", func() {}, 0, ""},
+		{"1", func() { panic("boom") }, line + 9, "panic: boom"},
+		{"2", func() { _ = list[3] }, line + 10, "index out of range"},
+		{"3", func() { panic(io.EOF) }, line + 11, "panic: EOF"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := recoverer(tt.fn)
+			if 0 == tt.wantLine {
+				if nil != err {
+					t.Errorf("%q: Recover() = %v, want <nil>", tt.name, err)
+				}
+				return
+			}
+			se, _ := AsSource(err)
+			if nil == se {
+				t.Fatalf("%q: Recover() = %v, want an ErrSource", tt.name, err)
+			}
+			if se.Line != tt.wantLine {
+				t.Errorf("%q: Recover() line = %d, want %d", tt.name, se.Line, tt.wantLine)
+			}
+			if !strings.Contains(se.Error(), tt.wantText) {
+				t.Errorf("%q: Recover() = %q, want %q", tt.name, se.Error(), tt.wantText)
+			}
+			first := bytes.SplitN(se.Stack, []byte("\n"), 3)[1]
+			if !bytes.Contains(first, []byte("TestRecover.func")) {
+				t.Errorf("%q: Recover() stack =\n%s\nwant panic site first", tt.name, se.Stack)
+			}
+		})
+	}
+
+	if err := recoverer(func() { panic(io.EOF) }); !errors.Is(err, io.EOF) {
+		t.Errorf("Recover() = %v, want %v", err, io.EOF)
+	}
+} // TestRecover()
+
+/* _EoF_ */
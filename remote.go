@@ -0,0 +1,286 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Remote` is an error reconstructed from textual output of another
+// (possibly crashed) Go process, e.g. a goroutine dump.
+//
+// The fields are as follows:
+// - `Message`: The error message (if any).
+// - `Goroutine`: The ID of the goroutine the frames belong to.
+// - `State`: The goroutine's state, e.g. "running" or "chan receive".
+// - `Frames`: The goroutine's call stack, innermost first.
+// - `CreatedBy`: The location where the goroutine was started (if any).
+type Remote struct {
+	Message   string
+	Goroutine int
+	State     string
+	Frames    []Frame
+	CreatedBy *Frame
+}
+
+var (
+	// Regular expression matching a goroutine header line, e.g.
+	//
+	//	goroutine 18 [chan receive, 2 minutes]:
+	reGoroutineHeader = regexp.MustCompile(`^goroutine (\d+) (?:gp=\S+ m=\S+ (?:mp=\S+ )?)?\[([^\]]*)\]:$`)
+
+	// Error returned if the input doesn't contain any goroutine.
+	errNoGoroutine = errors.New("no goroutine found")
+
+	// Error returned if the input doesn't contain a panic message.
+	errNoPanic = errors.New("no panic found")
+)
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+// - `string`: The error's message or a description of the goroutine.
+func (r Remote) Error() string {
+	if "" != r.Message {
+		return r.Message
+	}
+
+	return fmt.Sprintf("goroutine %d [%s]", r.Goroutine, r.State)
+} // Error()
+
+// `String()` implements the `Stringer` interface and returns the
+// error in the format used by the Go runtime; recursion cycles are
+// folded.
+//
+// Returns:
+// - `string`: The error's string representation.
+func (r Remote) String() string {
+	var sb strings.Builder
+
+	if "" != r.Message {
+		sb.WriteString(r.Message + "\n\n")
+	}
+	fmt.Fprintf(&sb, "goroutine %d [%s]:\n", r.Goroutine, r.State)
+	writeFrames(&sb, r.Frames, func(aFrame Frame) string {
+		return fmt.Sprintf("%s(...)\n\t%s\n", aFrame.Function, aFrame)
+	})
+	if nil != r.CreatedBy {
+		fmt.Fprintf(&sb, "created by %s\n\t%s\n",
+			r.CreatedBy.Function, r.CreatedBy)
+	}
+
+	return sb.String()
+} // String()
+
+// --------------------------------------------------------------------------
+
+// `parseFileLine()` parses a frame's location line as printed by the
+// Go runtime, e.g. "\t/src/main.go:12 +0x1d".
+//
+// Parameters:
+// - `aLine`: The line to parse.
+//
+// Returns:
+// - `string`: The source file.
+// - `int`: The line number.
+// - `bool`: `true` if the line was a location line.
+func parseFileLine(aLine string) (string, int, bool) {
+	if !strings.HasPrefix(aLine, "\t") {
+		return "", 0, false
+	}
+	aLine = strings.TrimSpace(aLine)
+	if idx := strings.LastIndex(aLine, " +0x"); 0 < idx {
+		aLine = aLine[:idx]
+	}
+	idx := strings.LastIndexByte(aLine, ':')
+	if 0 >= idx {
+		return "", 0, false
+	}
+	line, err := strconv.Atoi(aLine[idx+1:])
+	if nil != err {
+		return "", 0, false
+	}
+
+	return aLine[:idx], line, true
+} // parseFileLine()
+
+// `parseFunction()` returns the function name of a frame's function
+// line as printed by the Go runtime, e.g. "main.(*T).M(0xc0000, ...)".
+//
+// Parameters:
+// - `aLine`: The line to parse.
+//
+// Returns:
+// - `string`: The function name without its arguments.
+func parseFunction(aLine string) string {
+	aLine = strings.TrimSpace(aLine)
+	if strings.HasSuffix(aLine, ")") {
+		if idx := strings.LastIndexByte(aLine, '('); 0 < idx {
+			aLine = aLine[:idx]
+		}
+	}
+
+	return demangle(aLine)
+} // parseFunction()
+
+// `parseGoroutines()` parses the goroutines of the given text lines.
+//
+// All lines before the first goroutine header are ignored.
+//
+// Parameters:
+// - `aScanner`: The scanner providing the text lines.
+//
+// Returns:
+// - `[]Remote`: The parsed goroutines.
+// - `error`: A possible reading error.
+func parseGoroutines(aScanner *bufio.Scanner) ([]Remote, error) {
+	var (
+		result   []Remote
+		current  *Remote
+		function string
+	)
+	for aScanner.Scan() {
+		line := strings.TrimRight(aScanner.Text(), "\r")
+		if match := reGoroutineHeader.FindStringSubmatch(line); nil != match {
+			id, _ := strconv.Atoi(match[1])
+			result = append(result, Remote{
+				Goroutine: id,
+				State:     match[2],
+			})
+			current, function = &result[len(result)-1], ""
+			continue
+		}
+		if nil == current {
+			continue
+		}
+		if "" == strings.TrimSpace(line) {
+			current, function = nil, ""
+			continue
+		}
+
+		if file, lineNo, ok := parseFileLine(line); ok {
+			if "" == function {
+				continue
+			}
+			if creator, isCreator := strings.CutPrefix(function, "created by "); isCreator {
+				if idx := strings.Index(creator, " in goroutine "); 0 < idx {
+					creator = creator[:idx]
+				}
+				current.CreatedBy = &Frame{
+					File:     file,
+					Function: demangle(creator),
+					Line:     lineNo,
+				}
+			} else {
+				current.Frames = append(current.Frames, Frame{
+					File:     file,
+					Function: parseFunction(function),
+					Line:     lineNo,
+				})
+			}
+			function = ""
+			continue
+		}
+		if strings.HasPrefix(line, "...") {
+			// e.g. "...additional frames elided..."
+			continue
+		}
+		function = line
+	}
+
+	return result, aScanner.Err()
+} // parseGoroutines()
+
+// `ParseGoroutineDump()` parses a full goroutine dump as written by the
+// Go runtime (e.g. on `SIGQUIT` or by `runtime.Stack(buf, true)`).
+//
+// Parameters:
+// - `aReader`: The source to read the dump from.
+//
+// Returns:
+// - `[]Remote`: One entry per goroutine of the dump.
+// - `error`: A possible reading error or an error if no goroutine
+// was found.
+func ParseGoroutineDump(aReader io.Reader) ([]Remote, error) {
+	scanner := bufio.NewScanner(aReader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	result, err := parseGoroutines(scanner)
+	if nil != err {
+		return result, err
+	}
+	if 0 == len(result) {
+		return nil, errNoGoroutine
+	}
+
+	return result, nil
+} // ParseGoroutineDump()
+
+// `ParsePanic()` reconstructs the panic message and the panicking
+// goroutine's call stack from the output of a crashed Go program (e.g.
+// as captured from a subprocess' stderr or a container's log).
+//
+// Any text before the line starting with "panic: " or "fatal error: "
+// is ignored.
+//
+// Parameters:
+// - `aText`: The captured output.
+//
+// Returns:
+// - `*Remote`: The reconstructed error.
+// - `error`: An error if no panic or goroutine was found.
+func ParsePanic(aText string) (*Remote, error) {
+	lines := strings.Split(strings.ReplaceAll(aText, "\r\n", "\n"), "\n")
+
+	start := -1
+	for idx, line := range lines {
+		if strings.HasPrefix(line, "panic: ") ||
+			strings.HasPrefix(line, "fatal error: ") {
+			start = idx
+			break
+		}
+	}
+	if 0 > start {
+		return nil, errNoPanic
+	}
+
+	end := start
+	for ; end < len(lines); end++ {
+		if ("" == strings.TrimSpace(lines[end])) ||
+			reGoroutineHeader.MatchString(lines[end]) {
+			break
+		}
+	}
+	message := strings.TrimPrefix(
+		strings.Join(lines[start:end], "\n"), "panic: ")
+
+	scanner := bufio.NewScanner(strings.NewReader(
+		strings.Join(lines[end:], "\n")))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	goroutines, err := parseGoroutines(scanner)
+	if nil != err {
+		return nil, err
+	}
+	if 0 == len(goroutines) {
+		return nil, errNoGoroutine
+	}
+
+	result := goroutines[0]
+	result.Message = message
+
+	return &result, nil
+} // ParsePanic()
+
+/* _EoF_ */
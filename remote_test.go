@@ -0,0 +1,179 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// a shortened goroutine dump as written on SIGQUIT
+	testGoroutineDump = `SIGQUIT: quit
+PC=0x46b7a1 m=0 sigcode=0
+
+goroutine 0 gp=0x5c1e40 m=0 mp=0x5c2ca0 [idle]:
+runtime.futex(0x5c2de0, 0x80, 0x0, 0x0, 0x0, 0x0)
+	/usr/local/go/src/runtime/sys_linux_amd64.s:557 +0x21 fp=0x7ffd3c8 sp=0x7ffd3c0 pc=0x46b7a1
+
+goroutine 1 [chan receive, 2 minutes]:
+main.(*Server).wait(0xc000012345, {0x5, 0x6})
+	/src/app/server.go:42 +0x1d
+main.main()
+	/src/app/main.go:12 +0x25
+
+goroutine 7 [select]:
+example.com/app/worker.Run[...](...)
+	/src/app/worker/run.go:88
+...additional frames elided...
+created by main.main in goroutine 1
+	/src/app/main.go:10 +0x7f
+`
+)
+
+func TestParseGoroutineDump(t *testing.T) {
+	got, err := ParseGoroutineDump(strings.NewReader(testGoroutineDump))
+	if nil != err {
+		t.Fatalf("ParseGoroutineDump() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		remote      Remote
+		wantID      int
+		wantState   string
+		wantFrames  []Frame
+		wantCreator *Frame
+	}{
+		{"0", got[0], 0, "idle", []Frame{
+			{"/usr/local/go/src/runtime/sys_linux_amd64.s", "runtime.futex", 557, 0},
+		}, nil},
+		{"1", got[1], 1, "chan receive, 2 minutes", []Frame{
+			{"/src/app/server.go", "main.(*Server).wait", 42, 0},
+			{"/src/app/main.go", "main.main", 12, 0},
+		}, nil},
+		{"2", got[2], 7, "select", []Frame{
+			{"/src/app/worker/run.go", "example.com/app/worker.Run[...]", 88, 0},
+		}, &Frame{"/src/app/main.go", "main.main", 10, 0}},
+		// TODO: Add test cases.
+	}
+	if len(got) != len(tests) {
+		t.Fatalf("ParseGoroutineDump() = %d goroutines, want %d",
+			len(got), len(tests))
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.remote
+			if (r.Goroutine != tt.wantID) || (r.State != tt.wantState) {
+				t.Errorf("%q: goroutine = %d [%s], want %d [%s]",
+					tt.name, r.Goroutine, r.State, tt.wantID, tt.wantState)
+			}
+			if len(r.Frames) != len(tt.wantFrames) {
+				t.Errorf("%q: frames = %v, want %v",
+					tt.name, r.Frames, tt.wantFrames)
+				return
+			}
+			for idx, frame := range r.Frames {
+				if frame != tt.wantFrames[idx] {
+					t.Errorf("%q: frame[%d] = %v, want %v",
+						tt.name, idx, frame, tt.wantFrames[idx])
+				}
+			}
+			if (nil == r.CreatedBy) != (nil == tt.wantCreator) ||
+				((nil != r.CreatedBy) && (*r.CreatedBy != *tt.wantCreator)) {
+				t.Errorf("%q: created by = %v, want %v",
+					tt.name, r.CreatedBy, tt.wantCreator)
+			}
+		})
+	}
+} // TestParseGoroutineDump()
+
+func TestParseGoroutineDump_runtime(t *testing.T) {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+
+	got, err := ParseGoroutineDump(strings.NewReader(string(buf)))
+	if nil != err {
+		t.Fatalf("ParseGoroutineDump() error = %v", err)
+	}
+	if 2 > len(got) {
+		t.Fatalf("ParseGoroutineDump() = %d goroutines, want >= 2", len(got))
+	}
+	if "running" != got[0].State {
+		t.Errorf("ParseGoroutineDump() state = %q, want %q",
+			got[0].State, "running")
+	}
+	found := false
+	for _, frame := range got[0].Frames {
+		if strings.HasSuffix(frame.Function, ".TestParseGoroutineDump_runtime") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("ParseGoroutineDump() missing test frame in\n%s", got[0])
+	}
+
+	if _, err = ParseGoroutineDump(strings.NewReader("no dump")); nil == err {
+		t.Error("ParseGoroutineDump() without goroutines: want error")
+	}
+} // TestParseGoroutineDump_runtime()
+
+func TestParsePanic(t *testing.T) {
+	text1 := `some log line
+panic: runtime error: index out of range [3] with length 2 [recovered]
+	panic: again
+
+goroutine 1 [running]:
+main.load(...)
+	/src/app/load.go:7
+main.main()
+	/src/app/main.go:12 +0x25
+exit status 2
+`
+	text2 := "fatal error: all goroutines are asleep - deadlock!\r\n\r\n" +
+		"goroutine 1 [chan receive]:\r\nmain.main()\r\n\t/src/app/main.go:5 +0x1d\r\n"
+
+	tests := []struct {
+		name       string
+		text       string
+		wantMsg    string
+		wantFrames int
+		wantErr    bool
+	}{
+		{"0", "", "", 0, true},
+		{"1", text1, "runtime error: index out of range [3] with length 2 [recovered]\n\tpanic: again", 2, false},
+		{"2", text2, "fatal error: all goroutines are asleep - deadlock!", 1, false},
+		{"3", "panic: no stack\n", "", 0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePanic(tt.text)
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: ParsePanic() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Error() != tt.wantMsg {
+				t.Errorf("%q: ParsePanic() message = %q, want %q",
+					tt.name, got.Error(), tt.wantMsg)
+			}
+			if len(got.Frames) != tt.wantFrames {
+				t.Errorf("%q: ParsePanic() frames = %v, want %d",
+					tt.name, got.Frames, tt.wantFrames)
+			}
+		})
+	}
+} // TestParsePanic()
+
+/* _EoF_ */
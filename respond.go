@@ -0,0 +1,192 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tProblem` is a "problem details" response body (RFC 9457).
+	tProblem struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail,omitempty"`
+		ID     string `json:"id,omitempty"`
+	}
+)
+
+const (
+	// The media types `RespondError()` can produce.
+	mediaProblem = "application/problem+json"
+	mediaJSON    = "application/json"
+	mediaHTML    = "text/html"
+	mediaText    = "text/plain"
+)
+
+var (
+	// The debug page rendered if enabled (see `Config.SetDebugPage()`).
+	debugPage = htmltemplate.Must(htmltemplate.New("debug").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>{{.Status}} {{.Title}}</title></head>
+<body><h1>{{.Status}} {{.Title}}</h1>
+<p>{{.Message}}</p>
+{{.Details}}
+</body></html>
+`))
+)
+
+// `acceptedMedia()` selects the response's media type from the given
+// `Accept` header.
+//
+// Parameters:
+// - `aAccept`: The request's `Accept` header.
+// - `aHTML`: Whether an HTML response may be selected.
+//
+// Returns:
+// - `string`: The selected media type.
+func acceptedMedia(aAccept string, aHTML bool) string {
+	result, best := mediaText, 0.0
+	for _, part := range strings.Split(aAccept, ",") {
+		media, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if nil != err {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); nil != err {
+				continue
+			}
+		}
+		if quality <= best {
+			continue
+		}
+
+		switch media {
+		case mediaProblem, mediaJSON:
+			result, best = mediaProblem, quality
+		case mediaHTML:
+			if aHTML {
+				result, best = mediaHTML, quality
+			}
+		case mediaText, "text/*", "*/*":
+			result, best = mediaText, quality
+		}
+	}
+
+	return result
+} // acceptedMedia()
+
+// `statusOf()` returns the HTTP status code suitable for the given
+// error.
+//
+// The first error in the chain providing a `StatusCode() int` method
+// returning a valid code determines the status; errors wrapping
+// `ErrNotImplemented` or `ErrUnsupported` result in "501 Not
+// Implemented", all others in "500 Internal Server Error".
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `int`: The HTTP status code.
+func statusOf(aErr error) int {
+	result := 0
+	_ = walkChain(aErr, func(aLink error) bool {
+		if sc, ok := aLink.(interface{ StatusCode() int }); ok {
+			if code := sc.StatusCode(); (100 <= code) && (600 > code) {
+				result = code
+				return false
+			}
+		}
+		return true
+	})
+	if 0 != result {
+		return result
+	}
+	if errors.Is(aErr, ErrNotImplemented) || errors.Is(aErr, ErrUnsupported) {
+		return http.StatusNotImplemented
+	}
+
+	return http.StatusInternalServerError
+} // statusOf()
+
+// `RespondError()` writes the given error as response to the given
+// request, negotiating the response's format with the request's
+// `Accept` header:
+// - `application/problem+json` (or `application/json`): a "problem
+// details" object (RFC 9457) with the sanitised message and the ID;
+// - `text/html`: a debug page with the error's locations and call
+// stack, available only if enabled by `Config.SetDebugPage()` and in
+// the `Development` profile;
+// - `text/plain` (otherwise): the sanitised message and the ID.
+//
+// The status code is taken from the first error in the chain with a
+// `StatusCode() int` method; otherwise it's "501 Not Implemented" for
+// `ErrNotImplemented` or `ErrUnsupported` and "500 Internal Server
+// Error" for all other errors.
+//
+// The error is counted with the request's context (see `CountError()`).
+//
+// Parameters:
+// - `aWriter`: Used to send the response.
+// - `aRequest`: The HTTP request that failed.
+// - `aErr`: The error to report.
+func RespondError(aWriter http.ResponseWriter, aRequest *http.Request, aErr error) {
+	if nil == aErr {
+		return
+	}
+	_ = CountError(aRequest.Context(), aErr)
+
+	status := statusOf(aErr)
+	public := Public(aErr).(PublicError)
+	debug := defaultConfig.DebugPage() &&
+		(Development.Name == CurrentProfile().Name)
+	media := acceptedMedia(aRequest.Header.Get("Accept"), debug)
+
+	header := aWriter.Header()
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Add("Vary", "Accept")
+	switch media {
+	case mediaProblem:
+		header.Set("Content-Type", mediaProblem)
+		aWriter.WriteHeader(status)
+		_ = json.NewEncoder(aWriter).Encode(tProblem{
+			Type:   "about:blank",
+			Title:  http.StatusText(status),
+			Status: status,
+			Detail: public.Message,
+			ID:     public.ID,
+		})
+
+	case mediaHTML:
+		header.Set("Content-Type", mediaHTML+"; charset=utf-8")
+		aWriter.WriteHeader(status)
+		_ = debugPage.Execute(aWriter, map[string]any{
+			"Status":  status,
+			"Title":   http.StatusText(status),
+			"Message": public.Message,
+			"Details": SafeHTML(aErr),
+		})
+
+	default:
+		header.Set("Content-Type", mediaText+"; charset=utf-8")
+		aWriter.WriteHeader(status)
+		fmt.Fprintln(aWriter, public.Error())
+	}
+} // RespondError()
+
+/* _EoF_ */
@@ -0,0 +1,114 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// an error providing its HTTP status
+	tStatusErr int
+)
+
+func (e tStatusErr) Error() string   { return http.StatusText(int(e)) }
+func (e tStatusErr) StatusCode() int { return int(e) }
+
+func Test_acceptedMedia(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		html   bool
+		want   string
+	}{
+		{"0", "", true, mediaText},
+		{"1", "application/json", false, mediaProblem},
+		{"2", "text/html,application/xhtml+xml,*/*;q=0.8", true, mediaHTML},
+		{"3", "text/html,application/xhtml+xml,*/*;q=0.8", false, mediaText},
+		{"4", "text/plain;q=0.5, application/problem+json", true, mediaProblem},
+		{"5", "application/json;q=0.2, text/plain", true, mediaText},
+		{"6", "image/png", true, mediaText},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := acceptedMedia(tt.accept, tt.html); got != tt.want {
+				t.Errorf("%q: acceptedMedia() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_acceptedMedia()
+
+func TestRespondError(t *testing.T) {
+	defer UseProfile(CurrentProfile())
+	defer DefaultConfig().SetDebugPage(DefaultConfig().DebugPage())
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	cl2 := Wrap(tStatusErr(http.StatusConflict), 0)
+
+	tests := []struct {
+		name       string
+		profile    Profile
+		debug      bool
+		accept     string
+		err        error
+		wantStatus int
+		wantType   string
+		wantBody   string
+	}{
+		{"0", Development, false, "", cl1, 500, mediaText, ID(cl1)},
+		{"1", Development, false, "application/json", cl2, 409, mediaProblem, `"status":409`},
+		{"2", Development, true, "text/html", cl1, 500, mediaHTML, "respond_test.go"},
+		{"3", Production, true, "text/html", cl1, 500, mediaText, "Internal Server Error"},
+		{"4", Development, false, "", NotImplemented("export"), 501, mediaText, "Not Implemented"},
+		{"5", Development, false, "text/html", cl1, 500, mediaText, "Internal Server Error"},
+		{"6", Development, false, "", WithPublicMessage(cl1, "try again"), 500, mediaText, "try again"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			UseProfile(tt.profile)
+			DefaultConfig().SetDebugPage(tt.debug)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			RespondError(rec, req, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("%q: RespondError() status = %d, want %d",
+					tt.name, rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.wantType) {
+				t.Errorf("%q: RespondError() type = %q, want %q",
+					tt.name, got, tt.wantType)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("%q: RespondError() body =\n%s\nwant %q",
+					tt.name, rec.Body.String(), tt.wantBody)
+			}
+			if (mediaHTML != tt.wantType) && strings.Contains(rec.Body.String(), ".go") {
+				t.Errorf("%q: RespondError() leaks source files:\n%s",
+					tt.name, rec.Body.String())
+			}
+			if mediaProblem == tt.wantType {
+				var problem tProblem
+				if err := json.Unmarshal(rec.Body.Bytes(), &problem); nil != err {
+					t.Errorf("%q: RespondError() invalid JSON: %v", tt.name, err)
+				}
+			}
+		})
+	}
+} // TestRespondError()
+
+/* _EoF_ */
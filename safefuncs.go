@@ -0,0 +1,139 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// The type of the `error` interface.
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// `panicSite()` is a frame strategy selecting the frame that raised
+// the panic currently being recovered.
+//
+// Besides live call stacks it handles the frames parsed from a
+// goroutine dump, where the runtime prints `runtime.gopanic` as "panic".
+//
+// Parameters:
+// - `aFrames`: The frames of the calling goroutine's stack.
+//
+// Returns:
+// - `int`: The index of the panicking frame, or `0` if there's none.
+func panicSite(aFrames []Frame) int {
+	for idx, frame := range aFrames {
+		if ("runtime.gopanic" != frame.Function) && ("panic" != frame.Function) {
+			continue
+		}
+		// skip the runtime's helpers raising e.g. index panics
+		for idx++; idx < len(aFrames); idx++ {
+			if !strings.HasPrefix(aFrames[idx].Function, "runtime.") {
+				return idx
+			}
+		}
+		break
+	}
+
+	return 0
+} // panicSite()
+
+// `safeFunc()` returns a function wrapping the given template function
+// which recovers panics and returns them as located errors.
+//
+// Parameters:
+// - `aName`: The function's name within the template's function map.
+// - `aFunc`: The template function to wrap.
+//
+// Returns:
+// - `any`: The wrapping function or `aFunc` if it's not a valid
+// template function.
+func safeFunc(aName string, aFunc any) any {
+	fValue := reflect.ValueOf(aFunc)
+	fType := fValue.Type()
+	if (reflect.Func != fType.Kind()) || fValue.IsNil() {
+		return aFunc
+	}
+	switch fType.NumOut() {
+	case 1:
+	case 2:
+		if errorType != fType.Out(1) {
+			return aFunc
+		}
+	default:
+		return aFunc
+	}
+
+	in := make([]reflect.Type, fType.NumIn())
+	for idx := range in {
+		in[idx] = fType.In(idx)
+	}
+	out := []reflect.Type{fType.Out(0), errorType}
+	wType := reflect.FuncOf(in, out, fType.IsVariadic())
+
+	return reflect.MakeFunc(wType, func(aArgs []reflect.Value) (rResults []reflect.Value) {
+		defer func() {
+			if r := recover(); nil != r {
+				var err error
+				if pErr, ok := r.(error); ok {
+					err = fmt.Errorf("template function %q panicked: %w", aName, pErr)
+				} else {
+					err = fmt.Errorf("template function %q panicked: %v", aName, r)
+				}
+				err = newSource(err, 0, 0, panicSite)
+				rResults = []reflect.Value{
+					reflect.Zero(out[0]),
+					reflect.ValueOf(&err).Elem(),
+				}
+			}
+		}()
+
+		var results []reflect.Value
+		if fType.IsVariadic() {
+			results = fValue.CallSlice(aArgs)
+		} else {
+			results = fValue.Call(aArgs)
+		}
+		if 2 == len(results) {
+			return results
+		}
+
+		return []reflect.Value{results[0], reflect.Zero(errorType)}
+	}).Interface()
+} // safeFunc()
+
+// `SafeFuncs()` returns a copy of the given template function map
+// (of either `html/template` or `text/template`) whose functions
+// recover panics and return them as errors located at the code that
+// raised the panic:
+//
+//	tpl := template.New("page").Funcs(sourceerror.SafeFuncs(funcs))
+//
+// The resulting error aborts the template's execution and can be
+// found in the chain of the error returned by `Execute()` with
+// `AsSource()`.
+//
+// Parameters:
+// - `aFuncs`: The template functions to wrap.
+//
+// Returns:
+// - `M`: The map of wrapped functions.
+func SafeFuncs[M ~map[string]any](aFuncs M) M {
+	result := make(M, len(aFuncs))
+	for name, fn := range aFuncs {
+		result[name] = safeFunc(name, fn)
+	}
+
+	return result
+} // SafeFuncs()
+
+/* _EoF_ */
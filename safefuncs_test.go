@@ -0,0 +1,111 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"html/template"
+	"io"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// error raised by a test template function
+	errTemplateFunc = errors.New("template func failed")
+)
+
+func testPanicIndex(aIdx int) string {
+	list := []string{"a"}
+	return list[aIdx] // panics for aIdx > 0
+} // testPanicIndex()
+
+func testPanicError() string {
+	panic(errTemplateFunc)
+} // testPanicError()
+
+func testFailing(aText string) (string, error) {
+	if "" == aText {
+		return "", errTemplateFunc
+	}
+	return aText, nil
+} // testFailing()
+
+func testJoin(aSep string, aParts ...string) string {
+	return strings.Join(aParts, aSep)
+} // testJoin()
+
+func TestSafeFuncs(t *testing.T) {
+	funcs := SafeFuncs(template.FuncMap{
+		"index1": testPanicIndex,
+		"fail":   testPanicError,
+		"check":  testFailing,
+		"join":   testJoin,
+	})
+
+	tests := []struct {
+		name     string
+		text     string
+		wantOut  string
+		wantFunc string
+		wantIs   error
+	}{
+		{"0", `{{index1 0}}`, "a", "", nil},
+		{"1", `{{index1 1}}`, "", thisPackage + ".testPanicIndex", nil},
+		{"2", `{{fail}}`, "", thisPackage + ".testPanicError", errTemplateFunc},
+		{"3", `{{check "x"}}`, "x", "", nil},
+		{"4", `{{check ""}}`, "", "", errTemplateFunc},
+		{"5", `{{join "-" "a" "b"}}`, "a-b", "", nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			tpl := template.Must(template.New(tt.name).Funcs(funcs).Parse(tt.text))
+			err := tpl.Execute(&sb, nil)
+			if ("" == tt.wantFunc) && (nil == tt.wantIs) {
+				if nil != err {
+					t.Errorf("%q: Execute() error = %v", tt.name, err)
+				} else if sb.String() != tt.wantOut {
+					t.Errorf("%q: Execute() = %q, want %q",
+						tt.name, sb.String(), tt.wantOut)
+				}
+				return
+			}
+			if nil == err {
+				t.Errorf("%q: Execute() error = <nil>", tt.name)
+				return
+			}
+			if (nil != tt.wantIs) && !errors.Is(err, tt.wantIs) {
+				t.Errorf("%q: Execute() error = %v, want %v", tt.name, err, tt.wantIs)
+			}
+			if "" == tt.wantFunc {
+				return
+			}
+			if se, ok := AsSource(err); !ok || (se.Function != tt.wantFunc) {
+				t.Errorf("%q: Execute() location = %v, want function %q",
+					tt.name, se, tt.wantFunc)
+			}
+		})
+	}
+} // TestSafeFuncs()
+
+func TestSafeFuncs_text(t *testing.T) {
+	funcs := SafeFuncs(texttemplate.FuncMap{
+		"index1": testPanicIndex,
+	})
+	tpl := texttemplate.Must(texttemplate.New("text").Funcs(funcs).Parse(`{{index1 2}}`))
+	err := tpl.Execute(io.Discard, nil)
+	if se, ok := AsSource(err); !ok || (se.Function != thisPackage+".testPanicIndex") {
+		t.Errorf("Execute() error = %v, want location in testPanicIndex", err)
+	}
+} // TestSafeFuncs_text()
+
+/* _EoF_ */
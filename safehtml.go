@@ -0,0 +1,120 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tHTMLField` is a labelled value of the HTML representation.
+	tHTMLField struct {
+		Label string
+		Value string
+	}
+)
+
+var (
+	// The HTML fragment rendered by `SafeHTML()`; all values are
+	// escaped by the template engine.
+	errorFragment = htmltemplate.Must(htmltemplate.New("error").Parse(
+		`<div class="sourceerror">
+<p class="message">{{.Message}}</p>
+{{- with .Fields}}
+<dl>{{range .}}<dt>{{.Label}}</dt><dd>{{.Value}}</dd>{{end}}</dl>
+{{- end}}
+{{- with .Chain}}
+<ol class="chain" start="0">{{range .}}<li>{{.}}</li>{{end}}</ol>
+{{- end}}
+{{- with .Stack}}
+<pre class="stack">{{.}}</pre>
+{{- end}}
+</div>`))
+)
+
+// `SafeHTML()` returns an HTML representation of the given error for
+// debug pages, with the message and all other values escaped, so that
+// user influenced error messages can't inject markup or scripts:
+//
+//	<div class="sourceerror">
+//	<p class="message">…</p>
+//	<dl><dt>File</dt><dd>…</dd>…</dl>
+//	<ol class="chain" start="0"><li>…</li>…</ol>
+//	<pre class="stack">…</pre>
+//	</div>
+//
+// Parameters:
+// - `aErr`: The error to render.
+//
+// Returns:
+// - `htmltemplate.HTML`: The error's HTML representation or an empty
+// string for `nil`.
+func SafeHTML(aErr error) htmltemplate.HTML {
+	if nil == aErr {
+		return ""
+	}
+
+	data := struct {
+		Message string
+		Fields  []tHTMLField
+		Chain   []string
+		Stack   string
+	}{
+		Message: aErr.Error(),
+	}
+	if se, ok := asSource(aErr); ok {
+		add := func(aLabel, aValue string) {
+			if "" != aValue {
+				data.Fields = append(data.Fields, tHTMLField{aLabel, aValue})
+			}
+		}
+		add("ID", se.ID)
+		if "" != se.File {
+			add("Location", se.Location().String())
+			add("Function", se.Function)
+		}
+		if nil != se.External {
+			add("External", se.External.String())
+		}
+		if nil != se.Input {
+			add("Input", se.Input.String())
+		}
+		if 0 < se.Elapsed {
+			add("Elapsed", se.Elapsed.String())
+		}
+		add("Phase", se.Phase)
+		add("Test", se.Test)
+		if 0 < se.Seq {
+			add("Seq", strconv.FormatUint(se.Seq, 10))
+		}
+		add("Stack omitted", se.OmitReason)
+
+		if layers := se.layers(); 1 < len(layers) {
+			for _, loc := range layers {
+				if "" == loc.File {
+					data.Chain = append(data.Chain, "-")
+					continue
+				}
+				data.Chain = append(data.Chain, loc.String()+" "+loc.Function)
+			}
+		}
+		data.Stack = string(demangleStack(foldStack(se.StackTrace())))
+	}
+
+	var sb strings.Builder
+	if err := errorFragment.Execute(&sb, data); nil != err {
+		return htmltemplate.HTML(htmltemplate.HTMLEscapeString(aErr.Error()))
+	}
+
+	return htmltemplate.HTML(sb.String())
+} // SafeHTML()
+
+/* _EoF_ */
@@ -0,0 +1,56 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestSafeHTML(t *testing.T) {
+	evil := errors.New(`user "<script>alert(1)</script>" not found`)
+	cl1 := Wrap(evil, 0)
+	cl2 := WithPhase(Wrap(cl1, 0), "<b>startup</b>")
+
+	tests := []struct {
+		name    string
+		err     error
+		want    []string
+		wantNot []string
+	}{
+		{"0", nil, nil, []string{"<div"}},
+		{"1", evil, []string{`<p class="message">user &#34;&lt;script&gt;alert(1)&lt;/script&gt;&#34; not found</p>`},
+			[]string{"<script>", "<dl>", "<pre"}},
+		{"2", cl1, []string{"<dt>Location</dt>", "safehtml_test.go:", `<pre class="stack">`},
+			[]string{"<script>", `class="chain"`}},
+		{"3", cl2, []string{`<ol class="chain" start="0">`, "&lt;b&gt;startup&lt;/b&gt;"},
+			[]string{"<script>", "<b>"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(SafeHTML(tt.err))
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("%q: SafeHTML() =\n%s\nwant containing %q",
+						tt.name, got, want)
+				}
+			}
+			for _, want := range tt.wantNot {
+				if strings.Contains(got, want) {
+					t.Errorf("%q: SafeHTML() =\n%s\nwant not containing %q",
+						tt.name, got, want)
+				}
+			}
+		})
+	}
+} // TestSafeHTML()
+
+/* _EoF_ */
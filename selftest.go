@@ -0,0 +1,94 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `ErrDegraded` is wrapped by all findings of `SelfTest()`.
+	ErrDegraded = errors.New("degraded")
+)
+
+// `degraded()` returns a finding of `SelfTest()`.
+//
+// Parameters:
+// - `aFeature`: The affected feature.
+// - `aFormat`: The format string of the finding's reason.
+// - `aArgs`: The arguments for `aFormat`.
+//
+// Returns:
+// - `error`: The finding wrapping `ErrDegraded`.
+func degraded(aFeature, aFormat string, aArgs ...any) error {
+	return fmt.Errorf("%s %w: %s", aFeature, ErrDegraded,
+		fmt.Sprintf(aFormat, aArgs...))
+} // degraded()
+
+// `SelfTest()` checks whether the package's features work in the
+// current environment and configuration, e.g. to log capability
+// warnings at program start instead of discovering missing locations
+// or call stacks during an incident:
+//
+//	if err := sourceerror.SelfTest(); nil != err {
+//		log.Printf("error reporting: %v", err)
+//	}
+//
+// The checks cover the capture of locations and call stacks, source
+// paths shortened by `-trimpath`, the availability of the build info
+// (used for module versions), and the settings of `DefaultConfig()`
+// (as well as the deprecated `NODEBUG`/`NOSTACK` flags).
+//
+// Returns:
+// - `error`: `nil` if all features are available, otherwise the joined
+// findings (each wrapping `ErrDegraded`).
+func SelfTest() error {
+	var errs []error
+
+	if defaultConfig.noDebug() {
+		errs = append(errs, degraded("locations", "disabled by configuration"))
+	}
+	if defaultConfig.noStacks() || defaultConfig.noDebug() {
+		errs = append(errs, degraded("call stacks", "disabled by configuration"))
+	}
+
+	loc, ok := callerLocation(0, Raw)
+	switch {
+	case !ok || ("" == loc.File) || (0 >= loc.Line):
+		errs = append(errs, degraded("locations",
+			"runtime.Caller() can't resolve source positions on %s/%s",
+			runtime.GOOS, runtime.GOARCH))
+	case thisPackage+".SelfTest" != loc.Function:
+		errs = append(errs, degraded("function names",
+			"resolved %q instead of %q", loc.Function, thisPackage+".SelfTest"))
+	case !filepath.IsAbs(loc.File):
+		errs = append(errs, degraded("source paths",
+			"module relative %q (built with -trimpath?)", loc.File))
+	}
+
+	se := ErrSource{
+		Stack: debug.Stack(),
+	}
+	if 0 == len(se.Frames()) {
+		errs = append(errs, degraded("call stacks",
+			"debug.Stack() can't be parsed on %s/%s", runtime.GOOS, runtime.GOARCH))
+	}
+
+	if _, ok := debug.ReadBuildInfo(); !ok {
+		errs = append(errs, degraded("module versions", "no build info available"))
+	}
+
+	return errors.Join(errs...)
+} // SelfTest()
+
+/* _EoF_ */
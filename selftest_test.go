@@ -0,0 +1,53 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestSelfTest(t *testing.T) {
+	defer UseProfile(CurrentProfile())
+
+	tests := []struct {
+		name     string
+		profile  Profile
+		wantText []string
+	}{
+		{"0", Development, nil},
+		{"1", Staging, []string{"call stacks"}},
+		{"2", Production, []string{"locations", "call stacks"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			UseProfile(tt.profile)
+			err := SelfTest()
+			if 0 == len(tt.wantText) {
+				if nil != err {
+					t.Errorf("%q: SelfTest() = %v, want <nil>", tt.name, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrDegraded) {
+				t.Errorf("%q: SelfTest() = %v, want %v", tt.name, err, ErrDegraded)
+				return
+			}
+			for _, text := range tt.wantText {
+				if !strings.Contains(err.Error(), text) {
+					t.Errorf("%q: SelfTest() = %v, want %q", tt.name, err, text)
+				}
+			}
+		})
+	}
+} // TestSelfTest()
+
+/* _EoF_ */
@@ -0,0 +1,69 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"sync/atomic"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// Whether errors get sequence numbers (checked without locking).
+	seqOn atomic.Bool
+
+	// The last sequence number assigned.
+	seqLast atomic.Uint64
+)
+
+// `nextSeq()` returns the next sequence number if sequencing is
+// enabled.
+//
+// Returns:
+// - `uint64`: The next sequence number or `0` if sequencing is disabled.
+func nextSeq() uint64 {
+	if !seqOn.Load() {
+		return 0
+	}
+
+	return seqLast.Add(1)
+} // nextSeq()
+
+// `Sequence()` returns the sequence number of the outermost `ErrSource`
+// in the given error's chain (see `SetSequencing()`).
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `uint64`: The error's sequence number or `0` if it has none.
+func Sequence(aErr error) uint64 {
+	if se, ok := asSource(aErr); ok {
+		return se.Seq
+	}
+
+	return 0
+} // Sequence()
+
+// `SetSequencing()` enables or disables numbering the errors wrapped
+// by this package.
+//
+// The numbers are taken from a process-wide counter, so that errors
+// of concurrent goroutines can be totally ordered in postmortems even
+// if their timestamps collide or aren't recorded at all. The counter
+// continues where it stopped when sequencing is enabled again.
+//
+// NOTE: Sequence numbers are assigned even if locations are disabled
+// (see `Config.SetEnabled()`).
+//
+// Parameters:
+// - `aEnabled`: Whether to number the errors.
+func SetSequencing(aEnabled bool) {
+	seqOn.Store(aEnabled)
+} // SetSequencing()
+
+/* _EoF_ */
@@ -0,0 +1,67 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestSetSequencing(t *testing.T) {
+	e := errors.New("some first error")
+	defer func(aNoDebug bool) {
+		SetSequencing(false)
+		NODEBUG = aNoDebug
+	}(NODEBUG)
+
+	if got := Sequence(Wrap(e, 0)); 0 != got {
+		t.Errorf("Sequence() = %d while disabled, want 0", got)
+	}
+
+	SetSequencing(true)
+	const workers, perWorker = 8, 50
+	var (
+		wg   sync.WaitGroup
+		mtx  sync.Mutex
+		seen = make(map[uint64]bool, workers*perWorker)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				seq := Sequence(Wrap(e, 0))
+				mtx.Lock()
+				if (0 == seq) || seen[seq] {
+					t.Errorf("Sequence() = %d, want unique non-zero", seq)
+				}
+				seen[seq] = true
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	first, second := Wrap(e, 0), Wrap(e, 0)
+	if Sequence(first) >= Sequence(second) {
+		t.Errorf("Sequence() = %d, %d, want increasing",
+			Sequence(first), Sequence(second))
+	}
+
+	NODEBUG = true
+	if got := Sequence(Wrap(e, 0)); 0 == got {
+		t.Error("Sequence() = 0 with NODEBUG, want non-zero")
+	}
+	if got := Sequence(e); 0 != got {
+		t.Errorf("Sequence() = %d for plain error, want 0", got)
+	}
+} // TestSetSequencing()
+
+/* _EoF_ */
@@ -0,0 +1,147 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bufio"
+	"log"
+	"regexp"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `ServerError` is an error logged by a `net/http` server, e.g. a
+	// failed TLS handshake or a panicking handler.
+	//
+	// The fields are as follows:
+	// - `Message`: The logged message (without a goroutine dump).
+	// - `RemoteAddr`: The address of the client involved (if any).
+	ServerError struct {
+		Message    string
+		RemoteAddr string
+	}
+
+	// `tServerLog` is the `io.Writer` of the logger returned by
+	// `ServerErrorLog()`.
+	tServerLog struct {
+		report func(error)
+	}
+)
+
+var (
+	// Regular expression matching the client address of a server's log
+	// line, e.g.
+	//
+	//	http: TLS handshake error from 192.0.2.1:50624: EOF
+	//	http: panic serving 192.0.2.1:50626: boom
+	//	http2: server: error reading preface from client [::1]:4711: EOF
+	reServerAddr = regexp.MustCompile(`^http2?: .*?(?:from|serving)(?: client)? (\S+): `)
+)
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+// - `string`: The logged message.
+func (e *ServerError) Error() string {
+	return e.Message
+} // Error()
+
+// `Write()` implements the `io.Writer` interface, turning each log
+// message into an error.
+//
+// Parameters:
+// - `aMessage`: The log message written by the server.
+//
+// Returns:
+// - `int`: The number of bytes processed, i.e. `len(aMessage)`.
+// - `error`: Always `nil`.
+func (sl *tServerLog) Write(aMessage []byte) (int, error) {
+	if err := serverError(string(aMessage)); nil != err {
+		_ = Report(err)
+		if nil != sl.report {
+			sl.report(err)
+		}
+	}
+
+	return len(aMessage), nil
+} // Write()
+
+// --------------------------------------------------------------------------
+
+// `serverError()` returns the error described by the given log message
+// of a `net/http` server.
+//
+// Messages with a goroutine dump (i.e. from a panicking handler) are
+// returned as an `ErrSource` located at the panicking function.
+//
+// Parameters:
+// - `aMessage`: The log message to parse.
+//
+// Returns:
+// - `error`: The logged error or `nil` for an empty message.
+func serverError(aMessage string) error {
+	aMessage = strings.TrimRight(aMessage, "\r\n")
+	if "" == strings.TrimSpace(aMessage) {
+		return nil
+	}
+
+	message, dump, _ := strings.Cut(aMessage, "\n")
+	result := &ServerError{
+		Message: message,
+	}
+	if match := reServerAddr.FindStringSubmatch(message); nil != match {
+		result.RemoteAddr = match[1]
+	}
+	if !strings.HasPrefix(dump, "goroutine ") {
+		if "" != dump {
+			result.Message = aMessage
+		}
+		return result
+	}
+
+	se := newInstance(result)
+	se.Stack = []byte(dump + "\n")
+	scanner := bufio.NewScanner(strings.NewReader(dump))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	if goroutines, _ := parseGoroutines(scanner); 0 < len(goroutines) {
+		if frames := goroutines[0].Frames; 0 < len(frames) {
+			frame := frames[panicSite(frames)]
+			se.File, se.Function, se.Line = frame.File, frame.Function, frame.Line
+		}
+	}
+
+	return se
+} // serverError()
+
+// `ServerErrorLog()` returns a logger for the `ErrorLog` field of an
+// `http.Server`, turning the server's log messages into errors:
+//
+//	server := &http.Server{
+//		Addr:     ":8080",
+//		ErrorLog: sourceerror.ServerErrorLog(nil),
+//	}
+//
+// Each message is returned as a `*ServerError` with the client's
+// address (if any); messages of panicking handlers are returned as an
+// `ErrSource` wrapping the `*ServerError`, located at the panicking
+// function and carrying the logged call stack.
+//
+// The errors are reported to all sinks registered by `RegisterSink()`
+// and passed to the given function.
+//
+// Parameters:
+// - `aReport`: An optional function to receive the errors.
+//
+// Returns:
+// - `*log.Logger`: The logger for the server.
+func ServerErrorLog(aReport func(error)) *log.Logger {
+	return log.New(&tServerLog{report: aReport}, "", 0)
+} // ServerErrorLog()
+
+/* _EoF_ */
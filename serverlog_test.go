@@ -0,0 +1,116 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `testPanicHandler()` is a HTTP handler panicking at a known location.
+func testPanicHandler(http.ResponseWriter, *http.Request) {
+	panic("handler exploded")
+} // testPanicHandler()
+
+func Test_serverError(t *testing.T) {
+	dump := "http: panic serving 192.0.2.1:50626: boom\n" +
+		"goroutine 7 [running]:\n" +
+		"net/http.(*conn).serve.func1()\n\t/go/src/net/http/server.go:1898 +0xbe\n" +
+		"panic({0x6a5f20?, 0x7f1b40?})\n\t/go/src/runtime/panic.go:770 +0x132\n" +
+		"main.handler({0x7f5d88, 0xc0001a8000}, 0xc0001b4000)\n\t/src/main.go:12 +0x25\n"
+
+	tests := []struct {
+		name     string
+		message  string
+		wantMsg  string
+		wantAddr string
+		wantLoc  string
+	}{
+		{"0", "\n", "", "", ""},
+		{"1", "http: TLS handshake error from 192.0.2.1:50624: EOF\n",
+			"http: TLS handshake error from 192.0.2.1:50624: EOF", "192.0.2.1:50624", ""},
+		{"2", "http: Accept error: too many files; retrying in 5ms\n",
+			"http: Accept error: too many files; retrying in 5ms", "", ""},
+		{"3", dump, "http: panic serving 192.0.2.1:50626: boom", "192.0.2.1:50626", "/src/main.go:12"},
+		{"4", "http2: server: error reading preface from client [::1]:4711: EOF",
+			"http2: server: error reading preface from client [::1]:4711: EOF", "[::1]:4711", ""},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := serverError(tt.message)
+			if "" == tt.wantMsg {
+				if nil != err {
+					t.Errorf("%q: serverError() = %v, want <nil>", tt.name, err)
+				}
+				return
+			}
+			var se *ServerError
+			if !errors.As(err, &se) {
+				t.Errorf("%q: serverError() = %T, want *ServerError", tt.name, err)
+				return
+			}
+			if (se.Message != tt.wantMsg) || (se.RemoteAddr != tt.wantAddr) {
+				t.Errorf("%q: serverError() = %q, %q, want %q, %q",
+					tt.name, se.Message, se.RemoteAddr, tt.wantMsg, tt.wantAddr)
+			}
+			var loc Location
+			As(err, &loc)
+			if got := loc.String(); ("" != tt.wantLoc) && (got != tt.wantLoc) {
+				t.Errorf("%q: serverError() location = %q, want %q",
+					tt.name, got, tt.wantLoc)
+			}
+		})
+	}
+} // Test_serverError()
+
+func TestServerErrorLog(t *testing.T) {
+	var (
+		mtx  sync.Mutex
+		errs []error
+		done = make(chan struct{}, 1)
+	)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(testPanicHandler))
+	server.Config.ErrorLog = ServerErrorLog(func(aErr error) {
+		mtx.Lock()
+		errs = append(errs, aErr)
+		mtx.Unlock()
+		done <- struct{}{}
+	})
+	server.Start()
+	defer server.Close()
+
+	if resp, err := http.Get(server.URL); nil == err {
+		resp.Body.Close()
+	}
+	<-done
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	if 1 != len(errs) {
+		t.Fatalf("ServerErrorLog() reported %d errors, want 1", len(errs))
+	}
+	se, ok := AsSource(errs[0])
+	if !ok {
+		t.Fatalf("ServerErrorLog() reported %T, want *ErrSource", errs[0])
+	}
+	if !strings.HasSuffix(se.Function, ".testPanicHandler") {
+		t.Errorf("ServerErrorLog() function = %q, want testPanicHandler",
+			se.Function)
+	}
+	if !strings.Contains(se.Error(), "handler exploded") {
+		t.Errorf("ServerErrorLog() message = %q, want panic value", se.Error())
+	}
+} // TestServerErrorLog()
+
+/* _EoF_ */
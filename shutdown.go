@@ -0,0 +1,95 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// The registered shutdown hooks.
+	shutdownHooks []func()
+
+	// Guard for `shutdownHooks`.
+	shutdownMtx sync.Mutex
+
+	// Where `Fatal()` writes the error to (replaceable for testing).
+	fatalWriter io.Writer = os.Stderr
+
+	// The function used to terminate the program (replaceable
+	// for testing).
+	osExit = os.Exit
+)
+
+// `Fatal()` reports the given error, runs all registered shutdown
+// hooks (see `RegisterShutdownHook()`) and terminates the program with
+// exit code `1`.
+//
+// Before that, the error is passed to the hooks registered by
+// `RegisterFatalHook()`.
+//
+// Parameters:
+// - `aErr`: The error causing the program's termination.
+func Fatal(aErr error) {
+	notifyFatalHooks(aErr)
+	if nil != aErr {
+		fmt.Fprint(fatalWriter, render(aErr, VerbosityFull))
+	}
+	RunShutdownHooks()
+	osExit(1)
+} // Fatal()
+
+// `RegisterShutdownHook()` registers a function to be called by
+// `RunShutdownHooks()`, e.g. to flush journals or close sinks before
+// the program terminates.
+//
+// Parameters:
+// - `aHook`: The function to call on shutdown.
+func RegisterShutdownHook(aHook func()) {
+	if nil == aHook {
+		return
+	}
+
+	shutdownMtx.Lock()
+	defer shutdownMtx.Unlock()
+
+	shutdownHooks = append(shutdownHooks, aHook)
+} // RegisterShutdownHook()
+
+// `RunShutdownHooks()` calls all registered shutdown hooks in reverse
+// order of their registration (like deferred functions).
+//
+// From now on all errors are stamped with the `PhaseShutdown` phase
+// (see `ShuttingDown()`).
+//
+// Each hook is called only once even if this function is called
+// several times (e.g. by the application's signal handling and by
+// `Fatal()`). A panicking hook doesn't prevent the others from running.
+func RunShutdownHooks() {
+	shuttingDown.Store(true)
+
+	shutdownMtx.Lock()
+	hooks := shutdownHooks
+	shutdownHooks = nil
+	shutdownMtx.Unlock()
+
+	for idx := len(hooks) - 1; 0 <= idx; idx-- {
+		func() {
+			defer func() {
+				_ = recover()
+			}()
+			hooks[idx]()
+		}()
+	}
+} // RunShutdownHooks()
+
+/* _EoF_ */
@@ -0,0 +1,59 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestFatal(t *testing.T) {
+	var (
+		buf      bytes.Buffer
+		calls    []string
+		exitCode = -1
+	)
+	fatalWriter, osExit = &buf, func(aCode int) { exitCode = aCode }
+	defer func() {
+		fatalWriter, osExit = os.Stderr, os.Exit
+		shuttingDown.Store(false)
+	}()
+
+	RegisterShutdownHook(func() { calls = append(calls, "first") })
+	RegisterShutdownHook(func() { panic("second") })
+	RegisterShutdownHook(nil)
+	RegisterShutdownHook(func() { calls = append(calls, "third") })
+
+	Fatal(Wrap(errors.New("some fatal error"), 0))
+
+	if 1 != exitCode {
+		t.Errorf("Fatal() exit code = %d, want 1", exitCode)
+	}
+	if !strings.Contains(buf.String(), "some fatal error") {
+		t.Errorf("Fatal() output = %q, want error message", buf.String())
+	}
+	if !strings.Contains(buf.String(), "TestFatal") {
+		t.Errorf("Fatal() output = %q, want error location", buf.String())
+	}
+	if got := strings.Join(calls, ","); "third,first" != got {
+		t.Errorf("Fatal() hooks = %q, want %q", got, "third,first")
+	}
+
+	// hooks are called only once
+	calls = nil
+	RunShutdownHooks()
+	if 0 != len(calls) {
+		t.Errorf("RunShutdownHooks() called hooks again: %v", calls)
+	}
+} // TestFatal()
+
+/* _EoF_ */
@@ -0,0 +1,154 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tSigned` is the envelope of a signed error record.
+	tSigned struct {
+		Payload   json.RawMessage `json:"payload"`
+		Algorithm string          `json:"alg"`
+		Signature string          `json:"sig"`
+	}
+)
+
+const (
+	// The signature algorithm used by `SignedEncode()`.
+	signAlgorithm = "HS256"
+)
+
+var (
+	// Error returned if no signing key is given.
+	errNoKey = errors.New("empty signing key")
+
+	// Error returned if there's no error to encode.
+	errNilError = errors.New("nil error")
+
+	// Error returned if a signature doesn't match its payload.
+	errBadSignature = errors.New("invalid signature")
+)
+
+// `recordOf()` returns the serialisable representation of the given
+// error; errors without location data are represented by their
+// message only.
+//
+// Parameters:
+// - `aErr`: The error to convert.
+//
+// Returns:
+// - `*tRecord`: The error's serialisable representation.
+func recordOf(aErr error) *tRecord {
+	if se, ok := asSource(aErr); ok {
+		return newRecord(se)
+	}
+
+	return &tRecord{
+		Version: recordVersion,
+		Message: aErr.Error(),
+	}
+} // recordOf()
+
+// `signature()` returns the HMAC-SHA256 of the given data.
+//
+// Parameters:
+// - `aData`: The data to sign.
+// - `aKey`: The secret key.
+//
+// Returns:
+// - `[]byte`: The data's signature.
+func signature(aData, aKey []byte) []byte {
+	mac := hmac.New(sha256.New, aKey)
+	mac.Write(aData)
+
+	return mac.Sum(nil)
+} // signature()
+
+// `SignedEncode()` serialises the given error (as JSON) and signs it
+// with the given key so that a collector can verify the report's
+// origin and integrity with `VerifyDecode()`.
+//
+// Parameters:
+// - `aErr`: The error to encode.
+// - `aKey`: The secret key shared with the receiver.
+//
+// Returns:
+// - `[]byte`: The signed error report.
+// - `error`: A possible error during encoding.
+func SignedEncode(aErr error, aKey []byte) ([]byte, error) {
+	if 0 == len(aKey) {
+		return nil, errNoKey
+	}
+	if nil == aErr {
+		return nil, errNilError
+	}
+
+	payload, err := json.Marshal(recordOf(aErr))
+	if nil != err {
+		return nil, err
+	}
+
+	return json.Marshal(tSigned{
+		Payload:   payload,
+		Algorithm: signAlgorithm,
+		Signature: hex.EncodeToString(signature(payload, aKey)),
+	})
+} // SignedEncode()
+
+// `VerifyDecode()` verifies the signature of an error report created
+// by `SignedEncode()` and restores the error.
+//
+// NOTE: The wrapped error is restored as a plain error with the
+// original error's message only.
+//
+// Parameters:
+// - `aData`: The signed error report.
+// - `aKey`: The secret key shared with the sender.
+//
+// Returns:
+// - `*ErrSource`: The restored error.
+// - `error`: An error if the report is malformed or its signature
+// is invalid.
+func VerifyDecode(aData, aKey []byte) (*ErrSource, error) {
+	if 0 == len(aKey) {
+		return nil, errNoKey
+	}
+
+	var signed tSigned
+	if err := json.Unmarshal(aData, &signed); nil != err {
+		return nil, err
+	}
+	if signAlgorithm != signed.Algorithm {
+		return nil, fmt.Errorf("unsupported signature algorithm %q",
+			signed.Algorithm)
+	}
+	sig, err := hex.DecodeString(signed.Signature)
+	if nil != err {
+		return nil, errBadSignature
+	}
+	if !hmac.Equal(sig, signature(signed.Payload, aKey)) {
+		return nil, errBadSignature
+	}
+
+	record, err := upgradeJSON(signed.Payload)
+	if nil != err {
+		return nil, err
+	}
+
+	return record.source(), nil
+} // VerifyDecode()
+
+/* _EoF_ */
@@ -0,0 +1,91 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestSignedEncode(t *testing.T) {
+	key := []byte("secret")
+	e := errors.New("some first error")
+	tests := []struct {
+		name    string
+		err     error
+		key     []byte
+		wantErr bool
+	}{
+		{"0", nil, key, true},
+		{"1", e, nil, true},
+		{"2", e, key, false},
+		{"3", Wrap(e, 0), key, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := SignedEncode(tt.err, tt.key)
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: SignedEncode() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if nil != err {
+				return
+			}
+			got, err := VerifyDecode(data, tt.key)
+			if nil != err {
+				t.Errorf("%q: VerifyDecode() error = %v", tt.name, err)
+				return
+			}
+			if got.message() != e.Error() {
+				t.Errorf("%q: VerifyDecode() message = %q, want %q",
+					tt.name, got.message(), e.Error())
+			}
+			if se, ok := asSource(tt.err); ok && (got.Location() != se.Location()) {
+				t.Errorf("%q: VerifyDecode() location = %v, want %v",
+					tt.name, got.Location(), se.Location())
+			}
+		})
+	}
+} // TestSignedEncode()
+
+func TestVerifyDecode(t *testing.T) {
+	key := []byte("secret")
+	data, err := SignedEncode(Wrap(errors.New("some first error"), 0), key)
+	if nil != err {
+		t.Fatalf("SignedEncode() = %v", err)
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		key     []byte
+		wantErr bool
+	}{
+		{"0", data, key, false},
+		{"1", data, []byte("other"), true},
+		{"2", data, nil, true},
+		{"3", bytes.Replace(data, []byte("first"), []byte("final"), 1), key, true},
+		{"4", bytes.Replace(data, []byte("HS256"), []byte("none"), 1), key, true},
+		{"5", []byte("garbage"), key, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyDecode(tt.data, tt.key)
+			if (nil != err) != tt.wantErr {
+				t.Errorf("%q: VerifyDecode() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+		})
+	}
+} // TestVerifyDecode()
+
+/* _EoF_ */
@@ -0,0 +1,213 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `Verbosity` selects how detailed an error is rendered by `Report()`.
+	Verbosity int
+
+	// `tSink` is a destination registered by `RegisterSink()`.
+	tSink struct {
+		mtx       sync.Mutex // serialises the writes
+		writer    io.Writer
+		verbosity Verbosity
+	}
+)
+
+const (
+	// `VerbosityCompact` renders a single line with the error's
+	// location, message, and ID.
+	VerbosityCompact Verbosity = iota
+
+	// `VerbosityMedium` adds the locations of the chain's layers and
+	// the error's owner (see `LoadOwners()`) to the compact line.
+	VerbosityMedium
+
+	// `VerbosityFull` renders all the error's data including the
+	// call stack.
+	VerbosityFull
+
+	// `VerbosityDebug` adds the call stacks of all goroutines (at the
+	// time of rendering) to the full rendering.
+	VerbosityDebug
+)
+
+var (
+	// The currently registered sinks.
+	sinks []*tSink
+
+	// Guard for `sinks`.
+	sinkMtx sync.RWMutex
+)
+
+// `String()` implements the `Stringer` interface.
+//
+// Returns:
+// - `string`: The verbosity's name.
+func (v Verbosity) String() string {
+	switch v {
+	case VerbosityCompact:
+		return "compact"
+	case VerbosityMedium:
+		return "medium"
+	case VerbosityFull:
+		return "full"
+	case VerbosityDebug:
+		return "debug"
+	case VerbosityDefault:
+		return "default"
+	}
+
+	return fmt.Sprintf("Verbosity(%d)", int(v))
+} // String()
+
+// --------------------------------------------------------------------------
+
+// `render()` returns the text of the given error with the given
+// verbosity.
+//
+// The detailed renderings start with the text of the whole chain if
+// the outermost `ErrSource` is wrapped by other errors adding text.
+//
+// Parameters:
+// - `aErr`: The error to render.
+// - `aVerbosity`: How detailed to render the error.
+//
+// Returns:
+// - `string`: The error's text ending with a newline.
+func render(aErr error, aVerbosity Verbosity) string {
+	se, ok := asSource(aErr)
+	if !ok {
+		return aErr.Error() + "\n"
+	}
+	if VerbosityFull <= aVerbosity {
+		var detail string
+		if message := aErr.Error(); message != se.Error() {
+			// keep the text added by the wrapping errors
+			detail = message + "\n"
+		}
+		detail += se.String() + "\n"
+		if VerbosityDebug <= aVerbosity {
+			detail += "Goroutines:\n" + allStacks()
+		}
+		return detail
+	}
+
+	var sb strings.Builder
+	if "" != se.File {
+		sb.WriteString(se.Location().String() + ": ")
+	}
+	sb.WriteString(aErr.Error())
+	if "" != se.ID {
+		fmt.Fprintf(&sb, " (error ID %s)", se.ID)
+	}
+	sb.WriteString("\n")
+	if VerbosityMedium > aVerbosity {
+		return sb.String()
+	}
+
+	if layers := se.layers(); 1 < len(layers) {
+		for idx, loc := range layers {
+			if "" != loc.File {
+				fmt.Fprintf(&sb, "\t[%d] %s %s\n", idx, loc, loc.Function)
+			}
+		}
+	}
+	if owner := Owner(se); "" != owner {
+		fmt.Fprintf(&sb, "\tOwner: %s\n", owner)
+	}
+
+	return sb.String()
+} // render()
+
+// `RegisterSink()` registers a destination for the errors reported
+// by `Report()`, each rendered with the given verbosity:
+//
+//	defer sourceerror.RegisterSink(os.Stderr, sourceerror.VerbosityCompact)()
+//	defer sourceerror.RegisterSink(journal, sourceerror.VerbosityFull)()
+//
+// Parameters:
+// - `aWriter`: The destination to write the rendered errors to.
+// - `aVerbosity`: How detailed to render the errors.
+//
+// Returns:
+// - `func()`: A function removing the registered sink.
+func RegisterSink(aWriter io.Writer, aVerbosity Verbosity) func() {
+	sink := &tSink{
+		writer:    aWriter,
+		verbosity: aVerbosity,
+	}
+
+	sinkMtx.Lock()
+	sinks = append(sinks, sink)
+	sinkMtx.Unlock()
+
+	return func() {
+		sinkMtx.Lock()
+		defer sinkMtx.Unlock()
+
+		for idx, s := range sinks {
+			if s == sink {
+				sinks = append(sinks[:idx:idx], sinks[idx+1:]...)
+				break
+			}
+		}
+	}
+} // RegisterSink()
+
+// `Report()` writes the given error to all sinks registered by
+// `RegisterSink()`, each rendered with the sink's verbosity unless
+// overridden for the error's package by `SetVerbosity()`.
+//
+// Parameters:
+// - `aErr`: The error to report.
+//
+// Returns:
+// - `error`: The joined errors of the sinks that failed to write.
+func Report(aErr error) error {
+	if nil == aErr {
+		return nil
+	}
+
+	sinkMtx.RLock()
+	list := append([]*tSink(nil), sinks...)
+	sinkMtx.RUnlock()
+
+	var (
+		errs  []error
+		texts = make(map[Verbosity]string, 3)
+	)
+	for _, sink := range list {
+		verbosity := packageVerbosity(aErr, sink.verbosity)
+		text, ok := texts[verbosity]
+		if !ok {
+			text = render(aErr, verbosity)
+			texts[verbosity] = text
+		}
+
+		sink.mtx.Lock()
+		_, err := io.WriteString(sink.writer, text)
+		sink.mtx.Unlock()
+		if nil != err {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+} // Report()
+
+/* _EoF_ */
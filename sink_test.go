@@ -0,0 +1,95 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// a writer always failing
+	tFailWriter struct{}
+)
+
+func (tFailWriter) Write([]byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
+
+func Test_render(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	cl2 := Wrap(fmt.Errorf("repo: %w", cl1), 0)
+
+	tests := []struct {
+		name      string
+		err       error
+		verbosity Verbosity
+		wantLines int
+		wantText  string
+	}{
+		{"0", e, VerbosityFull, 1, "some first error"},
+		{"1", cl1, VerbosityCompact, 1, "sink_test.go:"},
+		{"2", cl2, VerbosityCompact, 1, "some first error (error ID " + ID(cl2)},
+		{"3", cl2, VerbosityMedium, 3, "\t[1] "},
+		{"4", cl1, VerbosityFull, 0, "Stack: goroutine"},
+		{"5", fmt.Errorf("handler: %w", cl2), VerbosityCompact, 1, ": handler: repo: some first error (error ID " + ID(cl2)},
+		{"6", fmt.Errorf("saving invoice 42: %w", cl1), VerbosityFull, 0, "saving invoice 42: some first error\nID: " + ID(cl1)},
+		{"7", fmt.Errorf("saving invoice 42: %w", cl1), VerbosityDebug, 0, "saving invoice 42: some first error\nID: "},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := render(tt.err, tt.verbosity)
+			if !strings.HasSuffix(got, "\n") {
+				t.Errorf("%q: render() = %q, want trailing newline", tt.name, got)
+			}
+			if n := strings.Count(got, "\n"); (0 < tt.wantLines) && (n != tt.wantLines) {
+				t.Errorf("%q: render() = %d lines, want %d:\n%s",
+					tt.name, n, tt.wantLines, got)
+			}
+			if !strings.Contains(got, tt.wantText) {
+				t.Errorf("%q: render() =\n%s\nwant %q", tt.name, got, tt.wantText)
+			}
+		})
+	}
+} // Test_render()
+
+func TestReport(t *testing.T) {
+	var compact, full strings.Builder
+	removeCompact := RegisterSink(&compact, VerbosityCompact)
+	removeFull := RegisterSink(&full, VerbosityFull)
+	removeFail := RegisterSink(tFailWriter{}, VerbosityMedium)
+
+	cl1 := Wrap(errors.New("some first error"), 0)
+	if err := Report(cl1); !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("Report() = %v, want %v", err, io.ErrShortWrite)
+	}
+	removeFail()
+	if err := Report(cl1); nil != err {
+		t.Errorf("Report() = %v, want <nil>", err)
+	}
+	removeFull()
+	removeCompact()
+	if err := Report(cl1); nil != err {
+		t.Errorf("Report() = %v, want <nil>", err)
+	}
+
+	if got := strings.Count(compact.String(), "\n"); 2 != got {
+		t.Errorf("Report() compact = %d lines, want 2:\n%s", got, compact.String())
+	}
+	if got := strings.Count(full.String(), "Stack: "); 2 != got {
+		t.Errorf("Report() full = %d stacks, want 2", got)
+	}
+} // TestReport()
+
+/* _EoF_ */
@@ -0,0 +1,103 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"unsafe"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// The sizes of the types involved in the estimation.
+	sizeOfSource   = int(unsafe.Sizeof(ErrSource{}))
+	sizeOfLocation = int(unsafe.Sizeof(Location{}))
+	sizeOfPosition = int(unsafe.Sizeof(Position{}))
+	sizeOfFrame    = int(unsafe.Sizeof(Frame{}))
+	sizeOfForeign  = int(unsafe.Sizeof(ForeignStack{}))
+	sizeOfError    = int(unsafe.Sizeof(errors.New("")))
+	sizeOfPC       = int(unsafe.Sizeof(uintptr(0)))
+)
+
+// `sourceSize()` estimates the memory retained by the given instance
+// (not counting the wrapped error).
+//
+// Parameters:
+// - `aSource`: The instance to inspect.
+//
+// Returns:
+// - `int`: The estimated size in bytes.
+func sourceSize(aSource *ErrSource) int {
+	result := sizeOfSource +
+		len(aSource.ID) + len(aSource.File) + len(aSource.Function) +
+		cap(aSource.Stack) + sizeOfPC*cap(aSource.pcs) + len(aSource.OmitReason) + len(aSource.Phase) +
+		len(aSource.Test)
+	if nil != aSource.Input {
+		result += sizeOfPosition
+	}
+	if nil != aSource.External {
+		result += sizeOfLocation +
+			len(aSource.External.File) + len(aSource.External.Function)
+	}
+	for _, stack := range aSource.Foreign {
+		result += sizeOfForeign + len(stack.Name)
+		for _, frame := range stack.Frames {
+			result += sizeOfFrame + len(frame.File) + len(frame.Function)
+		}
+	}
+
+	return result
+} // sourceSize()
+
+// `Size()` estimates the memory retained by the given error including
+// all strings, stacks, and the errors it wraps.
+//
+// The result is meant as a guide for enforcing memory budgets (e.g.
+// when queueing errors) rather than an exact measure.
+//
+// Parameters:
+// - `aErr`: The error to inspect.
+//
+// Returns:
+// - `int`: The estimated size in bytes.
+func Size(aErr error) (rSize int) {
+	if nil == aErr {
+		return
+	}
+
+	// Foreign error types are estimated by the part of their
+	// message they add to the message of the error they wrap.
+	msgLen := func(aErr error) int {
+		if (nil == aErr) || (aErr == (*ErrSource)(nil)) {
+			return 0
+		}
+		return len(aErr.Error())
+	}
+
+	_ = walkChain(aErr, func(aLink error) bool {
+		switch se := aLink.(type) {
+		case *ErrSource:
+			if nil == se {
+				return false
+			}
+			rSize += sourceSize(se)
+		case ErrSource:
+			rSize += sourceSize(&se)
+		default:
+			if added := msgLen(aLink) - msgLen(unwrap(aLink)); 0 < added {
+				rSize += added
+			}
+			rSize += sizeOfError
+		}
+		return true
+	})
+
+	return
+} // Size()
+
+/* _EoF_ */
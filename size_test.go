@@ -0,0 +1,45 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestSize(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := &ErrSource{err: e, ID: "0123456789", File: "f.go", Function: "pkg.F"}
+	cl2 := fmt.Errorf("outer: %w", cl1)
+	cl3 := &ErrSource{err: e, Stack: make([]byte, 100, 1000)}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"0", nil, 0},
+		{"1", e, sizeOfError + len("some first error")},
+		{"2", cl1, sizeOfSource + 19 + sizeOfError + len("some first error")},
+		{"3", cl2, sizeOfError + len("outer: ") + Size(cl1)},
+		{"4", cl3, sizeOfSource + 1000 + Size(e)},
+		{"5", (*ErrSource)(nil), 0},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Size(tt.err); got != tt.want {
+				t.Errorf("%q: Size() = %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestSize()
+
+/* _EoF_ */
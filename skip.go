@@ -0,0 +1,71 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `ErrUnavailable` signals a service or resource that is
+	// temporarily not available (e.g. a database that's down).
+	ErrUnavailable = errors.New("unavailable")
+
+	// `ErrExternalDependency` signals a failure of an external
+	// dependency (e.g. a missing tool or an unreachable third-party
+	// API).
+	ErrExternalDependency = errors.New("external dependency failed")
+
+	// The kinds of errors `SkipInTest()` reports as reasons to skip a
+	// test; applications may append their own sentinels.
+	SkipKinds = []error{ErrUnavailable, ErrExternalDependency}
+)
+
+// `SkipInTest()` tells whether the given error is caused by the test
+// environment (see `SkipKinds`) so that an integration test should be
+// skipped rather than failed:
+//
+//	if err := db.Ping(); nil != err {
+//		if reason, ok := sourceerror.SkipInTest(err); ok {
+//			t.Skip(reason)
+//		}
+//		t.Fatal(err)
+//	}
+//
+// Errors are classified by wrapping one of the `SkipKinds`, e.g.
+//
+//	return fmt.Errorf("%w: %w", sourceerror.ErrUnavailable, err)
+//
+// Parameters:
+// - `aErr`: The error to classify.
+//
+// Returns:
+// - `string`: The reason for skipping the test.
+// - `bool`: `true` if the test should be skipped, `false` otherwise.
+func SkipInTest(aErr error) (string, bool) {
+	if nil == aErr {
+		return "", false
+	}
+
+	for _, kind := range SkipKinds {
+		if (nil == kind) || !errors.Is(aErr, kind) {
+			continue
+		}
+		result := "skipped (" + kind.Error() + "): " + aErr.Error()
+		var loc Location
+		if As(aErr, &loc) {
+			result += " at " + loc.String()
+		}
+		return result, true
+	}
+
+	return "", false
+} // SkipInTest()
+
+/* _EoF_ */
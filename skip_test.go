@@ -0,0 +1,53 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestSkipInTest(t *testing.T) {
+	refused := errors.New("connection refused")
+	down := fmt.Errorf("%w: %w", ErrUnavailable, refused)
+	tool := Wrap(fmt.Errorf("protoc: %w", ErrExternalDependency), 0)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantOK     bool
+		wantReason []string
+	}{
+		{"0", nil, false, nil},
+		{"1", refused, false, nil},
+		{"2", down, true, []string{"skipped (unavailable): unavailable: connection refused"}},
+		{"3", tool, true, []string{"(external dependency failed): protoc:", " at ", "skip_test.go:"}},
+		{"4", fmt.Errorf("setup: %w", down), true, []string{"setup: unavailable"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := SkipInTest(tt.err)
+			if ok != tt.wantOK {
+				t.Errorf("%q: SkipInTest() = %v, want %v", tt.name, ok, tt.wantOK)
+				return
+			}
+			for _, want := range tt.wantReason {
+				if !strings.Contains(reason, want) {
+					t.Errorf("%q: SkipInTest() reason = %q, want containing %q",
+						tt.name, reason, want)
+				}
+			}
+		})
+	}
+} // TestSkipInTest()
+
+/* _EoF_ */
@@ -0,0 +1,70 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"log/slog"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Attr()` returns a ready-made `slog` attribute for the given error.
+//
+// If there's an `ErrSource` in the error's chain the attribute is a
+// group named "error" holding the error's message (i.e. `aErr.Error()`
+// including the context added around the `ErrSource`), ID, location,
+// and the failed operation's duration (if known):
+//
+//	logger.Error("save failed", sourceerror.Attr(err))
+//
+// Otherwise the result equals `slog.Any("error", aErr)`.
+//
+// Parameters:
+// - `aErr`: The error to log.
+//
+// Returns:
+// - `slog.Attr`: The attribute to hand over to a `slog` logger.
+func Attr(aErr error) slog.Attr {
+	se, ok := asSource(aErr)
+	if !ok {
+		return slog.Any("error", aErr)
+	}
+
+	attrs := []any{
+		slog.String("msg", aErr.Error()),
+	}
+	if "" != se.ID {
+		attrs = append(attrs, slog.String("id", se.ID))
+	}
+	if "" != se.File {
+		attrs = append(attrs,
+			slog.String("file", se.File),
+			slog.Int("line", se.Line),
+			slog.String("function", se.Function),
+		)
+	}
+
+	if 0 < se.Elapsed {
+		attrs = append(attrs, slog.Duration("elapsed", se.Elapsed))
+	}
+	if "" != se.Phase {
+		attrs = append(attrs, slog.String("phase", se.Phase))
+	}
+	if nil != se.Input {
+		attrs = append(attrs, slog.String("input", se.Input.String()))
+	}
+	if 0 < se.Seq {
+		attrs = append(attrs, slog.Uint64("seq", se.Seq))
+	}
+	if IsIntentional(aErr) {
+		attrs = append(attrs, slog.Bool("intentional", true))
+	}
+
+	return slog.Group("error", attrs...)
+} // Attr()
+
+/* _EoF_ */
@@ -0,0 +1,61 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestAttr(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0).(*ErrSource)
+	cl2 := &ErrSource{err: e}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"0", nil, "error=<nil>"},
+		{"1", e, `error="some first error"`},
+		{"2", cl1, fmt.Sprintf(`error.msg="some first error" error.id=%s error.file=%s error.line=%d error.function=%s`,
+			cl1.ID, cl1.File, cl1.Line, cl1.Function)},
+		{"3", cl2, `error.msg="some first error"`},
+		{"4", fmt.Errorf("handler: %w", cl2), `error.msg="handler: some first error"`},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf,
+				&slog.HandlerOptions{
+					ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+						if slog.TimeKey == a.Key {
+							return slog.Attr{}
+						}
+						return a
+					},
+				}))
+			logger.Error("failed", Attr(tt.err))
+
+			got := strings.TrimPrefix(strings.TrimSpace(buf.String()),
+				"level=ERROR msg=failed ")
+			if got != tt.want {
+				t.Errorf("%q: Attr() =\n%s\nwant\n%s", tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestAttr()
+
+/* _EoF_ */
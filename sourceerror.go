@@ -7,9 +7,11 @@ Copyright © 2024  M.Watermann, 10247 Berlin, Germany
 package sourceerror
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 	"runtime/debug"
+	"time"
 )
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
@@ -20,6 +22,34 @@ const (
 
 	// How to build the string representation:
 	stringPattern = "Error: %v\nFile: %q\nLine: %d\nFunction: %q\nStack: %s"
+
+	// How to add an external location to the string representation:
+	externalPattern = "\nExternal: %s"
+
+	// How to add a foreign call stack to the string representation:
+	foreignPattern = "\nForeign stack %s"
+
+	// How to prefix the string representation with the error's ID:
+	idPattern = "ID: %s\n"
+
+	// How to add the duration of the failed operation:
+	elapsedPattern = "\nElapsed: %s"
+
+	// How to add the program's phase:
+	phasePattern = "\nPhase: %s"
+
+	// How to add the error's sequence number:
+	seqPattern = "\nSeq: %d"
+
+	// How to add the position within the input data:
+	inputPattern = "\nInput: %s"
+
+	// How to add the name of the test producing the error:
+	testPattern = "\nTest: %s"
+
+	// How to add the layers of a multiply wrapped error:
+	chainHeader  = "\nChain:"
+	chainPattern = "\n[%d] %s %s"
 )
 
 // `ErrSource` is an error type that wraps another error with the
@@ -27,39 +57,134 @@ const (
 // All public fields should be considered R/O (there really isn't any
 // reason to modify those fields apart from confusing yourself).
 //
+// An `ErrSource` is immutable once it's returned by this package (or
+// passed to a hook registered by `RegisterWrapHook()`): the functions
+// annotating an error (e.g. `WithPhase()` or `WithDuration()`) return
+// a modified copy, leaving the original untouched. Hence an error can
+// be shared between goroutines without any locking as long as its
+// fields aren't modified by the application.
+//
 // The fields are as follows:
 // - `File`: The source file where the error was encountered.
 // - `Function`: The function wherein the error was encountered
 // - `Line`: The code line within the `File`.
-// - `Stack`: The call stack to where the error was created.
+// - `Stack`: The call stack to where the error was created (see also
+// `Frames()`); it's empty if the stack is resolved lazily (see
+// `StackTrace()`).
+// - `External`: An optional non-Go source location (e.g. within a
+// template) the error refers to.
+// - `ID`: A unique identifier of the error instance.
+// - `Foreign`: The call stacks of embedded interpreters (see
+// `RegisterForeignStackDecoder()`).
+// - `StackOmitted`: Whether the call stack was not captured.
+// - `OmitReason`: Why the call stack was not captured (see `Reason…`).
+// - `Elapsed`: How long the failed operation ran (see `Timer()`).
+// - `Phase`: The program's phase the error occurred in (see `WithPhase()`).
+// - `Seq`: The error's sequence number (see `SetSequencing()`).
+// - `Input`: An optional position within the processed input data
+// (see `WrapParse()`).
+// - `Test`: The name of the test producing the error (see `WithTest()`).
 type ErrSource struct {
-	err      error  // 16 bytes
-	File     string // 16 bytes
-	Function string // dito
-	Line     int    // 8 bytes
-	Stack    []byte // 24 bytes
+	err      error          // 16 bytes
+	ID       string         // 16 bytes
+	File     string         // dito
+	Function string         // dito
+	Line     int            // 8 bytes
+	Stack    []byte         // 24 bytes
+	pcs      []uintptr      // dito
+	lazy     *tLazyStack    // 8 bytes
+	External *Location      // 8 bytes
+	Foreign  []ForeignStack // 24 bytes
+
+	StackOmitted bool   // 1 byte
+	OmitReason   string // 16 bytes
+
+	Elapsed time.Duration // 8 bytes
+	Phase   string        // 16 bytes
+	Seq     uint64        // 8 bytes
+	Input   *Position     // 8 bytes
+	Test    string        // 16 bytes
+
+	diagnostics error // 16 bytes
 }
 
 var (
 	// If set `true`, the `Wrap()` function will skip the error
 	// location investigation.
+	//
+	// Deprecated: Changing the flag at runtime races with the wrapping
+	// functions; use `DefaultConfig().SetEnabled()` instead.
 	NODEBUG bool
 
 	// If set `true`, the `Wrap()` function will skip the error's
 	// call-stack investigation.
+	//
+	// Deprecated: Changing the flag at runtime races with the wrapping
+	// functions; use `DefaultConfig().SetCaptureStack()` instead.
 	NOSTACK bool
 )
 
-// `Error()` returns a string representation of the error message
-// along with the error location.
+// `As()` allows to extract the error's metadata in addition to the
+// default behaviour of finding a matching error type.
 //
-// It includes the file name, line number, and function name where
-// the error occurred, along with original error's text.
+// Supported targets are:
+// - `*Location`: Receives the error's location (if there is one).
+// - `*Position`: Receives the error's input position (if there is one).
+//
+// NOTE: Since `errors.As()` panics on targets not implementing the
+// `error` interface use the package's `As()` function to search an
+// error's chain for metadata.
+//
+// Parameters:
+// - `aTarget`: A pointer to the variable to receive the metadata.
 //
 // Returns:
-// - `string`: a string representation of the error message and location.
+// - `bool`: `true` if the target was set, `false` otherwise.
+func (se ErrSource) As(aTarget any) bool {
+	switch target := aTarget.(type) {
+	case *Location:
+		if "" == se.File {
+			return false
+		}
+		*target = se.Location()
+		return true
+	case *Position:
+		if nil == se.Input {
+			return false
+		}
+		*target = *se.Input
+		return true
+	}
+
+	return false
+} // As()
+
+// `Detail()` returns a verbose string representation of the error
+// with its location, call stack and all other metadata (see `String()`).
+//
+// Returns:
+// - `string`: The error's full description.
+func (se ErrSource) Detail() string {
+	return se.primStr()
+} // Detail()
+
+// `Error()` implements the `error` interface and returns the text of
+// the wrapped error only.
+//
+// The text is compact and stable (i.e. independent of the error's ID
+// and call stack), hence it can be used for matching, as a logging key
+// or as a metric label; use `Detail()` to get the error's location and
+// call stack.
+//
+// Returns:
+// - `string`: The wrapped error's text or `StringSourceLocation` if
+// there's no wrapped error.
 func (se ErrSource) Error() string {
-	return fmt.Sprintf("%q\n%s", StringSourceLocation, se.primStr())
+	if result := se.message(); "" != result {
+		return result
+	}
+
+	return StringSourceLocation
 } // Error()
 
 // `init()` is a special function in Go that is automatically called when
@@ -73,28 +198,115 @@ func (se ErrSource) Error() string {
 // method for formatting the error message.
 func init() {
 	var (
-		_ error        = ErrSource{}
-		_ error        = (*ErrSource)(nil)
-		_ fmt.Stringer = ErrSource{}
-		_ fmt.Stringer = (*ErrSource)(nil)
+		_ error         = ErrSource{}
+		_ error         = (*ErrSource)(nil)
+		_ fmt.Stringer  = ErrSource{}
+		_ fmt.Stringer  = (*ErrSource)(nil)
+		_ fmt.Formatter = ErrSource{}
 	)
 } // init()
 
+// `Location()` returns the location where the error was encountered.
+//
+// Returns:
+// - `Location`: The error's location.
+func (se ErrSource) Location() Location {
+	return Location{
+		File:     se.File,
+		Function: se.Function,
+		Line:     se.Line,
+	}
+} // Location()
+
+// `layers()` returns the locations of all `ErrSource` layers of the
+// error's chain, starting with the error itself as layer `0`.
+//
+// Returns:
+// - `[]Location`: The locations of the chain's layers.
+func (se ErrSource) layers() []Location {
+	result := []Location{se.Location()}
+	_ = walkChain(se.err, func(aLink error) bool {
+		switch inner := aLink.(type) {
+		case *ErrSource:
+			if nil == inner {
+				return false
+			}
+			result = append(result, inner.Location())
+		case ErrSource:
+			result = append(result, inner.Location())
+		}
+		return true
+	})
+
+	return result
+} // layers()
+
+// `message()` returns the text of the wrapped error.
+//
+// Returns:
+// - `string`: The wrapped error's text or an empty string.
+func (se ErrSource) message() string {
+	if nil == se.err {
+		return ""
+	}
+
+	return se.err.Error()
+} // message()
+
 // The `primStr()` method is an internal helper function that constructs a
 // string representation of the error message along with the error location.
 // The method's purpose is twofold: firstly it avoids implicit recursions
-// between the `Error()` and `String()` methods, and secondly is serves
+// between the `Detail()` and `String()` methods, and secondly is serves
 // as a helper for the unit-tests.
 func (se ErrSource) primStr() string {
-	return fmt.Sprintf(stringPattern,
-		se.err, se.File, se.Line, se.Function, se.Stack)
+	result := fmt.Sprintf(stringPattern,
+		se.err, se.File, se.Line, se.Function, demangleStack(foldStack(se.StackTrace())))
+	for _, stack := range se.Foreign {
+		result += fmt.Sprintf(foreignPattern, stack)
+	}
+	if nil != se.External {
+		result += fmt.Sprintf(externalPattern, se.External)
+	}
+	if nil != se.Input {
+		result += fmt.Sprintf(inputPattern, se.Input)
+	}
+	if 0 < se.Elapsed {
+		result += fmt.Sprintf(elapsedPattern, se.Elapsed)
+	}
+	if "" != se.Phase {
+		result += fmt.Sprintf(phasePattern, se.Phase)
+	}
+	if 0 < se.Seq {
+		result += fmt.Sprintf(seqPattern, se.Seq)
+	}
+	if "" != se.Test {
+		result += fmt.Sprintf(testPattern, se.Test)
+	}
+	if layers := se.layers(); 1 < len(layers) {
+		result += chainHeader
+		for idx, loc := range layers {
+			if "" == loc.File {
+				result += fmt.Sprintf(chainPattern, idx, "-", "-")
+				continue
+			}
+			result += fmt.Sprintf(chainPattern, idx, loc, loc.Function)
+		}
+	}
+	if "" != se.ID {
+		result = fmt.Sprintf(idPattern, se.ID) + result
+	}
+
+	return result
 } // primStr()
 
 // `String()` implements the `Stringer` interface and returns a string
-// representation of the error location.
+// representation of the error location (the same as `Detail()`).
 //
 // It includes the file name, line number, and function name where
-// the error occurred ass well as a call stack.
+// the error occurred ass well as a call stack (with recursion cycles
+// folded). If the error wraps other `ErrSource` instances, the
+// locations of all layers are listed with their index, starting
+// with `[0]` for the outermost layer.
 //
 // Returns:
 // - `string`: a string representation of the error location.
@@ -113,65 +325,236 @@ func (se ErrSource) Unwrap() error {
 
 // --------------------------------------------------------------------------
 
-// `Wrap()` is a function that wraps an error with additional
-// information about the location where the error occurred. It uses
-// certain `runtime` functions to determine the file- and function-names,
-// as well as the code line and the call stack.
-// The `aLines` parameter allows for adjusting the reported line number by
-// subtracting the specified number of lines from the actual line number.
+// `As()` works like `errors.As()` but additionally supports the
+// metadata targets of `ErrSource.As()`:
+//
+//	var loc sourceerror.Location
+//	if sourceerror.As(err, &loc) {
+//		log.Println("failed at", loc)
+//	}
+//
+// Parameters:
+// - `aErr`: The error whose chain to search.
+// - `aTarget`: A pointer to the variable to receive the result.
 //
-// NOTE: If the global `NODEBUG` flag is `true`, this function returns an
-// instance with the given `aErr`, while file, function, line number,
-// stacktrace fields remain empty.
+// Returns:
+// - `bool`: `true` if the target was set, `false` otherwise.
+func As(aErr error, aTarget any) bool {
+	switch aTarget.(type) {
+	case *Location, *Position:
+		found := false
+		_ = walkChain(aErr, func(aLink error) bool {
+			if as, ok := aLink.(interface{ As(any) bool }); ok && as.As(aTarget) {
+				found = true
+			}
+			return !found
+		})
+		return found
+	}
+
+	return errors.As(aErr, aTarget)
+} // As()
+
+// `callerLocation()` returns the location of the stack frame selected
+// by the given strategy.
+//
+// Parameters:
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `callerLocation()`.
+// - `aStrategy`: The strategy to select the frame.
+//
+// Returns:
+// - `Location`: The selected frame's location.
+// - `bool`: `true` if the location could be determined.
+func callerLocation(aSkip int, aStrategy FrameStrategy) (Location, bool) {
+	if (nil == aStrategy) || isRaw(aStrategy) {
+		// Get program counter, file, line number, and status of the caller.
+		pc, file, line, ok := runtime.Caller(aSkip + 1)
+		if !ok {
+			return Location{}, false
+		}
+
+		// Get the name of the function for the program counter.
+		return Location{
+			File:     file,
+			Function: demangle(runtime.FuncForPC(pc).Name()),
+			Line:     line,
+		}, true
+	}
+
+	frames := callerFrames(aSkip + 1)
+	if 0 == len(frames) {
+		return Location{}, false
+	}
+	idx := aStrategy(frames)
+	if (0 > idx) || (len(frames) <= idx) {
+		idx = 0
+	}
+
+	return frames[idx].Location(), true
+} // callerLocation()
+
+// `buildSource()` creates a new instance without passing it to the
+// hooks registered by `RegisterWrapHook()`.
+//
+// It's used by the wrapping functions which complete the new instance
+// before publishing it by `notifyWrapHooks()`, so that no hook ever
+// sees an instance that's modified afterwards.
 //
 // Parameters:
 // - `aErr`: The error to be wrapped.
 // - `aLines`: The number of lines to subtract from the caller's line number.
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `buildSource()`.
+// - `aStrategy`: The strategy to select the error's location frame.
 //
 // Returns:
-// - `error`: A new `ErrSourceLocation` instance that contains `aErr`, as well
-// as file, function, and adjusted line number of the code causing the error.
-func Wrap(aErr error, aLines int) error {
-	if NODEBUG {
-		// Return a new instance of `ErrSource` with the provided error,
-		// while file, function, line, and stack-trace remain empty.
-		return &ErrSource{
-			err: aErr,
-		}
+// - `*ErrSource`: The new error instance.
+func buildSource(aErr error, aLines, aSkip int, aStrategy FrameStrategy) *ErrSource {
+	return captureSource(aErr, aLines, aSkip+1, aStrategy, &defaultConfig)
+} // buildSource()
+
+// `captureSource()` creates a new instance like `buildSource()` but
+// with the given configuration.
+//
+// Parameters:
+// - `aErr`: The error to be wrapped.
+// - `aLines`: The number of lines to subtract from the caller's line number.
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `captureSource()`.
+// - `aStrategy`: The strategy to select the error's location frame.
+// - `aConfig`: The configuration to capture the error's data with.
+//
+// Returns:
+// - `*ErrSource`: The new error instance.
+func captureSource(aErr error, aLines, aSkip int, aStrategy FrameStrategy, aConfig *Config) *ErrSource {
+	result := newInstance(aErr)
+	if aConfig.noDebug() {
+		// Return the new instance with the provided error, while
+		// file, function, line, and stack-trace remain empty.
+		return result.omitStack(ReasonNoDebug)
 	}
 
-	// Get program counter, file, line number, and status of the caller.
-	pc, eFile, eLine, ok := runtime.Caller(1)
+	loc, ok := callerLocation(aSkip+1, aStrategy)
 	if !ok {
 		// not possible to recover the information
-		return &ErrSource{
-			err: aErr,
-		}
+		return result.omitStack(ReasonCaptureFailed)
 	}
+	result.File, result.Function, result.Line = loc.File, loc.Function, loc.Line
 
 	// Adjust the line number if `aLines` is greater than zero and
 	// the calculated line number is not less than `aLines`.
-	if 0 < aLines && eLine >= aLines {
-		eLine -= aLines
+	if 0 < aLines && result.Line >= aLines {
+		result.Line -= aLines
 	}
+	auditLocation(result.Location())
+	result.Foreign = foreignStacks(aErr)
+	result.Test = nearestTest(aSkip + 1)
 
-	// Get the name of the function for the program counter.
-	eFunction := runtime.FuncForPC(pc).Name()
+	if aConfig.noStacks() {
+		return applyFaults(result.omitStack(ReasonNoStack))
+	}
+	if aConfig.LazyStack() {
+		result.pcs, result.lazy = callerPCs(aSkip+1), &tLazyStack{}
 
-	var eStack []byte
-	if !NOSTACK {
-		eStack = debug.Stack()
+		return applyFaults(result).countStack()
 	}
+	result.Stack, result.pcs = debug.Stack(), callerPCs(aSkip+1)
+	if phase, end := bootPhase(result.Stack); "" != phase {
+		// an error of `init()` or `TestMain()`: drop the runtime's frames
+		result.Phase, result.Stack = phase, result.Stack[:end]
+	}
+
+	return applyFaults(result).countStack()
+} // captureSource()
 
-	// Return a new instance of `ErrSource` with the provided error,
-	// file, function, adjusted line number, and stack trace.
-	return &ErrSource{
-		err:      aErr,
-		File:     eFile,
-		Function: eFunction,
-		Line:     eLine,
-		Stack:    eStack,
+// `newInstance()` returns a new instance wrapping the given error with
+// the data common to all new errors, i.e. its ID, its sequence number
+// and the shutdown phase.
+//
+// Parameters:
+// - `aErr`: The error to be wrapped.
+//
+// Returns:
+// - `*ErrSource`: The new error instance without location.
+func newInstance(aErr error) *ErrSource {
+	result := &ErrSource{
+		err: aErr,
+		ID:  newID(),
+		Seq: nextSeq(),
+	}
+	if ShuttingDown() {
+		result.Phase = PhaseShutdown
 	}
+
+	return result
+} // newInstance()
+
+// `newSource()` is the internal constructor used by all the wrapping
+// functions of this package.
+//
+// The new instance is passed to the hooks registered by
+// `RegisterWrapHook()`.
+//
+// Parameters:
+// - `aErr`: The error to be wrapped.
+// - `aLines`: The number of lines to subtract from the caller's line number.
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `newSource()`.
+// - `aStrategy`: The strategy to select the error's location frame.
+//
+// Returns:
+// - `*ErrSource`: The new error instance.
+func newSource(aErr error, aLines, aSkip int, aStrategy FrameStrategy) *ErrSource {
+	result := buildSource(aErr, aLines, aSkip+1, aStrategy)
+	notifyWrapHooks(result)
+
+	return result
+} // newSource()
+
+// `Wrap()` is a function that wraps an error with additional
+// information about the location where the error occurred. It uses
+// certain `runtime` functions to determine the file- and function-names,
+// as well as the code line and the call stack.
+// The `aLines` parameter allows for adjusting the reported line number by
+// subtracting the specified number of lines from the actual line number.
+//
+// The frame reported as the error's location is selected by the global
+// top frame strategy of `DefaultConfig()` (by default the immediate
+// caller, see `Config.SetTopFrame()`).
+// Like the compiler the reported location honours `//line` directives,
+// i.e. errors wrapped in generated code point to the original source
+// (e.g. a `.y` or `.proto` file).
+//
+// NOTE: If locations are disabled (see `Config.SetEnabled()`), this
+// function returns an instance with the given `aErr`, while file,
+// function, line number, stacktrace fields remain empty.
+//
+// Parameters:
+// - `aErr`: The error to be wrapped.
+// - `aLines`: The number of lines to subtract from the caller's line number.
+//
+// Returns:
+// - `error`: A new `ErrSourceLocation` instance that contains `aErr`, as well
+// as file, function, and adjusted line number of the code causing the error.
+func Wrap(aErr error, aLines int) error {
+	return newSource(aErr, aLines, 1, defaultConfig.TopFrame())
 } // Wrap()
 
+// `WrapWith()` works like `Wrap()` but uses the given strategy to
+// select the frame reported as the error's location instead of the
+// top frame strategy of `DefaultConfig()`.
+//
+// Parameters:
+// - `aErr`: The error to be wrapped.
+// - `aLines`: The number of lines to subtract from the selected line number.
+// - `aStrategy`: The strategy to select the error's location frame.
+//
+// Returns:
+// - `error`: A new `ErrSource` instance that contains `aErr`, as well
+// as file, function, and adjusted line number of the selected frame.
+func WrapWith(aErr error, aLines int, aStrategy FrameStrategy) error {
+	return newSource(aErr, aLines, 1, aStrategy)
+} // WrapWith()
+
 /* _EoF_ */
@@ -9,25 +9,74 @@ package sourceerror
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
 
+func TestAs(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	cl2 := fmt.Errorf("outer: %w", cl1)
+	cl3 := Wrap(&ErrSource{err: cl1}, 0)
+
+	tests := []struct {
+		name   string
+		err    error
+		want   Location
+		wantOK bool
+	}{
+		{"0", e, Location{}, false},
+		{"1", cl1, cl1.(*ErrSource).Location(), true},
+		{"2", cl2, cl1.(*ErrSource).Location(), true},
+		{"3", &ErrSource{err: cl1}, cl1.(*ErrSource).Location(), true},
+		{"4", cl3, cl3.(*ErrSource).Location(), true},
+		{"5", &ErrSource{err: e}, Location{}, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Location
+			if ok := As(tt.err, &got); ok != tt.wantOK {
+				t.Errorf("%q: As() = %v, want %v",
+					tt.name, ok, tt.wantOK)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("%q: As() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestAs()
+
+func TestAs_error(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := fmt.Errorf("outer: %w", Wrap(e, 0))
+
+	var se *ErrSource
+	if !As(cl1, &se) {
+		t.Error("As() = false, want true")
+	}
+	var pe *fs.PathError
+	if As(cl1, &pe) {
+		t.Error("As() = true, want false")
+	}
+} // TestAs_error()
+
 func TestErrSourceLocation_Error(t *testing.T) {
 	w0 := "some first error"
 	e := errors.New(w0)
 	cl1 := Wrap(e, 1)
-	w1 := fmt.Sprintf("%s", cl1) // use Stringer interface
-
 	cl2 := Wrap(nil, 0)
-	w2 := fmt.Sprintf("%s", cl2)
-
 	cl3 := Wrap(cl1, 0)
-	w3 := fmt.Sprintf("%s", cl3)
-
 	cl4 := Wrap(cl3, 0)
-	w4 := fmt.Sprintf("%s", cl4)
 
 	tests := []struct {
 		name string
@@ -35,10 +84,11 @@ func TestErrSourceLocation_Error(t *testing.T) {
 		want string
 	}{
 		{"0", e, w0},
-		{"1", cl1, w1},
-		{"2", cl2, w2},
-		{"3", cl3, w3},
-		{"4", cl4, w4},
+		{"1", cl1, w0},
+		{"2", cl2, StringSourceLocation},
+		{"3", cl3, w0},
+		{"4", cl4, w0},
+		{"5", fmt.Errorf("outer: %w", cl3), "outer: " + w0},
 		// TODO: Add test cases.
 	}
 	for _, tt := range tests {
@@ -47,17 +97,86 @@ func TestErrSourceLocation_Error(t *testing.T) {
 			if got := se.Error(); got != tt.want {
 				t.Errorf("%q: ErrSourceLocation.Error() =\n%q,\nwant %q",
 					tt.name, got, tt.want)
-				return
 			}
-			if oe, ok := se.(*ErrSource); ok {
-				t.Logf("%s\n", oe)
-				return
+		})
+	}
+} // TestErrSourceLocation_Error()
+
+func TestErrSource_Detail(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0).(*ErrSource)
+	cl2 := Wrap(nil, 0).(*ErrSource)
+	cl3 := Wrap(cl1, 0).(*ErrSource)
+
+	tests := []struct {
+		name string
+		se   *ErrSource
+		want []string
+	}{
+		{"0", cl1, []string{"Error: some first error", "File: ", "Stack: ", "TestErrSource_Detail"}},
+		{"1", cl2, []string{"Error: <nil>", "File: "}},
+		{"2", cl3, []string{"Error: some first error", "Chain:", "[1] "}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.se.Detail()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("%q: ErrSource.Detail() =\n%s\nwant containing %q",
+						tt.name, got, want)
+				}
 			}
+			if got != tt.se.String() {
+				t.Errorf("%q: ErrSource.Detail() differs from String()", tt.name)
+			}
+		})
+	}
+} // TestErrSource_Detail()
+
+func TestErrSource_layers(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0).(*ErrSource)
+	cl2 := Wrap(fmt.Errorf("repo: %w", cl1), 0).(*ErrSource)
+	cl3 := Wrap(*cl2, 0).(*ErrSource)
 
-			t.Logf("%s\n", tt.want)
+	tests := []struct {
+		name      string
+		se        *ErrSource
+		want      []Location
+		wantChain bool
+	}{
+		{"0", cl1, []Location{cl1.Location()}, false},
+		{"1", cl2, []Location{cl2.Location(), cl1.Location()}, true},
+		{"2", cl3, []Location{cl3.Location(), cl2.Location(), cl1.Location()}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.se.layers()
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("%q: ErrSource.layers() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			str := tt.se.String()
+			if gotChain := strings.Contains(str, chainHeader); gotChain != tt.wantChain {
+				t.Errorf("%q: ErrSource.String() chain = %v, want %v",
+					tt.name, gotChain, tt.wantChain)
+				return
+			}
+			for idx, loc := range tt.want {
+				if tt.wantChain && !strings.Contains(str,
+					fmt.Sprintf(chainPattern, idx, loc, loc.Function)) {
+					t.Errorf("%q: ErrSource.String() misses layer [%d]:\n%s",
+						tt.name, idx, str)
+				}
+			}
+			if rec := newRecord(tt.se); tt.wantChain != (nil != rec.Chain) {
+				t.Errorf("%q: newRecord().Chain = %v", tt.name, rec.Chain)
+			}
 		})
 	}
-} // TestErrSourceLocation_Error()
+} // TestErrSource_layers()
 
 func TestErrSourceLocation_String(t *testing.T) {
 	e := errors.New("some first error")
@@ -152,4 +271,32 @@ func TestErrSourceLocation_Unwrap(t *testing.T) {
 	}
 } // TestErrSourceLocation_Unwrap()
 
+// `TestErrSource_immutable()` checks (best run with `-race`) that an
+// error can be shared between goroutines deriving new errors from it.
+func TestErrSource_immutable(t *testing.T) {
+	defer RegisterSink(io.Discard, VerbosityFull)()
+	cl1 := Wrap(fmt.Errorf("repo: %w", Wrap(errors.New("some first error"), 0)), 0)
+	want := *cl1.(*ErrSource)
+
+	var wg sync.WaitGroup
+	for idx := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := WithPhase(cl1, fmt.Sprintf("phase %d", idx))
+			err = WithDuration(err, time.Duration(idx))
+			err = GoWrap(func() error { return err })()
+			_ = WithTest(err, t)
+			_ = fmt.Sprintf("%v %+s %+v", cl1, cl1, cl1)
+			_, _ = Narrate(cl1), Fingerprint(cl1)
+			_ = Report(cl1)
+		}()
+	}
+	wg.Wait()
+
+	if got := *cl1.(*ErrSource); !reflect.DeepEqual(got, want) {
+		t.Errorf("ErrSource modified:\n%s\nwant:\n%s", got.Detail(), want.Detail())
+	}
+} // TestErrSource_immutable()
+
 /* _EoF_ */
@@ -0,0 +1,67 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+/*
+Package sourceerrortest provides helpers to use the `sourceerror`
+package in tests.
+*/
+package sourceerrortest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Install()` routes all errors wrapped during the given test to the
+// test's log (with their full details) and fails the test if
+// `sourceerror.Fatal()` is called, giving visibility into errors that
+// get swallowed by the code under test:
+//
+//	func TestImport(t *testing.T) {
+//		sourceerrortest.Install(t)
+//		// ...
+//	}
+//
+// Errors wrapped within test binaries carry the name of the test
+// function producing them (see `ErrSource.Test`), so errors of other
+// tests running in parallel are ignored.
+//
+// `Fatal()` fails the test like `t.Fatal()`, hence it must be called
+// by the goroutine running the test; the program isn't terminated.
+//
+// The hooks are removed when the test finishes.
+//
+// Parameters:
+// - `aTest`: The current test.
+func Install(aTest testing.TB) {
+	aTest.Helper()
+	testFunc, _, _ := strings.Cut(aTest.Name(), "/")
+
+	removeWrap := sourceerror.RegisterWrapHook(func(aErr *sourceerror.ErrSource) {
+		if ("" != aErr.Test) && (testFunc != strings.SplitN(aErr.Test, "/", 2)[0]) {
+			return
+		}
+		aTest.Logf("wrapped error:\n%s", aErr.Detail())
+	})
+	removeFatal := sourceerror.RegisterFatalHook(func(aErr error) {
+		if se, ok := sourceerror.AsSource(aErr); ok {
+			aTest.Fatalf("sourceerror.Fatal():\n%s", se.Detail())
+		}
+		aTest.Fatalf("sourceerror.Fatal(): %v", aErr)
+	})
+
+	aTest.Cleanup(func() {
+		removeFatal()
+		removeWrap()
+	})
+} // Install()
+
+/* _EoF_ */
@@ -0,0 +1,80 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerrortest
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mwat56/sourceerror"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tRecorder` records the calls of `Install()`'s hooks.
+	tRecorder struct {
+		testing.TB
+		name     string
+		logs     []string
+		fatals   []string
+		cleanups []func()
+	}
+)
+
+func (r *tRecorder) Cleanup(aFunc func()) { r.cleanups = append(r.cleanups, aFunc) }
+func (r *tRecorder) Helper()              {}
+func (r *tRecorder) Name() string         { return r.name }
+func (r *tRecorder) Logf(aFormat string, a ...any) {
+	r.logs = append(r.logs, fmt.Sprintf(aFormat, a...))
+}
+func (r *tRecorder) Fatalf(aFormat string, a ...any) {
+	r.fatals = append(r.fatals, fmt.Sprintf(aFormat, a...))
+	runtime.Goexit()
+}
+
+func (r *tRecorder) finish() {
+	for idx := len(r.cleanups) - 1; 0 <= idx; idx-- {
+		r.cleanups[idx]()
+	}
+} // finish()
+
+func TestInstall(t *testing.T) {
+	rec := &tRecorder{TB: t, name: t.Name() + "/sub"}
+	other := &tRecorder{TB: t, name: "TestOther"}
+	Install(rec)
+	Install(other)
+
+	_ = sourceerror.Wrap(errors.New("swallowed error"), 0)
+	other.finish()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sourceerror.Fatal(errors.New("fatal error"))
+		t.Error("Fatal() returned")
+	}()
+	<-done
+	rec.finish()
+	_ = sourceerror.Wrap(errors.New("after test"), 0)
+
+	if 1 != len(rec.logs) || !strings.Contains(rec.logs[0], "swallowed error") {
+		t.Errorf("Install() logs = %q, want the swallowed error only", rec.logs)
+	}
+	if 1 != len(rec.fatals) || !strings.Contains(rec.fatals[0], "fatal error") {
+		t.Errorf("Install() fatals = %q, want the fatal error", rec.fatals)
+	}
+	if (0 != len(other.logs)) || (0 != len(other.fatals)) {
+		t.Errorf("Install() logged errors of other tests: %q, %q",
+			other.logs, other.fatals)
+	}
+} // TestInstall()
+
+/* _EoF_ */
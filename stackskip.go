@@ -0,0 +1,95 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `trimStack()` removes the given number of innermost frames from the
+// given call stack in the format used by `debug.Stack()`.
+//
+// The goroutine header and a trailing "created by" entry are kept.
+//
+// Parameters:
+// - `aStack`: The call stack to trim.
+// - `aFrames`: The number of frames to remove.
+//
+// Returns:
+// - `[]byte`: The trimmed call stack.
+func trimStack(aStack []byte, aFrames int) []byte {
+	if (0 >= aFrames) || (0 == len(aStack)) {
+		return aStack
+	}
+
+	// The first line is the goroutine header.
+	start := bytes.IndexByte(aStack, '\n') + 1
+	if 0 == start {
+		return aStack
+	}
+	end := start
+	for ; 0 < aFrames; aFrames-- {
+		rest := aStack[end:]
+		if (0 == len(rest)) || bytes.HasPrefix(rest, []byte("created by ")) {
+			break
+		}
+		// Each frame consists of a function and a location line.
+		idx := bytes.IndexByte(rest, '\n')
+		if 0 > idx {
+			end = len(aStack)
+			break
+		}
+		if next := bytes.IndexByte(rest[idx+1:], '\n'); 0 <= next {
+			end += idx + 1 + next + 1
+		} else {
+			end = len(aStack)
+		}
+	}
+
+	return append(aStack[:start:start], aStack[end:]...)
+} // trimStack()
+
+// `WrapSkip()` works like `Wrap()` but skips the given number of
+// frames both when selecting the error's location and when storing the
+// call stack, so that errors wrapped by helper functions point at the
+// helper's caller:
+//
+//	func check(aErr error) error {
+//		// report the location of `check()`'s caller
+//		return sourceerror.WrapSkip(aErr, 0, 1)
+//	}
+//
+// The call stack of the returned error starts with the frame reported
+// as the error's location, i.e. without the frames of the package's
+// own functions.
+//
+// Parameters:
+// - `aErr`: The error to be wrapped.
+// - `aLines`: The number of lines to subtract from the caller's line number.
+// - `aSkip`: The number of frames to skip with `0` identifying the
+// caller of `WrapSkip()`.
+//
+// Returns:
+// - `error`: A new `ErrSource` instance that contains `aErr`, as well
+// as file, function, and adjusted line number of the selected frame.
+func WrapSkip(aErr error, aLines, aSkip int) error {
+	if 0 > aSkip {
+		aSkip = 0
+	}
+	result := buildSource(aErr, aLines, aSkip+1, defaultConfig.TopFrame())
+
+	// skip `debug.Stack()`, `captureSource()`, `buildSource()`, and
+	// `WrapSkip()`
+	result.Stack = trimStack(result.Stack, aSkip+4)
+	notifyWrapHooks(result)
+
+	return result
+} // WrapSkip()
+
+/* _EoF_ */
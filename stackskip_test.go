@@ -0,0 +1,76 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `testCheck()` is a helper wrapping errors at its caller's location.
+func testCheck(aErr error) error {
+	return WrapSkip(aErr, 0, 1)
+} // testCheck()
+
+func Test_trimStack(t *testing.T) {
+	stack := "goroutine 7 [running]:\n" +
+		"main.a()\n\t/src/main.go:1 +0x1\n" +
+		"main.b()\n\t/src/main.go:2 +0x2\n" +
+		"created by main.main in goroutine 1\n\t/src/main.go:3 +0x3\n"
+
+	tests := []struct {
+		name   string
+		stack  string
+		frames int
+		want   string
+	}{
+		{"0", "", 1, ""},
+		{"1", stack, 0, stack},
+		{"2", stack, 1, "goroutine 7 [running]:\n" +
+			"main.b()\n\t/src/main.go:2 +0x2\n" +
+			"created by main.main in goroutine 1\n\t/src/main.go:3 +0x3\n"},
+		{"3", stack, 5, "goroutine 7 [running]:\n" +
+			"created by main.main in goroutine 1\n\t/src/main.go:3 +0x3\n"},
+		{"4", "goroutine 1 [running]:\nmain.a()\n\t/src/main.go:1", 1,
+			"goroutine 1 [running]:\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(trimStack([]byte(tt.stack), tt.frames)); got != tt.want {
+				t.Errorf("%q: trimStack() =\n%q\nwant\n%q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_trimStack()
+
+func TestWrapSkip(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	se := testCheck(errors.New("some first error")).(*ErrSource)
+
+	if se.Line != line+1 {
+		t.Errorf("WrapSkip() line = %d, want %d", se.Line, line+1)
+	}
+	for frame := range se.All() {
+		if frame.Function != se.Function {
+			t.Errorf("WrapSkip() stack starts with %q, want %q",
+				frame.Function, se.Function)
+		}
+		break
+	}
+
+	se = WrapSkip(errors.New("some first error"), 0, -1).(*ErrSource)
+	if se.Function != thisPackage+".TestWrapSkip" {
+		t.Errorf("WrapSkip() function = %q, want %q",
+			se.Function, thisPackage+".TestWrapSkip")
+	}
+} // TestWrapSkip()
+
+/* _EoF_ */
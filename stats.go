@@ -0,0 +1,133 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"sync/atomic"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The stack wasn't captured because locations were disabled (see
+	// `Config.SetEnabled()`).
+	ReasonNoDebug = "policy: NODEBUG"
+
+	// The stack wasn't captured because call stacks were disabled (see
+	// `Config.SetCaptureStack()`).
+	ReasonNoStack = "policy: NOSTACK"
+
+	// The stack wasn't captured because a precomputed location was used.
+	ReasonPrecomputed = "policy: precomputed location"
+
+	// The stack couldn't be captured by the `runtime`.
+	ReasonCaptureFailed = "capture failed"
+
+	// The stack was removed by an injected fault (see `InjectFault()`).
+	ReasonFaultInjected = "fault injected"
+)
+
+// `Statistics` holds the counters of the package's error creation.
+//
+// The fields are as follows:
+// - `Created`: The number of `ErrSource` instances created.
+// - `StacksCaptured`: The number of call stacks captured.
+// - `StacksOmitted`: The number of omitted call stacks by reason.
+type Statistics struct {
+	Created        uint64
+	StacksCaptured uint64
+	StacksOmitted  map[string]uint64
+}
+
+var (
+	// The counters reported by `Stats()`.
+	statCreated, statCaptured atomic.Uint64
+
+	// The omission counters indexed by reason.
+	statOmitted = map[string]*atomic.Uint64{
+		ReasonNoDebug:       {},
+		ReasonNoStack:       {},
+		ReasonPrecomputed:   {},
+		ReasonCaptureFailed: {},
+		ReasonFaultInjected: {},
+	}
+)
+
+// `countCaptured()` records a newly created error with its stack.
+func countCaptured() {
+	statCreated.Add(1)
+	statCaptured.Add(1)
+} // countCaptured()
+
+// `countCreated()` records a newly created error whose stack was
+// taken over from another error.
+func countCreated() {
+	statCreated.Add(1)
+} // countCreated()
+
+// `countOmitted()` records a newly created error without stack.
+//
+// Parameters:
+// - `aReason`: The reason why the stack was omitted.
+func countOmitted(aReason string) {
+	statCreated.Add(1)
+	if cnt, ok := statOmitted[aReason]; ok {
+		cnt.Add(1)
+	}
+} // countOmitted()
+
+// `countStack()` records a newly created error whose stack was
+// captured, unless an injected fault removed it (see `applyFaults()`).
+//
+// Returns:
+// - `*ErrSource`: The error itself.
+func (se *ErrSource) countStack() *ErrSource {
+	if se.StackOmitted {
+		countOmitted(se.OmitReason)
+	} else {
+		countCaptured()
+	}
+
+	return se
+} // countStack()
+
+// `omitStack()` marks the error's call stack as omitted and updates
+// the statistics accordingly.
+//
+// Parameters:
+// - `aReason`: The reason why the stack was omitted.
+//
+// Returns:
+// - `*ErrSource`: The current error instance.
+func (se *ErrSource) omitStack(aReason string) *ErrSource {
+	se.StackOmitted, se.OmitReason = true, aReason
+	countOmitted(aReason)
+
+	return se
+} // omitStack()
+
+// `Stats()` returns the package's current error creation statistics.
+//
+// It allows to distinguish between call stacks that were not captured
+// by policy (see `Config`) and stacks whose capturing failed.
+//
+// Returns:
+// - `Statistics`: A snapshot of the current counters.
+func Stats() Statistics {
+	result := Statistics{
+		Created:        statCreated.Load(),
+		StacksCaptured: statCaptured.Load(),
+		StacksOmitted:  make(map[string]uint64, len(statOmitted)),
+	}
+	for reason, cnt := range statOmitted {
+		result.StacksOmitted[reason] = cnt.Load()
+	}
+
+	return result
+} // Stats()
+
+/* _EoF_ */
@@ -0,0 +1,68 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestStats(t *testing.T) {
+	e := errors.New("some first error")
+	defer func() {
+		NODEBUG, NOSTACK = false, false
+	}()
+
+	tests := []struct {
+		name       string
+		nodebug    bool
+		nostack    bool
+		wrap       func() error
+		wantReason string
+	}{
+		{"1", false, false, func() error { return Wrap(e, 0) }, ""},
+		{"2", false, true, func() error { return Wrap(e, 0) }, ReasonNoStack},
+		{"3", true, false, func() error { return Wrap(e, 0) }, ReasonNoDebug},
+		{"4", false, false, func() error { return WithLocation(e, Location{}) }, ReasonPrecomputed},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			NODEBUG, NOSTACK = tt.nodebug, tt.nostack
+			before := Stats()
+			se := tt.wrap().(*ErrSource)
+			after := Stats()
+
+			if se.OmitReason != tt.wantReason {
+				t.Errorf("%q: OmitReason = %q, want %q",
+					tt.name, se.OmitReason, tt.wantReason)
+			}
+			if se.StackOmitted != ("" != tt.wantReason) {
+				t.Errorf("%q: StackOmitted = %v, want %v",
+					tt.name, se.StackOmitted, "" != tt.wantReason)
+			}
+			if 1 != after.Created-before.Created {
+				t.Errorf("%q: Stats().Created delta = %d, want 1",
+					tt.name, after.Created-before.Created)
+			}
+			if "" == tt.wantReason {
+				if 1 != after.StacksCaptured-before.StacksCaptured {
+					t.Errorf("%q: Stats().StacksCaptured not incremented", tt.name)
+				}
+				return
+			}
+			if 1 != after.StacksOmitted[tt.wantReason]-before.StacksOmitted[tt.wantReason] {
+				t.Errorf("%q: Stats().StacksOmitted[%q] not incremented",
+					tt.name, tt.wantReason)
+			}
+		})
+	}
+} // TestStats()
+
+/* _EoF_ */
@@ -0,0 +1,90 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `TestStdlibOnly()` guards the guarantee that the package and the
+// subpackages of its module depend on the standard library only;
+// integrations with third-party libraries belong in separate modules.
+func TestStdlibOnly(t *testing.T) {
+	mod, err := os.ReadFile("go.mod")
+	if nil != err {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(mod), "require") {
+		t.Errorf("go.mod requires other modules:\n%s", mod)
+	}
+	module := modulePath(mod)
+
+	fset := token.NewFileSet()
+	err = filepath.WalkDir(".", func(aPath string, aEntry fs.DirEntry, aErr error) error {
+		if nil != aErr {
+			return aErr
+		}
+		if aEntry.IsDir() {
+			if "." == aPath {
+				return nil
+			}
+			name := aEntry.Name()
+			if strings.HasPrefix(name, ".") || ("testdata" == name) {
+				return filepath.SkipDir
+			}
+			// separate modules may have their own dependencies
+			if _, err := os.Stat(filepath.Join(aPath, "go.mod")); nil == err {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(aPath, ".go") || strings.HasSuffix(aPath, "_test.go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, aPath, nil, parser.ImportsOnly)
+		if nil != err {
+			return err
+		}
+		for _, spec := range f.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			if (module == path) || strings.HasPrefix(path, module+"/") {
+				continue
+			}
+			first, _, _ := strings.Cut(path, "/")
+			if strings.Contains(first, ".") {
+				t.Errorf("%s imports non-stdlib package %q", aPath, path)
+			}
+		}
+		return nil
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+} // TestStdlibOnly()
+
+// `modulePath()` returns the module path declared by the given `go.mod`
+// file's contents.
+func modulePath(aMod []byte) string {
+	for _, line := range strings.Split(string(aMod), "\n") {
+		if path, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(path), `"`)
+		}
+	}
+
+	return ""
+} // modulePath()
+
+/* _EoF_ */
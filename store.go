@@ -0,0 +1,513 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tStoreEntry` is a single error kept by a `Store`.
+	tStoreEntry struct {
+		err   *ErrSource // the stored error
+		added time.Time  // when the error was added
+		size  int        // the error's estimated size
+	}
+
+	// `tStoreSnapshot` is the serialisable state of a `Store`.
+	tStoreSnapshot struct {
+		Version int                  `json:"v"`
+		Errors  []tStoreSnapshotItem `json:"errors"`
+	}
+
+	// `tStoreSnapshotItem` is a single error of a `tStoreSnapshot`.
+	tStoreSnapshotItem struct {
+		Added time.Time `json:"added"`
+		Error *tRecord  `json:"error"`
+	}
+
+	// `Store` is an in-memory collection of errors indexed by their
+	// instance ID (see `ID()`).
+	//
+	// Errors are evicted once they are older than the store's TTL or
+	// when the store's size limit (see `SetMaxBytes()`) is exceeded.
+	// The store's state can be saved by `Persist()` on shutdown (see
+	// `PersistOnShutdown()`) and restored by `Load()` on start.
+	// A `Store` is safe for concurrent use and implements the
+	// `http.Handler` interface to allow looking up errors by their ID.
+	Store struct {
+		mtx      sync.Mutex
+		entries  map[string]tStoreEntry
+		order    []string // IDs in the order they were added
+		ttl      time.Duration
+		bytes    int                   // estimated size of all entries
+		maxBytes int                   // size limit of all entries
+		onEvict  func(aErr *ErrSource) // eviction hook
+		timeFmt  string                // layout to render timestamps
+		timeLoc  *time.Location        // time zone to render timestamps
+	}
+)
+
+// `NewStore()` returns a new error store.
+//
+// Parameters:
+// - `aTTL`: How long to keep an added error; a value <= 0 keeps the
+// errors forever.
+//
+// Returns:
+// - `*Store`: The new error store.
+func NewStore(aTTL time.Duration) *Store {
+	return &Store{
+		entries: make(map[string]tStoreEntry),
+		ttl:     aTTL,
+	}
+} // NewStore()
+
+// `Add()` stores the outermost `ErrSource` of the given error's chain.
+//
+// Parameters:
+// - `aErr`: The error to store.
+//
+// Returns:
+// - `string`: The stored error's ID or an empty string if `aErr`
+// doesn't contain an `ErrSource` with an ID.
+func (s *Store) Add(aErr error) string {
+	se, ok := asSource(aErr)
+	if !ok || ("" == se.ID) {
+		return ""
+	}
+
+	s.mtx.Lock()
+	entry := tStoreEntry{
+		err:   se,
+		added: time.Now(),
+		size:  Size(se),
+	}
+	s.put(se.ID, entry)
+	evicted := s.evict(entry.added)
+	s.mtx.Unlock()
+
+	s.notify(evicted)
+
+	return se.ID
+} // Add()
+
+// `put()` stores the given entry as the store's newest one, replacing
+// an entry with the same ID.
+//
+// NOTE: The caller must hold the store's lock.
+//
+// Parameters:
+// - `aID`: The ID of the entry to store.
+// - `aEntry`: The entry to store.
+func (s *Store) put(aID string, aEntry tStoreEntry) {
+	if old, exists := s.entries[aID]; exists {
+		s.bytes -= old.size
+		// move a re-added ID to the end so `evict()` sees it last
+		if idx := slices.Index(s.order, aID); 0 <= idx {
+			s.order = slices.Delete(s.order, idx, idx+1)
+		}
+	}
+	s.order = append(s.order, aID)
+	s.entries[aID] = aEntry
+	s.bytes += aEntry.size
+} // put()
+
+// `evict()` removes all expired entries as well as the oldest entries
+// exceeding the store's size limit.
+//
+// NOTE: The caller must hold the store's lock.
+//
+// Parameters:
+// - `aNow`: The current time.
+//
+// Returns:
+// - `[]*ErrSource`: The evicted errors.
+func (s *Store) evict(aNow time.Time) []*ErrSource {
+	var result []*ErrSource
+
+	idx := 0
+	for ; idx < len(s.order); idx++ {
+		entry, ok := s.entries[s.order[idx]]
+		if ok {
+			expired := (0 < s.ttl) && (aNow.Sub(entry.added) >= s.ttl)
+			tooBig := (0 < s.maxBytes) && (s.bytes > s.maxBytes)
+			if !expired && !tooBig {
+				break
+			}
+			s.bytes -= entry.size
+			result = append(result, entry.err)
+		}
+		delete(s.entries, s.order[idx])
+	}
+	if 0 < idx {
+		s.order = append(s.order[:0], s.order[idx:]...)
+	}
+
+	return result
+} // evict()
+
+// `ExportJSONL()` writes the errors added since the given time as
+// JSON Lines, i.e. one JSON object per line in the order the errors
+// were added, e.g. for shipping them periodically to a log collector.
+//
+// Each line has the same structure as an entry of the file written
+// by `Persist()`.
+//
+// Parameters:
+// - `aWriter`: The destination to write the errors to.
+// - `aSince`: Only errors added after this time are exported; use the
+// zero time to export all errors.
+//
+// Returns:
+// - `int`: The number of exported errors.
+// - `error`: A possible error during encoding or writing.
+func (s *Store) ExportJSONL(aWriter io.Writer, aSince time.Time) (int, error) {
+	s.mtx.Lock()
+	evicted := s.evict(time.Now())
+	items := make([]tStoreSnapshotItem, 0, len(s.order))
+	for _, id := range s.order {
+		entry := s.entries[id]
+		if entry.added.After(aSince) {
+			items = append(items, tStoreSnapshotItem{
+				Added: entry.added,
+				Error: newRecord(entry.err),
+			})
+		}
+	}
+	s.mtx.Unlock()
+	s.notify(evicted)
+
+	enc := json.NewEncoder(aWriter)
+	for idx, item := range items {
+		if err := enc.Encode(item); nil != err {
+			return idx, err
+		}
+	}
+
+	return len(items), nil
+} // ExportJSONL()
+
+// `Get()` returns the stored error with the given ID.
+//
+// Parameters:
+// - `aID`: The ID of the error to look up.
+//
+// Returns:
+// - `*ErrSource`: A copy of the stored error.
+// - `bool`: `true` if the error was found, `false` otherwise.
+func (s *Store) Get(aID string) (*ErrSource, bool) {
+	entry, ok := s.entry(aID)
+	if !ok {
+		return nil, false
+	}
+
+	return entry.err.clone(), true
+} // Get()
+
+// `entry()` returns the store's entry with the given ID.
+//
+// Parameters:
+// - `aID`: The ID of the error to look up.
+//
+// Returns:
+// - `tStoreEntry`: The stored entry.
+// - `bool`: `true` if the entry was found, `false` otherwise.
+func (s *Store) entry(aID string) (tStoreEntry, bool) {
+	s.mtx.Lock()
+	evicted := s.evict(time.Now())
+	entry, ok := s.entries[aID]
+	s.mtx.Unlock()
+
+	s.notify(evicted)
+
+	return entry, ok
+} // entry()
+
+// `Len()` returns the number of errors currently stored.
+//
+// Returns:
+// - `int`: The number of stored errors.
+func (s *Store) Len() int {
+	s.mtx.Lock()
+	evicted := s.evict(time.Now())
+	result := len(s.entries)
+	s.mtx.Unlock()
+
+	s.notify(evicted)
+
+	return result
+} // Len()
+
+// `Load()` adds the errors saved by `Persist()` to the store.
+//
+// Errors which are already expired are skipped; a missing file is
+// not considered an error.
+//
+// Parameters:
+// - `aFilename`: The name of the file to read.
+//
+// Returns:
+// - `error`: A possible error during reading or decoding the file.
+func (s *Store) Load(aFilename string) error {
+	data, err := os.ReadFile(aFilename) // #nosec G304
+	if nil != err {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var snapshot tStoreSnapshot
+	if err = json.Unmarshal(data, &snapshot); nil != err {
+		return err
+	}
+
+	s.mtx.Lock()
+	for _, item := range snapshot.Errors {
+		if (nil == item.Error) || ("" == item.Error.ID) {
+			continue
+		}
+		entry := tStoreEntry{
+			err:   item.Error.source(),
+			added: item.Added,
+		}
+		entry.size = Size(entry.err)
+		s.put(item.Error.ID, entry)
+	}
+	evicted := s.evict(time.Now())
+	s.mtx.Unlock()
+
+	s.notify(evicted)
+
+	return nil
+} // Load()
+
+// `notify()` calls the eviction hook (if any) for the given errors.
+//
+// NOTE: The caller must not hold the store's lock.
+//
+// Parameters:
+// - `aEvicted`: The evicted errors.
+func (s *Store) notify(aEvicted []*ErrSource) {
+	if 0 == len(aEvicted) {
+		return
+	}
+
+	s.mtx.Lock()
+	hook := s.onEvict
+	s.mtx.Unlock()
+
+	if nil != hook {
+		for _, se := range aEvicted {
+			hook(se)
+		}
+	}
+} // notify()
+
+// `OnEvict()` sets a function to be called for each error removed from
+// the store because of its age or the store's size limit.
+//
+// Parameters:
+// - `aHook`: The function to call or `nil` to remove a former hook.
+func (s *Store) OnEvict(aHook func(aErr *ErrSource)) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.onEvict = aHook
+} // OnEvict()
+
+// `formatTime()` renders the given timestamp with the store's time
+// format and zone (see `SetTimeFormat()`).
+//
+// Parameters:
+// - `aTime`: The timestamp to render.
+//
+// Returns:
+// - `string`: The rendered timestamp.
+func (s *Store) formatTime(aTime time.Time) string {
+	s.mtx.Lock()
+	layout, location := s.timeFmt, s.timeLoc
+	s.mtx.Unlock()
+
+	return formatTime(aTime, layout, location)
+} // formatTime()
+
+// `Persist()` saves the store's errors to the given file so that they
+// can be restored by `Load()` e.g. after a process restart.
+//
+// NOTE: The store isn't saved automatically; call this method on
+// shutdown or register it by `PersistOnShutdown()`.
+//
+// The file is written atomically, i.e. a previous version is replaced
+// only after the new data were written completely.
+//
+// Parameters:
+// - `aFilename`: The name of the file to write.
+//
+// Returns:
+// - `error`: A possible error during encoding or writing the file.
+func (s *Store) Persist(aFilename string) error {
+	s.mtx.Lock()
+	evicted := s.evict(time.Now())
+	snapshot := tStoreSnapshot{
+		Version: recordVersion,
+		Errors:  make([]tStoreSnapshotItem, 0, len(s.order)),
+	}
+	for _, id := range s.order {
+		entry := s.entries[id]
+		snapshot.Errors = append(snapshot.Errors, tStoreSnapshotItem{
+			Added: entry.added,
+			Error: newRecord(entry.err),
+		})
+	}
+	s.mtx.Unlock()
+	s.notify(evicted)
+
+	data, err := json.Marshal(snapshot)
+	if nil != err {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(aFilename), filepath.Base(aFilename)+".*")
+	if nil != err {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); nil != err {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); nil != err {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), aFilename); nil != err {
+		return err
+	}
+
+	return nil
+} // Persist()
+
+// `PersistOnShutdown()` registers a shutdown hook (see
+// `RegisterShutdownHook()`) saving the store's errors to the given
+// file by `Persist()`:
+//
+//	store := sourceerror.NewStore(time.Hour)
+//	_ = store.Load(stateFile)
+//	store.PersistOnShutdown(stateFile)
+//
+// NOTE: The hook runs only if the application calls `RunShutdownHooks()`
+// (e.g. in its signal handling) or terminates by `Fatal()`; a failure
+// to save the errors is reported to `os.Stderr`.
+//
+// Parameters:
+// - `aFilename`: The name of the file to write.
+func (s *Store) PersistOnShutdown(aFilename string) {
+	RegisterShutdownHook(func() {
+		if err := s.Persist(aFilename); nil != err {
+			fmt.Fprintf(fatalWriter, "sourceerror: persisting the error store: %v\n", err)
+		}
+	})
+} // PersistOnShutdown()
+
+// `SetMaxBytes()` limits the estimated memory (see `Size()`) retained
+// by the stored errors; the oldest errors are evicted when the limit
+// is exceeded.
+//
+// Parameters:
+// - `aMaxBytes`: The size limit; a value <= 0 disables the limit.
+func (s *Store) SetMaxBytes(aMaxBytes int) {
+	s.mtx.Lock()
+	s.maxBytes = aMaxBytes
+	evicted := s.evict(time.Now())
+	s.mtx.Unlock()
+
+	s.notify(evicted)
+} // SetMaxBytes()
+
+// `SetTimeFormat()` sets the time format and zone used to render the
+// stored errors' timestamps, overriding the global `TimeFormat` and
+// `TimeLocation` settings.
+//
+// Parameters:
+// - `aLayout`: The layout (see `time.Layout`) or `TimeFormatUnixMilli`;
+// an empty string selects the global `TimeFormat`.
+// - `aLocation`: The time zone; `nil` selects the global `TimeLocation`.
+func (s *Store) SetTimeFormat(aLayout string, aLocation *time.Location) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.timeFmt, s.timeLoc = aLayout, aLocation
+} // SetTimeFormat()
+
+// `Bytes()` returns the estimated memory retained by the stored errors.
+//
+// Returns:
+// - `int`: The estimated size of all stored errors.
+func (s *Store) Bytes() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.bytes
+} // Bytes()
+
+// `ServeHTTP()` implements the `http.Handler` interface.
+//
+// The requested error ID is taken either from the "id" query parameter
+// or from the last element of the request's URL path; the response
+// contains the full error data, the time it was added to the store
+// (see `SetTimeFormat()`), its owner (see `LoadOwners()`), and the
+// program's build info.
+//
+// Parameters:
+// - `aWriter`: Used to send the response.
+// - `aRequest`: The HTTP request to handle.
+func (s *Store) ServeHTTP(aWriter http.ResponseWriter, aRequest *http.Request) {
+	if (http.MethodGet != aRequest.Method) && (http.MethodHead != aRequest.Method) {
+		aWriter.Header().Set("Allow", "GET, HEAD")
+		http.Error(aWriter, http.StatusText(http.StatusMethodNotAllowed),
+			http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := aRequest.URL.Query().Get("id")
+	if "" == id {
+		id = path.Base(aRequest.URL.Path)
+	}
+	entry, ok := s.entry(id)
+	if !ok {
+		http.Error(aWriter, fmt.Sprintf("error ID %q not found", id),
+			http.StatusNotFound)
+		return
+	}
+
+	aWriter.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	aWriter.WriteHeader(http.StatusOK)
+	fmt.Fprintf(aWriter, "%s\n", entry.err.String())
+	fmt.Fprintf(aWriter, "Added: %s\n", s.formatTime(entry.added))
+	if owner := Owner(entry.err); "" != owner {
+		fmt.Fprintf(aWriter, "Owner: %s\n", owner)
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprintf(aWriter, "\nBuild: %s", info.String())
+	}
+} // ServeHTTP()
+
+/* _EoF_ */
@@ -0,0 +1,360 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestStore_Add(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	cl2 := fmt.Errorf("outer: %w", Wrap(e, 0))
+	s := NewStore(0)
+
+	tests := []struct {
+		name    string
+		err     error
+		wantID  string
+		wantLen int
+	}{
+		{"0", nil, "", 0},
+		{"1", e, "", 0},
+		{"2", cl1, ID(cl1), 1},
+		{"3", cl2, ID(cl2), 2},
+		{"4", cl1, ID(cl1), 2},
+		{"5", &ErrSource{err: e}, "", 2},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Add(tt.err); got != tt.wantID {
+				t.Errorf("%q: Store.Add() = %q, want %q",
+					tt.name, got, tt.wantID)
+			}
+			if got := s.Len(); got != tt.wantLen {
+				t.Errorf("%q: Store.Len() = %d, want %d",
+					tt.name, got, tt.wantLen)
+			}
+		})
+	}
+} // TestStore_Add()
+
+func TestStore_Get(t *testing.T) {
+	cl1 := Wrap(errors.New("some first error"), 0)
+	cl2 := Wrap(errors.New("some other error"), 0)
+	s := NewStore(time.Hour)
+	s.Add(cl1)
+	s.Add(cl2)
+
+	// expire the first entry
+	entry := s.entries[ID(cl1)]
+	entry.added = entry.added.Add(-2 * time.Hour)
+	s.entries[ID(cl1)] = entry
+
+	tests := []struct {
+		name   string
+		id     string
+		wantOK bool
+	}{
+		{"0", "", false},
+		{"1", ID(cl1), false},
+		{"2", ID(cl2), true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.Get(tt.id)
+			if ok != tt.wantOK {
+				t.Errorf("%q: Store.Get() = %v, want %v",
+					tt.name, ok, tt.wantOK)
+				return
+			}
+			if !ok {
+				return
+			}
+			if got.ID != tt.id {
+				t.Errorf("%q: Store.Get() ID = %q, want %q",
+					tt.name, got.ID, tt.id)
+			}
+			// the result is a copy
+			got.Line = 0
+			if stored := s.entries[tt.id].err; 0 == stored.Line {
+				t.Errorf("%q: Store.Get() returned the stored instance",
+					tt.name)
+			}
+		})
+	}
+	if 1 != len(s.order) {
+		t.Errorf("Store.order = %v, want 1 entry", s.order)
+	}
+} // TestStore_Get()
+
+func TestStore_put(t *testing.T) {
+	cl1 := Wrap(errors.New("some first error"), 0)
+	cl2 := Wrap(errors.New("some other error"), 0)
+	s := NewStore(time.Hour)
+	s.Add(cl1)
+	s.Add(cl2)
+	s.Add(cl1) // moves `cl1` behind `cl2`
+
+	// expire the second entry
+	entry := s.entries[ID(cl2)]
+	entry.added = entry.added.Add(-2 * time.Hour)
+	s.entries[ID(cl2)] = entry
+
+	tests := []struct {
+		name   string
+		id     string
+		wantOK bool
+	}{
+		{"0", ID(cl1), true},
+		{"1", ID(cl2), false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := s.Get(tt.id); ok != tt.wantOK {
+				t.Errorf("%q: Store.Get() = %v, want %v",
+					tt.name, ok, tt.wantOK)
+			}
+		})
+	}
+	if want := []string{ID(cl1)}; !slices.Equal(s.order, want) {
+		t.Errorf("Store.order = %v, want %v", s.order, want)
+	}
+} // TestStore_put()
+
+func TestStore_ExportJSONL(t *testing.T) {
+	cl1 := Wrap(errors.New("some first error"), 0)
+	cl2 := Wrap(errors.New("some other error"), 0)
+	s := NewStore(0)
+	s.Add(cl1)
+	s.Add(cl2)
+
+	// pretend the first entry is older
+	entry := s.entries[ID(cl1)]
+	entry.added = entry.added.Add(-time.Hour)
+	s.entries[ID(cl1)] = entry
+
+	tests := []struct {
+		name    string
+		since   time.Time
+		wantIDs []string
+	}{
+		{"0", time.Time{}, []string{ID(cl1), ID(cl2)}},
+		{"1", time.Now().Add(-time.Minute), []string{ID(cl2)}},
+		{"2", time.Now().Add(time.Minute), nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			n, err := s.ExportJSONL(&sb, tt.since)
+			if nil != err {
+				t.Errorf("%q: Store.ExportJSONL() error = %v", tt.name, err)
+				return
+			}
+			if n != len(tt.wantIDs) {
+				t.Errorf("%q: Store.ExportJSONL() = %d, want %d",
+					tt.name, n, len(tt.wantIDs))
+			}
+			var gotIDs []string
+			dec := json.NewDecoder(strings.NewReader(sb.String()))
+			for dec.More() {
+				var item tStoreSnapshotItem
+				if err := dec.Decode(&item); nil != err {
+					t.Errorf("%q: Store.ExportJSONL() invalid line: %v", tt.name, err)
+					return
+				}
+				gotIDs = append(gotIDs, item.Error.ID)
+			}
+			if fmt.Sprint(gotIDs) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("%q: Store.ExportJSONL() IDs = %v, want %v",
+					tt.name, gotIDs, tt.wantIDs)
+			}
+			if strings.Count(sb.String(), "\n") != n {
+				t.Errorf("%q: Store.ExportJSONL() = %d lines, want %d",
+					tt.name, strings.Count(sb.String(), "\n"), n)
+			}
+		})
+	}
+} // TestStore_ExportJSONL()
+
+func TestStore_SetMaxBytes(t *testing.T) {
+	var evicted []string
+	cl1 := Wrap(errors.New("some first error"), 0)
+	cl2 := Wrap(errors.New("some other error"), 0)
+	cl3 := Wrap(errors.New("yet another error"), 0)
+	s := NewStore(0)
+	s.OnEvict(func(aErr *ErrSource) {
+		evicted = append(evicted, aErr.ID)
+	})
+	s.Add(cl1)
+	s.Add(cl2)
+	s.Add(cl3)
+	if got, want := s.Bytes(), Size(cl1)+Size(cl2)+Size(cl3); got != want {
+		t.Errorf("Store.Bytes() = %d, want %d", got, want)
+	}
+
+	s.SetMaxBytes(Size(cl2) + Size(cl3))
+	if got := fmt.Sprint(evicted); got != fmt.Sprint([]string{ID(cl1)}) {
+		t.Errorf("Store.SetMaxBytes() evicted = %v, want [%s]", got, ID(cl1))
+	}
+	if _, ok := s.Get(ID(cl1)); ok {
+		t.Errorf("Store.Get() found evicted error %q", ID(cl1))
+	}
+
+	s.SetMaxBytes(1)
+	if 0 != s.Len() || 0 != s.Bytes() {
+		t.Errorf("Store.SetMaxBytes(1) = %d entries, %d bytes, want 0, 0",
+			s.Len(), s.Bytes())
+	}
+	if 3 != len(evicted) {
+		t.Errorf("Store.OnEvict() called %d times, want 3", len(evicted))
+	}
+} // TestStore_SetMaxBytes()
+
+func TestStore_Persist(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "errors.json")
+	cl1 := Wrap(errors.New("some first error"), 0).(*ErrSource)
+	cl2 := WrapTemplate(errors.New("template: page:3: oops"), "").(*ErrSource)
+	s1 := NewStore(time.Hour)
+	s1.Add(cl1)
+	s1.Add(cl2)
+
+	if err := s1.Persist(fName); nil != err {
+		t.Fatalf("Store.Persist() error = %v", err)
+	}
+	s2 := NewStore(time.Hour)
+	if err := s2.Load(fName); nil != err {
+		t.Fatalf("Store.Load() error = %v", err)
+	}
+	if err := s2.Load(fName + ".missing"); nil != err {
+		t.Fatalf("Store.Load() missing file error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want *ErrSource
+	}{
+		{"1", cl1},
+		{"2", cl2},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s2.Get(tt.want.ID)
+			if !ok {
+				t.Errorf("%q: Store.Load() lost error %q", tt.name, tt.want.ID)
+				return
+			}
+			if got.primStr() != tt.want.primStr() {
+				t.Errorf("%q: Store.Load() =\n%s\nwant\n%s",
+					tt.name, got.primStr(), tt.want.primStr())
+			}
+		})
+	}
+	if got := s2.order; (2 != len(got)) || (cl1.ID != got[0]) {
+		t.Errorf("Store.Load() order = %v, want [%s %s]", got, cl1.ID, cl2.ID)
+	}
+} // TestStore_Persist()
+
+func TestStore_PersistOnShutdown(t *testing.T) {
+	dir := t.TempDir()
+	cl1 := Wrap(errors.New("some first error"), 0).(*ErrSource)
+	var buf bytes.Buffer
+	fatalWriter = &buf
+	defer func() {
+		fatalWriter = os.Stderr
+		shuttingDown.Store(false)
+	}()
+
+	tests := []struct {
+		name     string
+		filename string
+		wantErr  bool
+	}{
+		{"0", filepath.Join(dir, "errors.json"), false},
+		{"1", filepath.Join(dir, "missing", "errors.json"), true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			s1 := NewStore(time.Hour)
+			s1.Add(cl1)
+			s1.PersistOnShutdown(tt.filename)
+			RunShutdownHooks()
+
+			if got := strings.Contains(buf.String(), "persisting"); got != tt.wantErr {
+				t.Errorf("%q: Store.PersistOnShutdown() output = %q, wantErr %v",
+					tt.name, buf.String(), tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			s2 := NewStore(time.Hour)
+			err := s2.Load(tt.filename)
+			if _, ok := s2.Get(cl1.ID); (nil != err) || !ok {
+				t.Errorf("%q: Store.Load() = %v, lost error %q", tt.name, err, cl1.ID)
+			}
+		})
+	}
+} // TestStore_PersistOnShutdown()
+
+func TestStore_ServeHTTP(t *testing.T) {
+	cl1 := Wrap(errors.New("some first error"), 0)
+	s := NewStore(0)
+	s.Add(cl1)
+	s.SetTimeFormat(TimeFormatUnixMilli, nil)
+	added := s.formatTime(s.entries[ID(cl1)].added)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{"1", http.MethodGet, "/errors/" + ID(cl1), http.StatusOK, "some first error"},
+		{"2", http.MethodGet, "/errors?id=" + ID(cl1), http.StatusOK, ID(cl1)},
+		{"3", http.MethodGet, "/errors/unknown", http.StatusNotFound, "not found"},
+		{"4", http.MethodPost, "/errors/" + ID(cl1), http.StatusMethodNotAllowed, ""},
+		{"5", http.MethodGet, "/errors/" + ID(cl1), http.StatusOK, "Added: " + added + "\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("%q: Store.ServeHTTP() status = %d, want %d",
+					tt.name, rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("%q: Store.ServeHTTP() body =\n%s\nwant %q",
+					tt.name, rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+} // TestStore_ServeHTTP()
+
+/* _EoF_ */
@@ -0,0 +1,85 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"runtime"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// The frames of the program counters symbolised so far; a single
+	// program counter may yield several frames due to inlining.
+	pcFrames sync.Map // map[uintptr][]Frame
+)
+
+// `symbolize()` returns the frames of the given program counter.
+//
+// The frames' function names are stored as reported by the runtime
+// so that the cache doesn't depend on the `DemangleGenerics` setting.
+//
+// Parameters:
+// - `aPC`: The program counter as returned by `runtime.Callers()`.
+//
+// Returns:
+// - `[]Frame`: The frames of the program counter, innermost first.
+func symbolize(aPC uintptr) []Frame {
+	if cached, ok := pcFrames.Load(aPC); ok {
+		return cached.([]Frame)
+	}
+
+	var result []Frame
+	frames := runtime.CallersFrames([]uintptr{aPC})
+	for {
+		frame, more := frames.Next()
+		if (0 != frame.PC) || ("" != frame.Function) {
+			result = append(result, Frame{
+				File:     frame.File,
+				Function: frame.Function,
+				Line:     frame.Line,
+				PC:       frame.PC,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	pcFrames.Store(aPC, result)
+
+	return result
+} // symbolize()
+
+// `SymbolizePCs()` returns the frames of the given program counters
+// (e.g. as captured by `runtime.Callers()` or taken from a profile)
+// the same way the package determines the frames of the errors it
+// wraps: inlined functions are expanded, `//line` directives are
+// honoured, and the shape names of generic instantiations are
+// simplified (see `DemangleGenerics`).
+//
+// The symbolised program counters are cached, so repeatedly
+// symbolising the same code paths is cheap.
+//
+// Parameters:
+// - `aPCs`: The return program counters to symbolise.
+//
+// Returns:
+// - `[]Frame`: The frames of the program counters, innermost first.
+func SymbolizePCs(aPCs []uintptr) []Frame {
+	result := make([]Frame, 0, len(aPCs))
+	for _, pc := range aPCs {
+		for _, frame := range symbolize(pc) {
+			frame.Function = demangle(frame.Function)
+			result = append(result, frame)
+		}
+	}
+
+	return result
+} // SymbolizePCs()
+
+/* _EoF_ */
@@ -0,0 +1,71 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `callersOf()` returns the program counters of its caller's stack
+// from within a generic function.
+//
+//go:noinline
+func callersOf[T any](T) []uintptr {
+	pcs := make([]uintptr, 32)
+
+	return pcs[:runtime.Callers(1, pcs)]
+} // callersOf()
+
+func TestSymbolizePCs(t *testing.T) {
+	pcs := callersOf(42)
+
+	var want []Frame
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		want = append(want, Frame{frame.File, demangle(frame.Function), frame.Line, frame.PC})
+		if !more {
+			break
+		}
+	}
+
+	tests := []struct {
+		name      string
+		pcs       []uintptr
+		wantFirst string
+		wantLen   int
+	}{
+		{"0", nil, "", 0},
+		{"1", pcs, "sourceerror.callersOf[...]", len(want)},
+		{"2", pcs[1:2], "sourceerror.TestSymbolizePCs", 1},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SymbolizePCs(tt.pcs)
+			if len(got) != tt.wantLen {
+				t.Fatalf("%q: SymbolizePCs() = %v, want %d frames", tt.name, got, tt.wantLen)
+			}
+			if (0 < len(got)) && !strings.HasSuffix(got[0].Function, tt.wantFirst) {
+				t.Errorf("%q: SymbolizePCs() = %q, want %q",
+					tt.name, got[0].Function, tt.wantFirst)
+			}
+		})
+	}
+
+	// symbolised from the cache
+	if got := SymbolizePCs(pcs); !reflect.DeepEqual(got, want) {
+		t.Errorf("SymbolizePCs() =\n%v\nwant\n%v", got, want)
+	}
+} // TestSymbolizePCs()
+
+/* _EoF_ */
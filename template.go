@@ -0,0 +1,93 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"regexp"
+	"strconv"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// Regular expression to extract the template name and line from
+	// the error messages of the `html/template` and `text/template`
+	// packages, e.g.
+	//
+	//	template: page:12: function "foo" not defined
+	//	template: page:12:7: executing "page" at <.X>: ...
+	//	html/template:page:12:7: no such template "bar"
+	reTemplateLocation = regexp.MustCompile(
+		`^(?:html/)?template: ?([^:\s]+):(\d+)(?::\d+)?: `)
+)
+
+// `templateLocation()` extracts the template name and line from the
+// given template error.
+//
+// Parameters:
+// - `aErr`: The error returned by a template's parsing or execution.
+// - `aName`: The template name to use if none can be extracted.
+//
+// Returns:
+// - `*Location`: The location within the template.
+func templateLocation(aErr error, aName string) *Location {
+	result := &Location{
+		File: aName,
+	}
+
+	var hErr *htmltemplate.Error
+	if errors.As(aErr, &hErr) && (0 < hErr.Line) {
+		if "" != hErr.Name {
+			result.File = hErr.Name
+		}
+		result.Line = hErr.Line
+
+		return result
+	}
+
+	_ = walkChain(aErr, func(aLink error) bool {
+		match := reTemplateLocation.FindStringSubmatch(aLink.Error())
+		if nil == match {
+			return true
+		}
+		result.File = match[1]
+		result.Line, _ = strconv.Atoi(match[2])
+		return false
+	})
+
+	return result
+} // templateLocation()
+
+// `WrapTemplate()` wraps an error returned by parsing or executing an
+// `html/template` or `text/template` template.
+//
+// Besides the Go location of the caller (as with `Wrap()`) the name and
+// line of the failing template are extracted from the error and stored
+// in the `External` field of the returned error.
+//
+// Parameters:
+// - `aErr`: The template error to be wrapped.
+// - `aTemplateName`: The template's name to use if none can be extracted
+// from `aErr`.
+//
+// Returns:
+// - `error`: A new `ErrSource` instance or `nil` if `aErr` is `nil`.
+func WrapTemplate(aErr error, aTemplateName string) error {
+	if nil == aErr {
+		return nil
+	}
+
+	result := buildSource(aErr, 0, 1, defaultConfig.TopFrame())
+	result.External = templateLocation(aErr, aTemplateName)
+	notifyWrapHooks(result)
+
+	return result
+} // WrapTemplate()
+
+/* _EoF_ */
@@ -0,0 +1,61 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"testing"
+	"text/template"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestWrapTemplate(t *testing.T) {
+	_, e1 := template.New("page").Parse("line 1\n{{ .X ")
+	e2 := template.Must(template.New("page").Funcs(template.FuncMap{
+		"boom": func() (string, error) { return "", errors.New("boom") },
+	}).Parse("1\n2\n{{ boom }}")).Execute(io.Discard, nil)
+	e3 := htmltemplate.Must(htmltemplate.New("html").Parse(
+		"<a href=\"{{ . }}\">\n{{ template \"nope\" }}")).Execute(io.Discard, nil)
+	e4 := errors.New("not a template error")
+
+	tests := []struct {
+		name string
+		err  error
+		want *Location
+	}{
+		{"0", nil, nil},
+		{"1", e1, &Location{File: "page", Line: 2}},
+		{"2", e2, &Location{File: "page", Line: 3}},
+		{"3", e3, &Location{File: "html", Line: 2}},
+		{"4", e4, &Location{File: "fallback"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WrapTemplate(tt.err, "fallback")
+			if nil == tt.want {
+				if nil != err {
+					t.Errorf("%q: WrapTemplate() = %v, want <nil>", tt.name, err)
+				}
+				return
+			}
+			se := err.(*ErrSource)
+			if (nil == se.External) || (*se.External != *tt.want) {
+				t.Errorf("%q: WrapTemplate() external = %v, want %v\n(%v)",
+					tt.name, se.External, tt.want, tt.err)
+			}
+			if "" == se.File {
+				t.Errorf("%q: WrapTemplate() lost Go location", tt.name)
+			}
+		})
+	}
+} // TestWrapTemplate()
+
+/* _EoF_ */
@@ -0,0 +1,109 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// Whether the program is a test binary built by `go test`.
+	inTestBinary = sync.OnceValue(func() bool {
+		if 0 == len(os.Args) {
+			return false
+		}
+		name := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+
+		return strings.HasSuffix(name, ".test")
+	})
+)
+
+// `testFunction()` returns the name of the test function (e.g.
+// "TestLoad") the given function belongs to.
+//
+// Parameters:
+// - `aFunction`: The (fully qualified) function name, e.g.
+// "example.com/app.TestLoad.func1".
+//
+// Returns:
+// - `string`: The test function's name or an empty string.
+func testFunction(aFunction string) string {
+	pkg := funcPackage(aFunction)
+	if len(pkg) >= len(aFunction) {
+		return ""
+	}
+	name, _, _ := strings.Cut(aFunction[len(pkg)+1:], ".")
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz", "Example"} {
+		if strings.HasPrefix(name, prefix) {
+			return name
+		}
+	}
+
+	return ""
+} // testFunction()
+
+// `nearestTest()` returns the name of the nearest test function on
+// the calling goroutine's stack if the program is a test binary.
+//
+// Parameters:
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `nearestTest()`.
+//
+// Returns:
+// - `string`: The test function's name or an empty string.
+func nearestTest(aSkip int) string {
+	if !inTestBinary() {
+		return ""
+	}
+
+	for _, frame := range callerFrames(aSkip + 1) {
+		if !strings.HasSuffix(frame.File, "_test.go") {
+			continue
+		}
+		if name := testFunction(frame.Function); "" != name {
+			return name
+		}
+	}
+
+	return ""
+} // nearestTest()
+
+// `WithTest()` returns the given error annotated with the name of the
+// given test (including the names of subtests), e.g. in a helper of
+// table driven tests:
+//
+//	if err := load(tt.file); nil != err {
+//		return sourceerror.WithTest(err, t)
+//	}
+//
+// In test binaries errors are annotated automatically with the name of
+// the nearest test function on the stack (see the `Test` field); this
+// function provides the full name as known to the `testing` package.
+//
+// If `aErr` is an `ErrSource` a copy of it is annotated; otherwise
+// `aErr` is wrapped like by `Wrap()` first.
+//
+// Parameters:
+// - `aErr`: The error to annotate.
+// - `aTest`: The test (e.g. a `*testing.T`) producing the error.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func WithTest(aErr error, aTest interface{ Name() string }) error {
+	return annotate(aErr, 1, func(aSource *ErrSource) {
+		if nil != aTest {
+			aSource.Test = aTest.Name()
+		}
+	})
+} // WithTest()
+
+/* _EoF_ */
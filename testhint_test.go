@@ -0,0 +1,69 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `testDeepHelper()` wraps an error several calls below the test.
+func testDeepHelper(aDepth int) error {
+	if 0 < aDepth {
+		return testDeepHelper(aDepth - 1)
+	}
+
+	return Wrap(errors.New("deep error"), 0)
+} // testDeepHelper()
+
+func Test_testFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		want     string
+	}{
+		{"0", "example.com/app.TestLoad", "TestLoad"},
+		{"1", "example.com/app.TestLoad.func1.2", "TestLoad"},
+		{"2", "example.com/app/v2.BenchmarkLoad", "BenchmarkLoad"},
+		{"3", "example.com/app.(*T).TestLoad", ""},
+		{"4", "example.com/app.helper", ""},
+		{"5", "main", ""},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testFunction(tt.function); got != tt.want {
+				t.Errorf("%q: testFunction() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_testFunction()
+
+func Test_nearestTest(t *testing.T) {
+	if !inTestBinary() {
+		t.Skip("not run by a test binary")
+	}
+	t.Run("table", func(t *testing.T) {
+		se := testDeepHelper(5).(*ErrSource)
+		if "Test_nearestTest" != se.Test {
+			t.Errorf("ErrSource.Test = %q, want %q", se.Test, "Test_nearestTest")
+		}
+
+		se = WithTest(se, t).(*ErrSource)
+		if "Test_nearestTest/table" != se.Test {
+			t.Errorf("WithTest() = %q, want %q", se.Test, "Test_nearestTest/table")
+		}
+	})
+	if nil != WithTest(nil, t) {
+		t.Error("WithTest(nil) != nil")
+	}
+} // Test_nearestTest()
+
+/* _EoF_ */
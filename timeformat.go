@@ -0,0 +1,58 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"strconv"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `TimeFormatUnixMilli` renders timestamps as milliseconds since
+	// the Unix epoch instead of using a `time` layout.
+	TimeFormatUnixMilli = "unixmilli"
+)
+
+var (
+	// The layout (see `time.Layout`) or `TimeFormatUnixMilli` used to
+	// render timestamps, e.g. by `Store.ServeHTTP()`.
+	TimeFormat = time.RFC3339
+
+	// The time zone used to render timestamps; `nil` means local time.
+	TimeLocation *time.Location
+)
+
+// `formatTime()` renders the given timestamp.
+//
+// Parameters:
+// - `aTime`: The timestamp to render.
+// - `aLayout`: The layout to use; empty means the global `TimeFormat`.
+// - `aLocation`: The time zone to use; `nil` means the global
+// `TimeLocation`.
+//
+// Returns:
+// - `string`: The rendered timestamp.
+func formatTime(aTime time.Time, aLayout string, aLocation *time.Location) string {
+	if "" == aLayout {
+		aLayout = TimeFormat
+	}
+	if TimeFormatUnixMilli == aLayout {
+		return strconv.FormatInt(aTime.UnixMilli(), 10)
+	}
+	if nil == aLocation {
+		aLocation = TimeLocation
+	}
+	if nil != aLocation {
+		aTime = aTime.In(aLocation)
+	}
+
+	return aTime.Format(aLayout)
+} // formatTime()
+
+/* _EoF_ */
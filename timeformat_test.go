@@ -0,0 +1,47 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_formatTime(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	berlin := time.FixedZone("CET", 3600)
+
+	tests := []struct {
+		name     string
+		layout   string
+		location *time.Location
+		global   *time.Location
+		want     string
+	}{
+		{"0", "", time.UTC, nil, "2024-03-01T12:30:00Z"},
+		{"1", "", berlin, nil, "2024-03-01T13:30:00+01:00"},
+		{"2", "", nil, berlin, "2024-03-01T13:30:00+01:00"},
+		{"3", time.DateTime, time.UTC, berlin, "2024-03-01 12:30:00"},
+		{"4", TimeFormatUnixMilli, berlin, nil, "1709296200000"},
+		// TODO: Add test cases.
+	}
+	defer func(aLoc *time.Location) {
+		TimeLocation = aLoc
+	}(TimeLocation)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			TimeLocation = tt.global
+			if got := formatTime(ts, tt.layout, tt.location); got != tt.want {
+				t.Errorf("%q: formatTime() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_formatTime()
+
+/* _EoF_ */
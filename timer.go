@@ -0,0 +1,135 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"context"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tTimerKey` is the context key of the start time set by `Timer()`.
+	tTimerKey struct{}
+)
+
+// `Timer()` returns a copy of the given context recording the current
+// time as the start of an operation whose duration should be reported
+// if it fails (see `StopTimer()`):
+//
+//	ctx = sourceerror.Timer(ctx)
+//	if err := fetch(ctx); nil != err {
+//		return sourceerror.StopTimer(ctx, err)
+//	}
+//
+// Parameters:
+// - `aCtx`: The context of the operation to time.
+//
+// Returns:
+// - `context.Context`: The context carrying the operation's start time.
+func Timer(aCtx context.Context) context.Context {
+	return context.WithValue(aCtx, tTimerKey{}, time.Now())
+} // Timer()
+
+// `StopTimer()` returns the given error annotated with the time elapsed
+// since `Timer()` was called for the given context.
+//
+// If the context doesn't carry a start time the error is annotated
+// like by `WithDuration()` with a zero duration, i.e. just wrapped.
+//
+// Parameters:
+// - `aCtx`: The context returned by `Timer()`.
+// - `aErr`: The error of the failed operation.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func StopTimer(aCtx context.Context, aErr error) error {
+	var elapsed time.Duration
+	if start, ok := aCtx.Value(tTimerKey{}).(time.Time); ok {
+		elapsed = time.Since(start)
+	}
+
+	return withDuration(aErr, elapsed, 1)
+} // StopTimer()
+
+// `WithDuration()` returns the given error annotated with the given
+// duration of the failed operation.
+//
+// If `aErr` is an `ErrSource` a copy of it is annotated; otherwise
+// `aErr` is wrapped like by `Wrap()` first.
+//
+// Parameters:
+// - `aErr`: The error of the failed operation.
+// - `aDuration`: How long the operation ran before failing.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func WithDuration(aErr error, aDuration time.Duration) error {
+	return withDuration(aErr, aDuration, 1)
+} // WithDuration()
+
+// `annotate()` returns a copy of the given error annotated by the
+// given function.
+//
+// If `aErr` is an `ErrSource` a copy of it is annotated; otherwise
+// `aErr` is wrapped like by `Wrap()` first.
+//
+// Parameters:
+// - `aErr`: The error to annotate.
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `annotate()`.
+// - `aAnnotate`: The function setting the annotation.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func annotate(aErr error, aSkip int, aAnnotate func(*ErrSource)) error {
+	var (
+		result *ErrSource
+		fresh  bool // whether `result` wraps a plain error
+	)
+
+	switch se := aErr.(type) {
+	case nil:
+		return nil
+	case *ErrSource:
+		if nil == se {
+			return aErr
+		}
+		clone := *se
+		result = &clone
+	case ErrSource:
+		result = &se
+	default:
+		result, fresh = buildSource(aErr, 0, aSkip+1, defaultConfig.TopFrame()), true
+	}
+	aAnnotate(result)
+	if fresh {
+		// publish the new instance once it's complete
+		notifyWrapHooks(result)
+	}
+
+	return result
+} // annotate()
+
+// `withDuration()` implements `WithDuration()` and `StopTimer()`.
+//
+// Parameters:
+// - `aErr`: The error of the failed operation.
+// - `aDuration`: How long the operation ran before failing.
+// - `aSkip`: The number of stack frames to skip with `0` identifying
+// the caller of `withDuration()`.
+//
+// Returns:
+// - `error`: The annotated error or `nil` if `aErr` is `nil`.
+func withDuration(aErr error, aDuration time.Duration, aSkip int) error {
+	return annotate(aErr, aSkip+1, func(aSource *ErrSource) {
+		aSource.Elapsed = aDuration
+	})
+} // withDuration()
+
+/* _EoF_ */
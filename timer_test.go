@@ -0,0 +1,96 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestStopTimer(t *testing.T) {
+	e := errors.New("some first error")
+	ctx := Timer(context.Background())
+	time.Sleep(2 * time.Millisecond)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		err         error
+		wantElapsed bool
+	}{
+		{"0", ctx, nil, false},
+		{"1", context.Background(), e, false},
+		{"2", ctx, e, true},
+		{"3", ctx, Wrap(e, 0), true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StopTimer(tt.ctx, tt.err)
+			if nil == tt.err {
+				if nil != got {
+					t.Errorf("%q: StopTimer() = %v, want <nil>", tt.name, got)
+				}
+				return
+			}
+			se, ok := got.(*ErrSource)
+			if !ok {
+				t.Errorf("%q: StopTimer() = %T, want *ErrSource", tt.name, got)
+				return
+			}
+			if gotElapsed := (2*time.Millisecond <= se.Elapsed); gotElapsed != tt.wantElapsed {
+				t.Errorf("%q: StopTimer() elapsed = %v, want %v",
+					tt.name, se.Elapsed, tt.wantElapsed)
+			}
+			if !strings.HasSuffix(se.File, "timer_test.go") {
+				t.Errorf("%q: StopTimer() file = %q, want %q",
+					tt.name, se.File, "timer_test.go")
+			}
+		})
+	}
+} // TestStopTimer()
+
+func TestWithDuration(t *testing.T) {
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0).(*ErrSource)
+
+	tests := []struct {
+		name     string
+		err      error
+		duration time.Duration
+	}{
+		{"0", e, time.Second},
+		{"1", cl1, 2 * time.Second},
+		{"2", *cl1, 3 * time.Second},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WithDuration(tt.err, tt.duration).(*ErrSource)
+			if got.Elapsed != tt.duration {
+				t.Errorf("%q: WithDuration() elapsed = %v, want %v",
+					tt.name, got.Elapsed, tt.duration)
+			}
+			if want := "Elapsed: " + tt.duration.String(); !strings.Contains(got.String(), want) {
+				t.Errorf("%q: WithDuration() = %s\nwant %q", tt.name, got, want)
+			}
+			if !errors.Is(got, e) {
+				t.Errorf("%q: WithDuration() lost the wrapped error", tt.name)
+			}
+		})
+	}
+	if 0 != cl1.Elapsed {
+		t.Errorf("WithDuration() modified the original error")
+	}
+} // TestWithDuration()
+
+/* _EoF_ */
@@ -0,0 +1,112 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `VerbosityDefault` removes a package's verbosity set by
+	// `SetVerbosity()` so that the sinks' own verbosity applies again.
+	VerbosityDefault Verbosity = -1
+)
+
+var (
+	// The verbosities set by `SetVerbosity()` indexed by package prefix.
+	pkgVerbosities = make(map[string]Verbosity)
+
+	// The number of entries in `pkgVerbosities` (checked without locking).
+	pkgVerbosityCount atomic.Int32
+
+	// Guard for `pkgVerbosities`.
+	pkgVerbosityMtx sync.RWMutex
+)
+
+// `allStacks()` returns the call stacks of all goroutines.
+//
+// Returns:
+// - `string`: The goroutines' call stacks.
+func allStacks() string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+} // allStacks()
+
+// `packageVerbosity()` returns the verbosity to render the given error
+// with.
+//
+// Parameters:
+// - `aErr`: The error to render.
+// - `aVerbosity`: The verbosity to use if none is set for the
+// error's package.
+//
+// Returns:
+// - `Verbosity`: The verbosity set for the package of the function
+// wrapping the error or `aVerbosity`.
+func packageVerbosity(aErr error, aVerbosity Verbosity) Verbosity {
+	if 0 == pkgVerbosityCount.Load() {
+		return aVerbosity
+	}
+	se, ok := asSource(aErr)
+	if !ok || ("" == se.Function) {
+		return aVerbosity
+	}
+
+	prefix := ""
+	pkgVerbosityMtx.RLock()
+	defer pkgVerbosityMtx.RUnlock()
+	for pkg, verbosity := range pkgVerbosities {
+		if (len(pkg) > len(prefix)) && inModule(se.Function, pkg) {
+			prefix, aVerbosity = pkg, verbosity
+		}
+	}
+
+	return aVerbosity
+} // packageVerbosity()
+
+// `SetVerbosity()` sets the verbosity `Report()` renders the errors
+// wrapped within the given package (or its sub-packages) with,
+// regardless of the sinks' verbosity.
+//
+// This allows to crank up the diagnostics for the subsystem under
+// investigation only while keeping the other errors' reports short:
+//
+//	sourceerror.SetVerbosity("example.com/app/billing", sourceerror.VerbosityDebug)
+//	sourceerror.SetVerbosity("example.com/app/cache", sourceerror.VerbosityCompact)
+//
+// If several prefixes match an error's package, the longest one wins.
+//
+// Parameters:
+// - `aPkgPrefix`: The package's import path, e.g. "example.com/app";
+// a trailing slash is ignored.
+// - `aLevel`: The verbosity to use or `VerbosityDefault` to remove
+// the package's verbosity.
+func SetVerbosity(aPkgPrefix string, aLevel Verbosity) {
+	aPkgPrefix = strings.TrimSuffix(aPkgPrefix, "/")
+	pkgVerbosityMtx.Lock()
+	defer pkgVerbosityMtx.Unlock()
+
+	if VerbosityDefault == aLevel {
+		delete(pkgVerbosities, aPkgPrefix)
+	} else {
+		pkgVerbosities[aPkgPrefix] = aLevel
+	}
+	pkgVerbosityCount.Store(int32(len(pkgVerbosities)))
+} // SetVerbosity()
+
+/* _EoF_ */
@@ -0,0 +1,76 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_packageVerbosity(t *testing.T) {
+	const pkg = "github.com/mwat56/sourceerror"
+	e := errors.New("some first error")
+	cl1 := Wrap(e, 0)
+	defer SetVerbosity("github.com/mwat56", VerbosityDefault)
+	defer SetVerbosity(pkg, VerbosityDefault)
+
+	tests := []struct {
+		name   string
+		prefix string
+		level  Verbosity
+		err    error
+		want   Verbosity
+	}{
+		{"0", "", VerbosityDefault, cl1, VerbosityMedium},
+		{"1", "github.com/mwat56", VerbosityFull, cl1, VerbosityFull},
+		{"2", pkg, VerbosityCompact, cl1, VerbosityCompact},
+		{"3", pkg, VerbosityCompact, e, VerbosityMedium},
+		{"4", pkg, VerbosityDefault, cl1, VerbosityFull},
+		{"5", "github.com/mwat", VerbosityDebug, cl1, VerbosityFull},
+		{"6", "github.com/mwat56/", VerbosityCompact, cl1, VerbosityCompact},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetVerbosity(tt.prefix, tt.level)
+			if got := packageVerbosity(tt.err, VerbosityMedium); got != tt.want {
+				t.Errorf("%q: packageVerbosity() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+	SetVerbosity("github.com/mwat", VerbosityDefault)
+} // Test_packageVerbosity()
+
+func TestSetVerbosity(t *testing.T) {
+	var sb strings.Builder
+	defer RegisterSink(&sb, VerbosityCompact)()
+	cl1 := Wrap(errors.New("some first error"), 0)
+
+	SetVerbosity("github.com/mwat56/sourceerror", VerbosityDebug)
+	err := Report(cl1)
+	SetVerbosity("github.com/mwat56/sourceerror", VerbosityDefault)
+	if nil != err {
+		t.Fatalf("Report() = %v, want <nil>", err)
+	}
+	if got := sb.String(); !strings.Contains(got, "\nGoroutines:\n") {
+		t.Errorf("Report() =\n%s\nwant all goroutines", got)
+	}
+
+	sb.Reset()
+	if err := Report(cl1); nil != err {
+		t.Fatalf("Report() = %v, want <nil>", err)
+	}
+	if got := strings.Count(sb.String(), "\n"); 1 != got {
+		t.Errorf("Report() = %d lines, want 1:\n%s", got, sb.String())
+	}
+} // TestSetVerbosity()
+
+/* _EoF_ */
@@ -0,0 +1,81 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `warmFunc()` makes the runtime load the symbol data of the given
+// function.
+//
+// Parameters:
+// - `aEntry`: The function's entry address.
+func warmFunc(aEntry uintptr) {
+	fn := runtime.FuncForPC(aEntry)
+	if nil == fn {
+		return
+	}
+	_, _ = fn.FileLine(aEntry)
+	_ = demangle(fn.Name())
+} // warmFunc()
+
+// `Warmup()` resolves the data needed to wrap errors in advance, so
+// that the first error of a latency-sensitive service doesn't pay for
+// it on the hot path:
+//
+//	func main() {
+//		_ = sourceerror.Warmup(handleOrder, handlePayment)
+//		// ...
+//	}
+//
+// It reads the binary's build info, symbolises the frames of the
+// calling goroutine's stack (which are cached, see `SymbolizePCs()`),
+// and makes the runtime load the symbol data of the given arguments
+// which may be
+// - functions (e.g. a package's entry points), or
+// - program counters (`uintptr` or `[]uintptr`, e.g. taken from a
+// profile) which are symbolised and cached like the caller's stack.
+//
+// Parameters:
+// - `aFuncs`: The functions and program counters to resolve.
+//
+// Returns:
+// - `error`: The joined errors for the unsupported arguments.
+func Warmup(aFuncs ...any) error {
+	_ = dependencyVersions()
+	_ = inTestBinary()
+	_ = debug.Stack()
+	_ = SymbolizePCs(callerPCs(1))
+
+	var errs []error
+	for idx, arg := range aFuncs {
+		switch value := arg.(type) {
+		case uintptr:
+			_ = symbolize(value)
+		case []uintptr:
+			_ = SymbolizePCs(value)
+		default:
+			if rv := reflect.ValueOf(arg); reflect.Func == rv.Kind() {
+				warmFunc(rv.Pointer())
+				continue
+			}
+			errs = append(errs, fmt.Errorf(
+				"unsupported Warmup() argument #%d of type %T", idx, arg))
+		}
+	}
+
+	return errors.Join(errs...)
+} // Warmup()
+
+/* _EoF_ */
@@ -0,0 +1,51 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"runtime"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestWarmup(t *testing.T) {
+	var (
+		nilFunc func()
+		pcs     = make([]uintptr, 8)
+	)
+	pcs = pcs[:runtime.Callers(1, pcs)]
+
+	tests := []struct {
+		name    string
+		funcs   []any
+		wantErr bool
+	}{
+		{"0", nil, false},
+		{"1", []any{Wrap, TestWarmup, nilFunc}, false},
+		{"2", []any{pcs, pcs[0]}, false},
+		{"3", []any{Wrap, "github.com/mwat56/sourceerror"}, true},
+		{"4", []any{nil}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Warmup(tt.funcs...); (nil != err) != tt.wantErr {
+				t.Errorf("%q: Warmup() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+		})
+	}
+
+	for _, pc := range pcs {
+		if _, ok := pcFrames.Load(pc); !ok {
+			t.Errorf("Warmup() didn't cache the frames of PC %#x", pc)
+		}
+	}
+} // TestWarmup()
+
+/* _EoF_ */
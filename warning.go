@@ -0,0 +1,134 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `Warning` is a non-fatal finding (e.g. of a linter or validator)
+	// along with the location where it was raised.
+	//
+	// A `Warning` deliberately doesn't implement the `error` interface
+	// so it can't be confused with an error by `errors.Is()`,
+	// `errors.As()`, or a `nil != err` check.
+	//
+	// The fields are as follows:
+	// - `Location`: Where the warning was raised.
+	// - `Message`: The warning's text.
+	Warning struct {
+		Location
+		Message string
+	}
+
+	// `tWarnings` collects the warnings raised with a context.
+	tWarnings struct {
+		mtx  sync.Mutex
+		list []Warning
+	}
+
+	// `tWarningsKey` is the context key of the warnings collector.
+	tWarningsKey struct{}
+)
+
+const (
+	// How to build the string representation of a warning:
+	warningPattern = "%s: warning: %s"
+)
+
+// `String()` implements the `Stringer` interface and returns the
+// warning in the common "file:line: warning: message" notation.
+//
+// Returns:
+// - `string`: The warning's string representation.
+func (w Warning) String() string {
+	if "" == w.File {
+		return "warning: " + w.Message
+	}
+
+	return fmt.Sprintf(warningPattern, w.Location, w.Message)
+} // String()
+
+// --------------------------------------------------------------------------
+
+// `Warn()` raises a warning at the location of its caller (selected
+// by the `DefaultConfig()`'s top frame strategy) and adds it to the collector of
+// the given context (if any, see `WithWarnings()`).
+//
+// NOTE: If locations are disabled (see `Config.SetEnabled()`), the
+// warning's location remains empty.
+//
+// Parameters:
+// - `aCtx`: The context carrying the warnings collector.
+// - `aFormat`: The format string of the warning's text.
+// - `aArgs`: The arguments for `aFormat`.
+//
+// Returns:
+// - `Warning`: The raised warning.
+func Warn(aCtx context.Context, aFormat string, aArgs ...any) Warning {
+	result := Warning{
+		Message: fmt.Sprintf(aFormat, aArgs...),
+	}
+	if !defaultConfig.noDebug() {
+		result.Location, _ = callerLocation(1, defaultConfig.TopFrame())
+	}
+
+	if collector, ok := aCtx.Value(tWarningsKey{}).(*tWarnings); ok {
+		collector.mtx.Lock()
+		collector.list = append(collector.list, result)
+		collector.mtx.Unlock()
+	}
+
+	return result
+} // Warn()
+
+// `Warnings()` returns the warnings raised so far with the given
+// context (see `Warn()`), in the order they were raised.
+//
+// Parameters:
+// - `aCtx`: The context carrying the warnings collector.
+//
+// Returns:
+// - `[]Warning`: The collected warnings (if any).
+func Warnings(aCtx context.Context) []Warning {
+	collector, ok := aCtx.Value(tWarningsKey{}).(*tWarnings)
+	if !ok {
+		return nil
+	}
+	collector.mtx.Lock()
+	defer collector.mtx.Unlock()
+
+	return slices.Clone(collector.list)
+} // Warnings()
+
+// `WithWarnings()` returns a copy of the given context carrying a new
+// collector for the warnings raised by `Warn()`.
+//
+// Example:
+//
+//	ctx = sourceerror.WithWarnings(ctx)
+//	validate(ctx, cfg) // calls sourceerror.Warn(ctx, …)
+//	for _, w := range sourceerror.Warnings(ctx) {
+//		log.Println(w)
+//	}
+//
+// Parameters:
+// - `aCtx`: The parent context.
+//
+// Returns:
+// - `context.Context`: The context carrying the warnings collector.
+func WithWarnings(aCtx context.Context) context.Context {
+	return context.WithValue(aCtx, tWarningsKey{}, &tWarnings{})
+} // WithWarnings()
+
+/* _EoF_ */
@@ -0,0 +1,57 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestWarn(t *testing.T) {
+	ctx := WithWarnings(context.Background())
+	w1 := Warn(ctx, "unused key %q", "colour")
+	w2 := Warn(context.Background(), "not collected")
+	w3 := Warn(ctx, "deprecated setting")
+
+	tests := []struct {
+		name    string
+		warning Warning
+		wantMsg string
+	}{
+		{"1", w1, `unused key "colour"`},
+		{"2", w2, "not collected"},
+		{"3", w3, "deprecated setting"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.warning.Message != tt.wantMsg {
+				t.Errorf("%q: Warn() message = %q, want %q",
+					tt.name, tt.warning.Message, tt.wantMsg)
+			}
+			if tt.warning.Function != thisPackage+".TestWarn" {
+				t.Errorf("%q: Warn() function = %q, want %q",
+					tt.name, tt.warning.Function, thisPackage+".TestWarn")
+			}
+			if str := tt.warning.String(); !strings.Contains(str, "warning_test.go:") ||
+				!strings.HasSuffix(str, ": warning: "+tt.wantMsg) {
+				t.Errorf("%q: Warning.String() = %q", tt.name, str)
+			}
+		})
+	}
+	if got := Warnings(ctx); (2 != len(got)) || (got[0] != w1) || (got[1] != w3) {
+		t.Errorf("Warnings() = %v, want [%v %v]", got, w1, w3)
+	}
+	if got := Warnings(context.Background()); nil != got {
+		t.Errorf("Warnings() = %v, want <nil>", got)
+	}
+} // TestWarn()
+
+/* _EoF_ */
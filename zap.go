@@ -0,0 +1,146 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// The suffix zap appends to an error field's key for the error's
+	// verbose ("%+v") representation.
+	zapVerboseSuffix = "Verbose"
+)
+
+var (
+	// Error returned if a log entry doesn't contain a verbose error.
+	errNoZapError = errors.New("no verbose error found")
+)
+
+// `parseVerbose()` parses an error's verbose representation as
+// written by `github.com/pkg/errors`, i.e. the messages of the chain's
+// layers each followed by the call stack recorded by the layer.
+//
+// Parameters:
+// - `aText`: The error's verbose representation.
+//
+// Returns:
+// - `[]Frame`: The call stack recorded by the chain's innermost layer.
+func parseVerbose(aText string) []Frame {
+	var (
+		result   []Frame
+		function string
+	)
+	for _, line := range strings.Split(aText, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if file, lineNo, ok := parseFileLine(line); ok {
+			if "" != function {
+				result = append(result, Frame{
+					File:     file,
+					Function: parseFunction(function),
+					Line:     lineNo,
+				})
+			}
+			function = ""
+			continue
+		}
+		if (0 < len(result)) && ("" != function) {
+			// two non-location lines: the next layer's message
+			break
+		}
+		function = line
+	}
+
+	return result
+} // parseVerbose()
+
+// `ParseZapEntry()` reconstructs an error from a JSON log entry
+// written by `go.uber.org/zap` for an error created by
+// `github.com/pkg/errors`, e.g.
+//
+//	{"level":"error","msg":"load failed","error":"open x: no such file",
+//	 "errorVerbose":"open x: no such file\nmain.load\n\t/src/main.go:12\n..."}
+//
+// The error's message is taken from the error field while the call
+// stack is taken from its verbose companion field; if the error was
+// wrapped several times, the stack recorded by the innermost layer is
+// used as it's the one closest to the error's origin.
+//
+// Parameters:
+// - `aEntry`: The log entry to parse.
+//
+// Returns:
+// - `*Remote`: The reconstructed error.
+// - `error`: An error if the entry isn't valid JSON or doesn't
+// contain a verbose error.
+func ParseZapEntry(aEntry []byte) (*Remote, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(aEntry, &fields); nil != err {
+		return nil, err
+	}
+
+	key, verbose := "error", ""
+	if text, ok := fields[key+zapVerboseSuffix].(string); ok {
+		verbose = text
+	} else {
+		for name, value := range fields {
+			text, ok := value.(string)
+			if ok && strings.HasSuffix(name, zapVerboseSuffix) {
+				key, verbose = strings.TrimSuffix(name, zapVerboseSuffix), text
+				break
+			}
+		}
+	}
+	if "" == verbose {
+		return nil, errNoZapError
+	}
+
+	result := &Remote{
+		Frames: parseVerbose(verbose),
+	}
+	if message, ok := fields[key].(string); ok {
+		result.Message = message
+	} else {
+		result.Message, _, _ = strings.Cut(verbose, "\n")
+	}
+
+	return result, nil
+} // ParseZapEntry()
+
+// `ParseZapLog()` reconstructs the errors of a zap JSON log (see
+// `ParseZapEntry()`), e.g. to normalise historical logs of services
+// using `github.com/pkg/errors`.
+//
+// Lines which aren't JSON or don't contain a verbose error are skipped.
+//
+// Parameters:
+// - `aReader`: The source to read the log from.
+//
+// Returns:
+// - `[]*Remote`: The reconstructed errors in the log's order.
+// - `error`: A possible reading error.
+func ParseZapLog(aReader io.Reader) ([]*Remote, error) {
+	scanner := bufio.NewScanner(aReader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var result []*Remote
+	for scanner.Scan() {
+		if remote, err := ParseZapEntry(scanner.Bytes()); nil == err {
+			result = append(result, remote)
+		}
+	}
+
+	return result, scanner.Err()
+} // ParseZapLog()
+
+/* _EoF_ */
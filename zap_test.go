@@ -0,0 +1,88 @@
+/*
+Copyright © 2024  M.Watermann, 10247 Berlin, Germany
+
+	    All rights reserved
+	EMail : <support@mwat.de>
+*/
+package sourceerror
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// a zap log with errors created by `github.com/pkg/errors`
+	testZapLog = `{"level":"info","msg":"starting"}
+{"level":"error","msg":"load failed","error":"reading config: open x.ini: no such file","errorVerbose":"open x.ini: no such file\nexample.com/app/config.load\n\t/src/app/config/load.go:12\nmain.main\n\t/src/app/main.go:20\nruntime.main\n\t/usr/local/go/src/runtime/proc.go:271\nreading config\nexample.com/app/config.Read\n\t/src/app/config/read.go:30\nmain.main\n\t/src/app/main.go:20"}
+not JSON at all
+{"level":"error","msg":"save failed","cause":"disk full","causeVerbose":"disk full\nmain.(*Store).save\n\t/src/app/store.go:7"}
+{"level":"error","msg":"plain","error":"EOF"}
+`
+)
+
+func TestParseZapEntry(t *testing.T) {
+	lines := strings.Split(testZapLog, "\n")
+
+	tests := []struct {
+		name        string
+		entry       string
+		wantMessage string
+		wantFrames  []Frame
+		wantErr     bool
+	}{
+		{"0", lines[0], "", nil, true},
+		{"1", lines[1], "reading config: open x.ini: no such file", []Frame{
+			{"/src/app/config/load.go", "example.com/app/config.load", 12, 0},
+			{"/src/app/main.go", "main.main", 20, 0},
+			{"/usr/local/go/src/runtime/proc.go", "runtime.main", 271, 0},
+		}, false},
+		{"2", lines[2], "", nil, true},
+		{"3", lines[3], "disk full", []Frame{
+			{"/src/app/store.go", "main.(*Store).save", 7, 0},
+		}, false},
+		{"4", lines[4], "", nil, true},
+		{"5", `{"errorVerbose":"boom\nmain.main\n\t/src/main.go:3"}`, "boom", []Frame{
+			{"/src/main.go", "main.main", 3, 0},
+		}, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseZapEntry([]byte(tt.entry))
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("%q: ParseZapEntry() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Message != tt.wantMessage {
+				t.Errorf("%q: ParseZapEntry() message = %q, want %q",
+					tt.name, got.Message, tt.wantMessage)
+			}
+			if !reflect.DeepEqual(got.Frames, tt.wantFrames) {
+				t.Errorf("%q: ParseZapEntry() frames = %v, want %v",
+					tt.name, got.Frames, tt.wantFrames)
+			}
+		})
+	}
+} // TestParseZapEntry()
+
+func TestParseZapLog(t *testing.T) {
+	got, err := ParseZapLog(strings.NewReader(testZapLog))
+	if nil != err {
+		t.Fatalf("ParseZapLog() error = %v", err)
+	}
+	if 2 != len(got) {
+		t.Fatalf("ParseZapLog() = %d errors, want 2", len(got))
+	}
+	if "disk full" != got[1].Error() {
+		t.Errorf("ParseZapLog()[1] = %q, want %q", got[1].Error(), "disk full")
+	}
+} // TestParseZapLog()
+
+/* _EoF_ */